@@ -4,6 +4,8 @@ import (
 	"github.com/Vaixle/crud-golang/internal/app"
 	"github.com/Vaixle/crud-golang/internal/config"
 	"log"
+	// Time zones of recurring tasks resolve without tzdata on the host.
+	_ "time/tzdata"
 )
 
 func main() {
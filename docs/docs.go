@@ -23,85 +23,113 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/todo": {
+        "/me": {
             "get": {
-                "description": "Get todo tasks",
+                "description": "Get the user the request is authenticated as",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "users"
                 ],
-                "summary": "Get todo tasks",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "gt:1",
-                        "description": "greater than",
-                        "name": "filedName1",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "lt:1",
-                        "description": "lower than",
-                        "name": "filedName2",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "ge:1",
-                        "description": "greater and equal than",
-                        "name": "filedName3",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "le:1",
-                        "description": "lower and equal than",
-                        "name": "filedName4",
-                        "in": "query"
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.User"
+                        }
                     },
+                    "401": {
+                        "description": "{\"error\": \"no authenticated user\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/tasks": {
+            "get": {
+                "description": "Get the tasks assigned to the current user; takes the same filters as the task list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get my tasks",
+                "parameters": [
                     {
                         "type": "string",
-                        "example": "eq:1",
-                        "description": "equal",
-                        "name": "filedName5",
+                        "example": "2",
+                        "description": "page",
+                        "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "ne:1",
-                        "description": "not equal than",
-                        "name": "filedName6",
+                        "example": "3",
+                        "description": "limit",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "order_by:1",
-                        "description": "greater than",
-                        "name": "filedName7",
+                        "example": "id,description,status",
+                        "description": "comma separated fields to return",
+                        "name": "fields",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "like:1",
-                        "description": "like something",
-                        "name": "filedName8",
+                        "example": "tags",
+                        "description": "comma separated related resources to return: tags, assignees, comment_count",
+                        "name": "include",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                            }
+                        }
                     },
-                    {
-                        "type": "string",
-                        "example": "2",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "401": {
+                        "description": "{\"error\": \"no authenticated user\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "description": "Get projects ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Get projects",
+                "parameters": [
                     {
-                        "type": "string",
-                        "example": "3",
-                        "description": "limit",
-                        "name": "limit",
+                        "type": "boolean",
+                        "example": true,
+                        "description": "list archived projects too",
+                        "name": "include_archived",
                         "in": "query"
                     }
                 ],
@@ -111,7 +139,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Project"
                             }
                         }
                     },
@@ -124,7 +152,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create todo task",
+                "description": "Create project with optional defaults for the status and priority of its new tasks",
                 "consumes": [
                     "application/json"
                 ],
@@ -132,14 +160,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "projects"
+                ],
+                "summary": "Create project",
+                "parameters": [
+                    {
+                        "description": "Project",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Project"
+                        }
+                    }
                 ],
-                "summary": "Create todo task",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Project"
                         }
                     },
                     "400": {
@@ -151,20 +190,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/{id}": {
+        "/projects/{id}": {
             "get": {
-                "description": "Get todo task by id",
+                "description": "Get project by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "projects"
                 ],
-                "summary": "Get todo task",
+                "summary": "Get project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Todo task ID",
+                        "description": "Project ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -174,7 +213,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Project"
                         }
                     },
                     "400": {
@@ -182,54 +221,2937 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "github_com_Vaixle_crud-golang_internal_entity.Todo": {
-            "type": "object",
-            "required": [
-                "description",
-                "status"
-            ],
-            "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "some text"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string",
-                    "enum": [
-                        "open",
-                        "close"
-                    ],
-                    "example": "open/close"
-                },
-                "updatedAt": {
-                    "type": "string"
+            },
+            "patch": {
+                "description": "Update some fields of a project; archiving hides its tasks from the task list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Update project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.ProjectPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "gorm.DeletedAt": {
-            "type": "object",
-            "properties": {
-                "time": {
-                    "type": "string"
-                },
-                "valid": {
-                    "description": "Valid is true if Time is not NULL",
-                    "type": "boolean"
+        "/projects/{id}/todo": {
+            "get": {
+                "description": "Get the tasks of a project, archived or not; takes the same filters as the task list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Get project tasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "3",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "id,description,status",
+                        "description": "comma separated fields to return",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "tags",
+                        "description": "comma separated related resources to return: tags, assignees, comment_count",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a todo task in the project; status and priority default to those of the project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Create project task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Todo task",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "{\"error\": \"project is archived: 1\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Get all tags ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Tag"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create tag with a unique name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create tag",
+                "parameters": [
+                    {
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Tag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "{\"error\": \"tag already exists: \\\"bug\\\"\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "get": {
+                "description": "Get tag by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename tag; tasks keep it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Rename tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Tag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "{\"error\": \"tag already exists: \\\"bug\\\"\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete tag and remove it from every task",
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo": {
+            "get": {
+                "description": "Get todo tasks",
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/yaml",
+                    "application/msgpack",
+                    "text/csv"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get todo tasks",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "yaml",
+                            "xml",
+                            "msgpack",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response type, instead of Accept",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "gt:1",
+                        "description": "greater than",
+                        "name": "filedName1",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "lt:1",
+                        "description": "lower than",
+                        "name": "filedName2",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "ge:1",
+                        "description": "greater and equal than",
+                        "name": "filedName3",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "le:1",
+                        "description": "lower and equal than",
+                        "name": "filedName4",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eq:1",
+                        "description": "equal",
+                        "name": "filedName5",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "ne:1",
+                        "description": "not equal than",
+                        "name": "filedName6",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "order_by:1",
+                        "description": "greater than",
+                        "name": "filedName7",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "like:1",
+                        "description": "like something",
+                        "name": "filedName8",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "open tasks past their due date",
+                        "name": "overdue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "24h",
+                        "description": "tasks due from now within the duration",
+                        "name": "due_within",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "priority",
+                            "due_at"
+                        ],
+                        "type": "string",
+                        "description": "priority (then due date) or due_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "any:bug,urgent",
+                        "description": "tasks with any or all of the tag names",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "open tasks whose blockers are all closed",
+                        "name": "ready",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "me",
+                        "description": "tasks assigned to the user id, or to the current user with me",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "tasks nobody is assigned to",
+                        "name": "unassigned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "include tasks of archived projects",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "id,description,status",
+                        "description": "comma separated fields to return",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "tags",
+                        "description": "comma separated related resources to return: tags, assignees, comment_count",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "3",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "W/\"5d41402abc4b2a76b9719d911017c592\"",
+                        "description": "ETag of a page already held",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Weak tag of the page"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "{\"error\": \"not acceptable, available types: application/json, application/yaml, application/xml, application/msgpack, text/csv\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create todo task. A retry with the same Idempotency-Key and body gets the first response again, marked with Idempotent-Replayed, instead of creating another task.",
+                "consumes": [
+                    "application/json",
+                    "application/xml",
+                    "application/yaml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Create todo task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "5f0c1a4e-7f3b-4a8e-9a57-1f0e5f6c2b9d",
+                        "description": "Key that makes retries of the request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "{\"error\": \"a request with the idempotency key is in progress: \\\"5f0c\\\"\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "415": {
+                        "description": "{\"error\": \"unsupported media type: text/plain\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "{\"error\": \"idempotency key already used for a different request: \\\"5f0c\\\"\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/batch": {
+            "post": {
+                "description": "Apply a list of operations. By default they all succeed or none is applied, the response taking the status of the failed operation; with atomic=false each operation stands on its own and partial success is answered with 207. Each result carries the status the operation would have had on its own.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Batch create, update and delete todo tasks",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "example": false,
+                        "description": "Apply all operations or none",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "5f0c1a4e-7f3b-4a8e-9a57-1f0e5f6c2b9d",
+                        "description": "Key that makes retries of the request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Operations in the order they are applied",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1.batchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1.batchResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1.batchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "{\"error\": \"too many operations in the batch: 1500, the limit is 1000\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/export": {
+            "get": {
+                "description": "Stream every task matching the filters, whatever the page, as CSV, JSON Lines or a JSON array. Tasks come in id order; sort and order_by are ignored.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Export todo tasks",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eq:open",
+                        "description": "any filter of GET /todo",
+                        "name": "filedName1",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/import": {
+            "post": {
+                "description": "Create a task from each row of a CSV, JSON Lines or JSON array file, in the columns of an export; ids, versions and timestamps of the file are ignored. Rows are checked and created on their own: a rejected row does not stop the others. The summary lists why rows were rejected; with report=errors the response is instead the rejected rows, in the format of the file, with their row number and error.",
+                "consumes": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Import todo tasks",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "File format, by default taken from Content-Type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Title:description,State:status",
+                        "description": "Columns or keys of the file to read into task fields",
+                        "name": "map",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Only check the rows",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "summary",
+                            "errors"
+                        ],
+                        "type": "string",
+                        "description": "summary or the error file",
+                        "name": "report",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1.importSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1.importSummary"
+                        }
+                    },
+                    "415": {
+                        "description": "{\"error\": \"unknown file format: \\\"text/plain\\\"\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/order": {
+            "get": {
+                "description": "Get the given tasks ordered so that every task comes after the tasks blocking it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Order tasks by dependencies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3,1,2",
+                        "description": "comma separated task IDs",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "{\"error\": \"dependency cycle among tasks [1 2]\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}": {
+            "get": {
+                "description": "Get todo task by id; the ETag header carries the task version for If-Match and If-None-Match",
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/yaml",
+                    "application/msgpack",
+                    "text/csv"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get todo task",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "yaml",
+                            "xml",
+                            "msgpack",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response type, instead of Accept",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "id,description,status",
+                        "description": "comma separated fields to return",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "tags",
+                        "description": "comma separated related resources to return: tags, assignees, comment_count",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"3-1747d27d\"",
+                        "description": "ETag of the representation already held",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the version already held",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Task version"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the last change"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "{\"error\": \"not acceptable, available types: application/json, application/yaml, application/xml, application/msgpack, text/csv\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a todo task; it can be restored until it is purged. With purge=true the task, its comments and its attachments are deleted for good.",
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Delete todo task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Delete for good",
+                        "name": "purge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"3-1747d27d\"",
+                        "description": "ETag of the task version to delete",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "{\"error\": \"task has subtasks: 2 left\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "{\"error\": \"task version mismatch: 1\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update some fields of a todo task. A status change must be allowed by the workflow.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Update todo task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"3-1747d27d\"",
+                        "description": "ETag of the task version the change applies to",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.TodoPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "{\"error\": \"task has open children: 2\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "{\"error\": \"task version mismatch: 1 is at version 4\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/attachments": {
+            "get": {
+                "description": "Get the attachments of a todo task, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Get attachments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Attach a file to a todo task. The content type is sniffed from the content and checked, along with the size, against the configured limits.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Upload attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to attach",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "{\"error\": \"attachment too large: larger than 10485760 bytes\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "415": {
+                        "description": "{\"error\": \"unsupported attachment content type: application/zip\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/attachments/{attachment_id}": {
+            "get": {
+                "description": "Get the metadata of an attachment of a todo task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Get attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an attachment of a todo task",
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Delete attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/attachments/{attachment_id}/content": {
+            "get": {
+                "description": "Download the content of an attachment; Range and If-Range requests are supported",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Download attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "bytes=0-1023",
+                        "description": "Byte range",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "416": {
+                        "description": "invalid range: failed to overlap",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/blockers": {
+            "get": {
+                "description": "Get the tasks that must be closed before this one can start",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get blockers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Make a task wait for another one; dependencies that would form a loop are refused",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Add blocker",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Blocking task",
+                        "name": "blocker",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1.blockerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "{\"error\": \"dependency cycle: task 2 already depends on task 1\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/blockers/{blocker_id}": {
+            "delete": {
+                "description": "Stop a task from waiting for another one",
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Remove blocker",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Blocking task ID",
+                        "name": "blocker_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/blocking": {
+            "get": {
+                "description": "Get the tasks that cannot start before this one is closed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get blocked tasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/children": {
+            "get": {
+                "description": "Get the direct subtasks of a todo task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get subtasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/comments": {
+            "get": {
+                "description": "Get a page of the top-level comments of a todo task, oldest first, each with its replies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "3",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Comment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Comment on a todo task as the current user; set parent_id to reply to a top-level comment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Create comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Comment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "{\"error\": \"no authenticated user\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/comments/{comment_id}": {
+            "get": {
+                "description": "Get a comment of a todo task",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a comment and its replies; only its author may",
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "{\"error\": \"only the author can change a comment: comment 1\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change the body of a comment; only its author may",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Edit comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New body",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1.commentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "{\"error\": \"only the author can change a comment: comment 1\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/history": {
+            "get": {
+                "description": "Get a page of the audit log of a todo task, oldest first: who created, changed, deleted or restored it, when, and which fields changed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get task history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "3",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/restore": {
+            "post": {
+                "description": "Bring back a deleted todo task that has not been purged",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Restore todo task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/transitions": {
+            "get": {
+                "description": "Get the status changes of a todo task with who made them and when",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get todo task transitions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.TodoTransition"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/tree": {
+            "get": {
+                "description": "Get a todo task with all of its subtasks nested below it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get task tree",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.TodoNode"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Get every user that has signed in, ordered by username",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get user by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "{\"error\": \"some error message\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_Vaixle_crud-golang_internal_entity.Attachment": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "description": "ContentType is sniffed from the content rather than taken from the upload.",
+                    "type": "string",
+                    "example": "image/png"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filename": {
+                    "description": "Filename is the base name given by the uploader.",
+                    "type": "string",
+                    "example": "screenshot.png"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sha256": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 52431
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "uploaded_by": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.FieldChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "operation": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "description": "UserID is the authenticated user behind the change, nil for anonymous changes.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.BatchOperation": {
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ],
+                    "example": "update"
+                },
+                "patch": {
+                    "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.TodoPatch"
+                },
+                "task": {
+                    "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.Comment": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.User"
+                },
+                "author_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Looks good to me"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "description": "ParentID is the top-level comment this one replies to.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Comment"
+                    }
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "status"
+                },
+                "new": {
+                    "type": "string",
+                    "example": "\"done\""
+                },
+                "old": {
+                    "type": "string",
+                    "example": "\"open\""
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.Project": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "archived": {
+                    "description": "Archived hides the tasks of the project from the default task list.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "default_priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "normal"
+                },
+                "default_status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "API and workers"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "backend"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.ProjectPatch": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "default_priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "default_status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "API and workers"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 1,
+                    "example": "backend"
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.Tag": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "bug"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.Todo": {
+            "type": "object",
+            "required": [
+                "description"
+            ],
+            "properties": {
+                "assignee_ids": {
+                    "description": "AssigneeIDs assigns existing users on create; the assigned users are returned in Assignees.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.User"
+                    }
+                },
+                "blocked": {
+                    "description": "Blocked is set while any of the tasks blocking this one is not closed.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "comment_count": {
+                    "description": "CommentCount is the number of comments and replies on the task.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "CreatedBy and UpdatedBy are the users who created and last changed the task; nil for anonymous changes.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "some text"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2024-01-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "occurrence": {
+                    "description": "Occurrence is the position of the task in its series, starting at 1.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "normal"
+                },
+                "progress": {
+                    "description": "Progress is the percentage of closed subtasks at every level; nil for a task without subtasks.",
+                    "type": "integer",
+                    "example": 50
+                },
+                "project_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recurrence": {
+                    "description": "Recurrence is an RRULE subset counted from DueAt; closing the task creates its next occurrence.",
+                    "type": "string",
+                    "example": "FREQ=MONTHLY;BYMONTHDAY=1"
+                },
+                "series_id": {
+                    "description": "SeriesID is the first task of the series; unset on that task itself.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "tag_ids": {
+                    "description": "TagIDs assigns existing tags on create; the assigned tags are returned in Tags.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Tag"
+                    }
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA zone the recurrence is evaluated in, UTC when empty.",
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "version": {
+                    "description": "Version starts at 1 and is incremented on every write of the task; it is the ETag of the task.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.TodoNode": {
+            "type": "object",
+            "required": [
+                "description"
+            ],
+            "properties": {
+                "assignee_ids": {
+                    "description": "AssigneeIDs assigns existing users on create; the assigned users are returned in Assignees.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.User"
+                    }
+                },
+                "blocked": {
+                    "description": "Blocked is set while any of the tasks blocking this one is not closed.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.TodoNode"
+                    }
+                },
+                "comment_count": {
+                    "description": "CommentCount is the number of comments and replies on the task.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "CreatedBy and UpdatedBy are the users who created and last changed the task; nil for anonymous changes.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "some text"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2024-01-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "occurrence": {
+                    "description": "Occurrence is the position of the task in its series, starting at 1.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "normal"
+                },
+                "progress": {
+                    "description": "Progress is the percentage of closed subtasks at every level; nil for a task without subtasks.",
+                    "type": "integer",
+                    "example": 50
+                },
+                "project_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recurrence": {
+                    "description": "Recurrence is an RRULE subset counted from DueAt; closing the task creates its next occurrence.",
+                    "type": "string",
+                    "example": "FREQ=MONTHLY;BYMONTHDAY=1"
+                },
+                "series_id": {
+                    "description": "SeriesID is the first task of the series; unset on that task itself.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "tag_ids": {
+                    "description": "TagIDs assigns existing tags on create; the assigned tags are returned in Tags.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Tag"
+                    }
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA zone the recurrence is evaluated in, UTC when empty.",
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "version": {
+                    "description": "Version starts at 1 and is incremented on every write of the task; it is the ETag of the task.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.TodoPatch": {
+            "type": "object",
+            "properties": {
+                "assignee_ids": {
+                    "description": "AssigneeIDs replaces the task assignees; an empty list unassigns everyone.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "some text"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2024-01-01T12:00:00Z"
+                },
+                "parent_id": {
+                    "description": "ParentID moves the task under another one; 0 makes it a top-level task.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "normal",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "project_id": {
+                    "description": "ProjectID moves the task to another project; 0 takes it out of any project.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "recurrence": {
+                    "description": "Recurrence replaces the recurrence rule; an empty string stops the task from recurring.",
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "in_progress"
+                },
+                "tag_ids": {
+                    "description": "TagIDs replaces the task tags; an empty list removes them all.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.TodoTransition": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "todo_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Vaixle_crud-golang_internal_entity.User": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_controller_http_v1.batchItem": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "task not found"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "op": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "task": {
+                    "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.Todo"
+                }
+            }
+        },
+        "internal_controller_http_v1.batchRequest": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "operations": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_Vaixle_crud-golang_internal_entity.BatchOperation"
+                    }
+                }
+            }
+        },
+        "internal_controller_http_v1.batchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_http_v1.batchItem"
+                    }
+                }
+            }
+        },
+        "internal_controller_http_v1.blockerRequest": {
+            "type": "object",
+            "required": [
+                "blocker_id"
+            ],
+            "properties": {
+                "blocker_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "internal_controller_http_v1.commentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Looks good to me"
+                }
+            }
+        },
+        "internal_controller_http_v1.importError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "unknown status \"later\""
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "internal_controller_http_v1.importSummary": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer",
+                    "example": 118
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "error": {
+                    "type": "string",
+                    "example": "unexpected EOF"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_http_v1.importError"
+                    }
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
                 }
             }
         }
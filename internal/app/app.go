@@ -1,58 +1,235 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"github.com/Vaixle/crud-golang/internal/controller/http/admin"
 	"github.com/Vaixle/crud-golang/internal/controller/http/v1"
+	"github.com/Vaixle/crud-golang/internal/controller/metrics"
 	"github.com/Vaixle/crud-golang/internal/repository"
 	"github.com/Vaixle/crud-golang/internal/usecase"
 	"github.com/Vaixle/crud-golang/migration"
 	"github.com/Vaixle/crud-golang/pkg/db/postgres"
+	"github.com/Vaixle/crud-golang/pkg/health"
 	"github.com/Vaixle/crud-golang/pkg/httpserver"
+	"github.com/Vaixle/crud-golang/pkg/lifecycle"
 	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/Vaixle/crud-golang/pkg/tracing"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
-	"os"
-	"os/signal"
-	"syscall"
+	"net/http"
+	"time"
+)
+
+// Components start in ascending priority and stop in reverse: readiness, HTTP, workers, database, tracing.
+const (
+	_priorityTracing = iota * 10
+	_priorityDatabase
+	_priorityWorkers
+	_priorityAuxServers
+	_priorityHTTPServer
+	_priorityReadiness
 )
 
 func Run() {
 	l := logger.New(viper.GetString("logger.log_level"))
 
+	// Tracing
+	tp, err := tracing.New(
+		tracing.Exporter(viper.GetString("tracing.exporter")),
+		tracing.Endpoint(viper.GetString("tracing.endpoint"), viper.GetBool("tracing.insecure")),
+		tracing.ServiceName(viper.GetString("tracing.service_name")),
+		tracing.SampleRatio(viper.GetFloat64("tracing.sample_ratio")),
+	)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - tracing.New: %w", err))
+	}
+
+	// Metrics
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
 	// Database
 	pg, err := postgres.New()
 	if err != nil {
 		l.Fatal(fmt.Errorf("app - Run - postgres.New: %w", err))
 	}
 
+	if err = pg.RegisterMetrics(reg); err != nil {
+		l.Fatal(fmt.Errorf("app - Run - pg.RegisterMetrics: %w", err))
+	}
+
+	if err = pg.RegisterTracing(); err != nil {
+		l.Fatal(fmt.Errorf("app - Run - pg.RegisterTracing: %w", err))
+	}
+
 	//Create task table
 	migration.InitTodoTable(pg.DB)
 
+	// Workflow
+	wf, err := loadWorkflow()
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - loadWorkflow: %w", err))
+	}
+	if err = migration.RenameStatuses(pg.DB, wf.Renamed); err != nil {
+		l.Fatal(fmt.Errorf("app - Run - migration.RenameStatuses: %w", err))
+	}
+
+	subtasks, err := loadSubtasks()
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - loadSubtasks: %w", err))
+	}
+
+	attachments, err := loadAttachments()
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - loadAttachments: %w", err))
+	}
+
+	batch, err := loadBatch()
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - loadBatch: %w", err))
+	}
+
+	blobs, err := newBlobStore()
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newBlobStore: %w", err))
+	}
+
 	// Repository
-	repo := repository.NewTodoRepository(pg.DB)
+	repo := repository.NewTodoRepository(pg.DB, wf)
+	tagRepo := repository.NewTagRepository(pg.DB)
+	projectRepo := repository.NewProjectRepository(pg.DB)
+	userRepo := repository.NewUserRepository(pg.DB)
+	commentRepo := repository.NewCommentRepository(pg.DB)
+	attachmentRepo := repository.NewAttachmentRepository(pg.DB)
+	auditRepo := repository.NewAuditRepository(pg.DB)
+	idempotencyRepo := repository.NewIdempotencyRepository(pg.DB)
 
 	// Use case
-	translationUseCase := usecase.NewTodoUseCase(repo, l)
+	translationUseCase := usecase.NewTodoUseCase(repo, tagRepo, projectRepo, userRepo, auditRepo, wf, subtasks, l,
+		usecase.Attachments(attachmentRepo, blobs), usecase.Batches(batch))
+	tagUseCase := usecase.NewTagUseCase(tagRepo, l)
+	projectUseCase := usecase.NewProjectUseCase(projectRepo, wf, l)
+	userUseCase := usecase.NewUserUseCase(userRepo, l)
+	commentUseCase := usecase.NewCommentUseCase(commentRepo, repo, l)
+	attachmentUseCase := usecase.NewAttachmentUseCase(attachmentRepo, repo, blobs, attachments, l)
+	auditUseCase := usecase.NewAuditUseCase(auditRepo, repo, l)
+	idempotencyUseCase := usecase.NewIdempotencyUseCase(idempotencyRepo, viper.GetDuration("idempotency.ttl"),
+		viper.GetDuration("idempotency.lease"), l)
+	reg.MustRegister(metrics.NewTodoCollector(translationUseCase, wf, l))
+
+	// Health checks
+	hc := health.New(health.Timeout(viper.GetDuration("health.timeout")))
+	hc.AddCheck("postgres", pg.Ping)
+	hc.AddCheck("migration", func(ctx context.Context) error {
+		return migration.CheckTables(ctx, pg.DB)
+	})
 
 	// HTTP Server
 	handler := gin.New()
-	v1.NewRouter(handler, translationUseCase, l)
-	httpServer := httpserver.New(handler, httpserver.Port(viper.GetString("http.port")))
+	v1.NewRouter(handler, translationUseCase, tagUseCase, projectUseCase, userUseCase, commentUseCase, attachmentUseCase, auditUseCase, idempotencyUseCase, hc, reg, l)
 
-	// Waiting signal
-	interrupt := make(chan os.Signal, 1)
-	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	// Lifecycle
+	lc := lifecycle.New(l, lifecycle.StopTimeout(viper.GetDuration("shutdown.timeout")))
+	workers := lifecycle.NewWorkers()
+
+	lc.Append(lifecycle.Hook{Name: "tracing", Priority: _priorityTracing, OnStop: tp.Shutdown})
+	lc.Append(lifecycle.Hook{Name: "postgres", Priority: _priorityDatabase, OnStop: func(context.Context) error {
+		return pg.Close()
+	}})
+	lc.Append(lifecycle.Hook{Name: "workers", Priority: _priorityWorkers, OnStop: workers.Stop})
 
-	select {
-	case s := <-interrupt:
-		l.Info("app - Run - signal: " + s.String())
-	case err = <-httpServer.Notify():
-		l.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
+	// Expired idempotency keys are purged in the background
+	if interval := viper.GetDuration("idempotency.purge_interval"); interval > 0 {
+		workers.Go(func(ctx context.Context) {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if _, err := idempotencyUseCase.PurgeExpired(ctx); err != nil {
+						l.Error(fmt.Errorf("app - Run - idempotencyUseCase.PurgeExpired: %w", err))
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
 	}
 
-	// Shutdown
-	err = httpServer.Shutdown()
+	// Metrics stay off the public listener, which serves them without auth, unless it is asked for
+	metricsHandler := promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+	if port := viper.GetString("metrics.port"); port != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metricsHandler)
+		lc.Append(serverHook("metrics server", _priorityAuxServers, httpserver.New(mux, httpserver.Port(port))))
+	} else if viper.GetBool("metrics.public") {
+		handler.GET("/metrics", gin.WrapH(metricsHandler))
+	}
+
+	// Admin Server
+	if viper.GetBool("admin.enabled") {
+		adminHandler := gin.New()
+		admin.NewRouter(adminHandler, metricsHandler, auditUseCase, l)
+		adminServer := httpserver.New(adminHandler, httpserver.Address(viper.GetString("admin.host"), viper.GetString("admin.port")))
+		lc.Append(serverHook("admin server", _priorityAuxServers, adminServer))
+	}
+
+	httpOpts, err := httpServerOptions()
 	if err != nil {
-		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
+		l.Fatal(fmt.Errorf("app - Run - httpServerOptions: %w", err))
+	}
+	lc.Append(serverHook("http server", _priorityHTTPServer, httpserver.New(handler, httpOpts...)))
+
+	// Readiness flips first on shutdown, then load balancers get the drain period to stop routing here
+	drain := viper.GetDuration("shutdown.drain_period")
+	lc.Append(lifecycle.Hook{
+		Name:     "readiness",
+		Priority: _priorityReadiness,
+		OnStart: func(context.Context) error {
+			hc.SetReady(true)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			hc.SetReady(false)
+			select {
+			case <-time.After(drain):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+
+	if err = lc.Start(context.Background()); err != nil {
+		l.Fatal(fmt.Errorf("app - Run - lc.Start: %w", err))
+	}
+
+	// Waiting signal
+	if err = lc.Wait(context.Background()); err != nil {
+		l.Error(fmt.Errorf("app - Run - lc.Wait: %w", err))
+	}
+
+	// Shutdown
+	if err = lc.Stop(context.Background()); err != nil {
+		l.Error(fmt.Errorf("app - Run - lc.Stop: %w", err))
+	}
+}
+
+// serverHook starts the server with the lifecycle and reports serve errors through Hook.Err.
+func serverHook(name string, priority int, s *httpserver.Server) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name:     name,
+		Priority: priority,
+		OnStart: func(context.Context) error {
+			return s.Start()
+		},
+		OnStop: func(context.Context) error {
+			return s.Shutdown()
+		},
+		Err: s.Notify(),
 	}
 }
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/blobstore"
+	"github.com/spf13/viper"
+)
+
+// loadAttachments reads the upload limits of the attachments section of the config.
+func loadAttachments() (entity.Attachments, error) {
+	var limits entity.Attachments
+	if err := viper.UnmarshalKey("attachments", &limits); err != nil {
+		return limits, err
+	}
+	return limits, nil
+}
+
+// newBlobStore opens the store the attachment content is kept in.
+func newBlobStore() (blobstore.BlobStore, error) {
+	switch driver := viper.GetString("attachments.storage.driver"); driver {
+	case "", "filesystem":
+		return blobstore.NewFilesystem(viper.GetString("attachments.storage.dir"))
+	case "s3":
+		return blobstore.NewS3(
+			viper.GetString("attachments.storage.s3.endpoint"),
+			viper.GetString("attachments.storage.s3.bucket"),
+			blobstore.Region(viper.GetString("attachments.storage.s3.region")),
+			blobstore.Credentials(viper.GetString("attachments.storage.s3.access_key"), viper.GetString("attachments.storage.s3.secret_key")),
+		)
+	default:
+		return nil, fmt.Errorf("unknown attachment storage driver %q", driver)
+	}
+}
@@ -0,0 +1,15 @@
+package app
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/spf13/viper"
+)
+
+// loadBatch reads the limits of batch requests from the batch section of the config.
+func loadBatch() (entity.Batch, error) {
+	var limits entity.Batch
+	if err := viper.UnmarshalKey("batch", &limits); err != nil {
+		return limits, err
+	}
+	return limits, nil
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"github.com/Vaixle/crud-golang/pkg/httpserver"
+	"github.com/spf13/viper"
+)
+
+// httpServerOptions builds the public listener options from the http section of the config.
+func httpServerOptions() ([]httpserver.Option, error) {
+	opts := []httpserver.Option{httpserver.Port(viper.GetString("http.port"))}
+
+	switch {
+	case viper.GetBool("http.systemd_socket"):
+		opts = append(opts, httpserver.SystemdSocket(viper.GetString("http.systemd_socket_name")))
+	case viper.GetString("http.unix_socket") != "":
+		opts = append(opts, httpserver.UnixSocket(viper.GetString("http.unix_socket")))
+	}
+
+	if timeout := viper.GetDuration("http.read_timeout"); timeout > 0 {
+		opts = append(opts, httpserver.ReadTimeout(timeout))
+	}
+	if timeout := viper.GetDuration("http.read_header_timeout"); timeout > 0 {
+		opts = append(opts, httpserver.ReadHeaderTimeout(timeout))
+	}
+	if timeout := viper.GetDuration("http.write_timeout"); timeout > 0 {
+		opts = append(opts, httpserver.WriteTimeout(timeout))
+	}
+	if timeout := viper.GetDuration("http.idle_timeout"); timeout > 0 {
+		opts = append(opts, httpserver.IdleTimeout(timeout))
+	}
+	if timeout := viper.GetDuration("http.shutdown_timeout"); timeout > 0 {
+		opts = append(opts, httpserver.ShutdownTimeout(timeout))
+	}
+	if n := viper.GetInt("http.max_header_bytes"); n > 0 {
+		opts = append(opts, httpserver.MaxHeaderBytes(n))
+	}
+
+	if viper.GetBool("http.tls.enabled") {
+		minVersion, err := httpserver.ParseTLSVersion(viper.GetString("http.tls.min_version"))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts,
+			httpserver.TLS(viper.GetString("http.tls.cert_file"), viper.GetString("http.tls.key_file")),
+			httpserver.MinTLSVersion(minVersion),
+		)
+		if caFile := viper.GetString("http.tls.client_ca_file"); caFile != "" {
+			opts = append(opts, httpserver.ClientCA(caFile))
+		}
+	}
+	if viper.GetBool("http.h2c") {
+		opts = append(opts, httpserver.H2C())
+	}
+
+	return opts, nil
+}
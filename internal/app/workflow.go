@@ -0,0 +1,28 @@
+package app
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/spf13/viper"
+)
+
+// loadWorkflow reads the workflow section of the config, falling back to open/close when it is absent.
+func loadWorkflow() (entity.Workflow, error) {
+	if !viper.IsSet("workflow") {
+		return entity.DefaultWorkflow(), nil
+	}
+
+	var wf entity.Workflow
+	if err := viper.UnmarshalKey("workflow", &wf); err != nil {
+		return wf, err
+	}
+	return wf, wf.Validate()
+}
+
+// loadSubtasks reads the subtasks section of the config.
+func loadSubtasks() (entity.Subtasks, error) {
+	var subtasks entity.Subtasks
+	if err := viper.UnmarshalKey("subtasks", &subtasks); err != nil {
+		return subtasks, err
+	}
+	return subtasks, nil
+}
@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+)
+
+const _redacted = "[REDACTED]"
+
+// secretKey matches config keys whose values must never leave the process.
+var secretKey = regexp.MustCompile(`(?i)(password|secret|token|dsn)`)
+
+type adminController struct {
+	l logger.Interface
+}
+
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	Modified  bool   `json:"modified"`
+	GoVersion string `json:"go_version"`
+}
+
+type logLevel struct {
+	Level string `json:"level" binding:"required" example:"debug"`
+}
+
+func newAdminRoutes(handler *gin.Engine, l logger.Interface) {
+	r := &adminController{l: l}
+
+	handler.GET("/buildinfo", r.getBuildInfo)
+	handler.GET("/loglevel", r.getLogLevel)
+	handler.PUT("/loglevel", r.setLogLevel)
+	handler.GET("/config", r.getConfig)
+}
+
+func (r *adminController) getBuildInfo(gc *gin.Context) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		gc.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"error": "build info is not available",
+		})
+		return
+	}
+
+	bi := buildInfo{Version: info.Main.Version, GoVersion: info.GoVersion}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			bi.Commit = setting.Value
+		case "vcs.time":
+			bi.BuildTime = setting.Value
+		case "vcs.modified":
+			bi.Modified = setting.Value == "true"
+		}
+	}
+
+	gc.JSON(http.StatusOK, &bi)
+}
+
+func (r *adminController) getLogLevel(gc *gin.Context) {
+	gc.JSON(http.StatusOK, &logLevel{Level: logger.Level()})
+}
+
+func (r *adminController) setLogLevel(gc *gin.Context) {
+	var level logLevel
+	if err := gc.ShouldBindJSON(&level); err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := logger.SetLevel(level.Level); err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	r.l.Info("admin - log level changed to " + logger.Level())
+	gc.JSON(http.StatusOK, &logLevel{Level: logger.Level()})
+}
+
+func (r *adminController) getConfig(gc *gin.Context) {
+	gc.JSON(http.StatusOK, redact(viper.AllSettings()))
+}
+
+func redact(settings map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			out[key] = redact(v)
+		default:
+			if secretKey.MatchString(key) {
+				out[key] = _redacted
+			} else {
+				out[key] = v
+			}
+		}
+	}
+	return out
+}
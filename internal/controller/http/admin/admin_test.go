@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"bytes"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestController_SetLogLevel(t *testing.T) {
+	testTable := []struct {
+		name               string
+		inputBody          string
+		expectedStatusCode int
+		expectedBody       string
+		expectedLevel      string
+	}{
+		{
+			name:               "OK",
+			inputBody:          `{"level":"debug"}`,
+			expectedStatusCode: 200,
+			expectedBody:       `{"level":"debug"}`,
+			expectedLevel:      "debug",
+		},
+		{
+			name:               "UNKNOWN LEVEL",
+			inputBody:          `{"level":"verbose"}`,
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"unknown log level \"verbose\""}`,
+			expectedLevel:      "info",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			l := logger.New("info")
+
+			r := gin.New()
+			newAdminRoutes(r, l)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/loglevel", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedLevel, logger.Level())
+		})
+	}
+}
+
+func TestController_GetConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("db.host", "postgres")
+	viper.Set("db.password", "empha-soft")
+	viper.Set("auth.basic.password", "admin")
+
+	r := gin.New()
+	newAdminRoutes(r, logger.New("info"))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/config", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"auth":{"basic":{"password":"[REDACTED]"}},"db":{"host":"postgres","password":"[REDACTED]"}}`, w.Body.String())
+}
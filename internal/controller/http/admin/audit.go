@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type auditController struct {
+	l       logger.Interface
+	useCase entity.AuditUseCase
+}
+
+func newAuditRoutes(handler *gin.Engine, useCase entity.AuditUseCase, l logger.Interface) {
+	r := &auditController{l: l, useCase: useCase}
+
+	handler.GET("/audit", r.getAuditEntries)
+}
+
+// getAuditEntries returns a page of the audit log of every task, newest first. It is filtered by actor and by a
+// time range, from inclusive and to exclusive, both RFC 3339.
+func (r *auditController) getAuditEntries(gc *gin.Context) {
+	_, pagination, err := httpquery.ParseQueryParams(gc.Request.URL.Query())
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error query params",
+		})
+		return
+	}
+
+	filter := entity.AuditFilter{Actor: gc.Query("actor")}
+	bounds := []struct {
+		param string
+		at    **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}}
+	for _, bound := range bounds {
+		value := gc.Query(bound.param)
+		if value == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "error " + bound.param + " param",
+			})
+			return
+		}
+		*bound.at = &at
+	}
+
+	entries, err := r.useCase.GetAuditEntries(gc.Request.Context(), filter, pagination)
+	if err != nil {
+		r.l.Error(err, "admin - get audit entries")
+		gc.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, &entries)
+}
@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestController_GetAuditEntries(t *testing.T) {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name               string
+		url                string
+		mockBehavior       func(u *mock_entity.MockAuditUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "OK",
+			url:  "/audit?actor=admin&from=2024-03-01T00:00:00Z",
+			mockBehavior: func(u *mock_entity.MockAuditUseCase) {
+				u.EXPECT().GetAuditEntries(gomock.Any(), entity.AuditFilter{Actor: "admin", From: &from}, gomock.Any()).
+					Return([]entity.AuditEntry{{ID: 1, TodoID: 1, Operation: entity.AuditDelete, Actor: "admin", CreatedAt: from, Changes: entity.DeletionChanges(true)}}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `[{"id":1,"todo_id":1,"operation":"delete","actor":"admin","changes":[{"field":"deleted","old":false,"new":true}],"created_at":"2024-03-01T00:00:00Z"}]`,
+		},
+		{
+			name:               "BAD TIME",
+			url:                "/audit?to=yesterday",
+			mockBehavior:       func(u *mock_entity.MockAuditUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"error to param"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditUseCase := mock_entity.NewMockAuditUseCase(ctrl)
+
+			testCase.mockBehavior(auditUseCase)
+
+			r := gin.New()
+			newAuditRoutes(r, auditUseCase, logger.New("info"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
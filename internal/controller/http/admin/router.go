@@ -0,0 +1,37 @@
+// Package admin implements the operator endpoints served on the admin listener.
+package admin
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/pprof"
+)
+
+// NewRouter -.
+func NewRouter(handler *gin.Engine, metrics http.Handler, auditUseCase entity.AuditUseCase, l logger.Interface) {
+	// Options
+	handler.Use(gin.Recovery())
+
+	// Profiling
+	p := handler.Group("/debug/pprof")
+	{
+		p.GET("/", gin.WrapF(pprof.Index))
+		p.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+		p.GET("/profile", gin.WrapF(pprof.Profile))
+		p.POST("/symbol", gin.WrapF(pprof.Symbol))
+		p.GET("/symbol", gin.WrapF(pprof.Symbol))
+		p.GET("/trace", gin.WrapF(pprof.Trace))
+		p.GET("/:profile", func(gc *gin.Context) {
+			pprof.Handler(gc.Param("profile")).ServeHTTP(gc.Writer, gc.Request)
+		})
+	}
+
+	// Metrics
+	handler.GET("/metrics", gin.WrapH(metrics))
+
+	// Routers
+	newAdminRoutes(handler, l)
+	newAuditRoutes(handler, auditUseCase, l)
+}
@@ -0,0 +1,26 @@
+package midleware
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// Actor puts the user authenticated by an earlier auth middleware into the request context. Any middleware
+// that stores the principal under gin.AuthUserKey works; its account is created on the first request.
+func Actor(users entity.UserUseCase) gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		if username := gc.GetString(gin.AuthUserKey); username != "" {
+			user, err := users.ResolveUser(gc.Request.Context(), username)
+			if err != nil {
+				_ = gc.Error(err)
+				gc.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"error": "error resolve user",
+				})
+				return
+			}
+			gc.Request = gc.Request.WithContext(entity.ContextWithUser(gc.Request.Context(), *user))
+		}
+		gc.Next()
+	}
+}
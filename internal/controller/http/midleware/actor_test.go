@@ -0,0 +1,79 @@
+package midleware
+
+import (
+	"errors"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActor(t *testing.T) {
+	testTable := []struct {
+		name               string
+		principal          string
+		mockBehavior       func(u *mock_entity.MockUserUseCase)
+		expectedStatusCode int
+		expectedActor      string
+		expectedUserID     uint
+	}{
+		{
+			name:      "OK",
+			principal: "admin",
+			mockBehavior: func(u *mock_entity.MockUserUseCase) {
+				u.EXPECT().ResolveUser(gomock.Any(), "admin").Return(&entity.User{Model: gorm.Model{ID: 7}, Username: "admin"}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedActor:      "admin",
+			expectedUserID:     7,
+		},
+		{
+			name:               "ANONYMOUS",
+			mockBehavior:       func(u *mock_entity.MockUserUseCase) {},
+			expectedStatusCode: 200,
+			expectedActor:      entity.AnonymousActor,
+		},
+		{
+			name:      "ERROR",
+			principal: "admin",
+			mockBehavior: func(u *mock_entity.MockUserUseCase) {
+				u.EXPECT().ResolveUser(gomock.Any(), "admin").Return(nil, errors.New("some error"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			users := mock_entity.NewMockUserUseCase(ctrl)
+			testCase.mockBehavior(users)
+
+			var actor string
+			var userID uint
+			r := gin.New()
+			r.GET("/", func(gc *gin.Context) {
+				if testCase.principal != "" {
+					gc.Set(gin.AuthUserKey, testCase.principal)
+				}
+			}, Actor(users), func(gc *gin.Context) {
+				actor = entity.ActorFromContext(gc.Request.Context())
+				if user, ok := entity.UserFromContext(gc.Request.Context()); ok {
+					userID = user.ID
+				}
+			})
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedActor, actor)
+			assert.Equal(t, testCase.expectedUserID, userID)
+		})
+	}
+}
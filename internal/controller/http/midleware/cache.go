@@ -0,0 +1,62 @@
+package midleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// CacheControl sets the Cache-Control header of GET and HEAD responses from policies keyed by route template,
+// such as /api/v1/todo/:id. Only 200 and 304 responses carry it, so errors are never cached.
+func CacheControl(policies map[string]string) gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		if gc.Request.Method != http.MethodGet && gc.Request.Method != http.MethodHead {
+			gc.Next()
+			return
+		}
+		policy, ok := policies[gc.FullPath()]
+		if !ok || policy == "" {
+			gc.Next()
+			return
+		}
+
+		gc.Writer = &cacheControlWriter{ResponseWriter: gc.Writer, policy: policy}
+		gc.Next()
+	}
+}
+
+// cacheControlWriter decides on the header once the status is known, just before the header is written.
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	policy string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	w.set(code)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheControlWriter) WriteHeaderNow() {
+	w.set(w.Status())
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	w.set(w.Status())
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	w.set(w.Status())
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *cacheControlWriter) set(code int) {
+	if w.Written() {
+		return
+	}
+	if code == http.StatusOK || code == http.StatusNotModified {
+		w.Header().Set("Cache-Control", w.policy)
+	} else {
+		w.Header().Del("Cache-Control")
+	}
+}
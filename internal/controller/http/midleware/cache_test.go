@@ -0,0 +1,73 @@
+package midleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCacheControl(t *testing.T) {
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		expectedCacheControl string
+	}{
+		{
+			name:                 "OK",
+			method:               "GET",
+			path:                 "/todo/1",
+			expectedCacheControl: "private, max-age=5",
+		},
+		{
+			name:                 "NOT MODIFIED",
+			method:               "GET",
+			path:                 "/todo/2",
+			expectedCacheControl: "private, max-age=5",
+		},
+		{
+			name:   "ERROR",
+			method: "GET",
+			path:   "/todo/3",
+		},
+		{
+			name:   "OTHER ROUTE",
+			method: "GET",
+			path:   "/todo",
+		},
+		{
+			name:   "UNSAFE METHOD",
+			method: "PATCH",
+			path:   "/todo/1",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(CacheControl(map[string]string{"/todo/:id": "private, max-age=5"}))
+			handler := func(gc *gin.Context) {
+				switch gc.Param("id") {
+				case "2":
+					gc.Status(http.StatusNotModified)
+				case "3":
+					gc.JSON(http.StatusNotFound, gin.H{"error": "task not found: 3"})
+				default:
+					gc.JSON(http.StatusOK, gin.H{})
+				}
+			}
+			r.GET("/todo", handler)
+			r.GET("/todo/:id", handler)
+			r.PATCH("/todo/:id", handler)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedCacheControl, w.Header().Get("Cache-Control"))
+		})
+	}
+}
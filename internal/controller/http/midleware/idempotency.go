@@ -0,0 +1,116 @@
+package midleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+)
+
+// _maxIdempotencyKey is the longest Idempotency-Key accepted, the size of the column it is stored in.
+const _maxIdempotencyKey = 255
+
+// _replayedHeaders are the response headers stored along with the response body and replayed with it.
+var _replayedHeaders = []string{"Content-Type", "ETag", "Last-Modified", "Location"}
+
+// Idempotency makes a request with an Idempotency-Key header safe to retry. The first request with a key runs
+// and its response is stored; a retry with the same key, path and body gets the stored response, marked with
+// Idempotent-Replayed, without running the handler again. It must run after Actor, as keys belong to an actor.
+func Idempotency(keys entity.IdempotencyUseCase) gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		key := gc.GetHeader("Idempotency-Key")
+		if key == "" {
+			gc.Next()
+			return
+		}
+		if len(key) > _maxIdempotencyKey {
+			gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "error Idempotency-Key header",
+			})
+			return
+		}
+
+		body, err := io.ReadAll(gc.Request.Body)
+		if err != nil {
+			gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		gc.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		hash.Write([]byte(gc.Request.Method + " " + gc.Request.URL.RequestURI() + "\n"))
+		hash.Write(body)
+
+		ctx := gc.Request.Context()
+		stored, err := keys.Begin(ctx, key, hex.EncodeToString(hash.Sum(nil)))
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, entity.ErrIdempotencyKeyReused):
+				status = http.StatusUnprocessableEntity
+			case errors.Is(err, entity.ErrRequestInProgress):
+				status = http.StatusConflict
+			default:
+				_ = gc.Error(err)
+			}
+			gc.AbortWithStatusJSON(status, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		if stored.Done() {
+			for name, value := range stored.Headers {
+				gc.Header(name, value)
+			}
+			gc.Header("Idempotent-Replayed", "true")
+			gc.Data(stored.StatusCode, stored.Headers["Content-Type"], stored.Response)
+			gc.Abort()
+			return
+		}
+
+		// A panic releases the key, as a server error does, before it goes on to the recovery middleware.
+		defer func() {
+			if r := recover(); r != nil {
+				_ = keys.Finish(ctx, stored, http.StatusInternalServerError, nil, nil)
+				panic(r)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: gc.Writer}
+		gc.Writer = recorder
+		gc.Next()
+
+		headers := make(map[string]string, len(_replayedHeaders))
+		for _, name := range _replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		if err = keys.Finish(ctx, stored, recorder.Status(), headers, recorder.body.Bytes()); err != nil {
+			_ = gc.Error(err)
+		}
+	}
+}
+
+// responseRecorder keeps a copy of the response body as it is written.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
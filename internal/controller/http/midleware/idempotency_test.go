@@ -0,0 +1,130 @@
+package midleware
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIdempotency(t *testing.T) {
+	testTable := []struct {
+		name               string
+		key                string
+		status             int
+		mockBehavior       func(u *mock_entity.MockIdempotencyUseCase)
+		expectedStatusCode int
+		expectedBody       string
+		expectedCalls      int
+		expectedReplayed   string
+	}{
+		{
+			name:               "NO KEY",
+			mockBehavior:       func(u *mock_entity.MockIdempotencyUseCase) {},
+			expectedStatusCode: 200,
+			expectedBody:       `{"id":1}`,
+			expectedCalls:      1,
+		},
+		{
+			name: "FIRST REQUEST",
+			key:  "a1",
+			mockBehavior: func(u *mock_entity.MockIdempotencyUseCase) {
+				key := &entity.IdempotencyKey{ID: 1, Key: "a1"}
+				u.EXPECT().Begin(gomock.Any(), "a1", gomock.Any()).Return(key, nil)
+				u.EXPECT().Finish(gomock.Any(), key, 200, map[string]string{"Content-Type": "application/json; charset=utf-8", "ETag": `"1"`}, []byte(`{"id":1}`)).Return(nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"id":1}`,
+			expectedCalls:      1,
+		},
+		{
+			name: "RETRY",
+			key:  "a1",
+			mockBehavior: func(u *mock_entity.MockIdempotencyUseCase) {
+				u.EXPECT().Begin(gomock.Any(), "a1", gomock.Any()).Return(&entity.IdempotencyKey{
+					ID: 1, Key: "a1", StatusCode: 200,
+					Headers:  map[string]string{"Content-Type": "application/json; charset=utf-8", "ETag": `"1"`},
+					Response: []byte(`{"id":1}`),
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"id":1}`,
+			expectedReplayed:   "true",
+		},
+		{
+			name: "DIFFERENT REQUEST",
+			key:  "a1",
+			mockBehavior: func(u *mock_entity.MockIdempotencyUseCase) {
+				u.EXPECT().Begin(gomock.Any(), "a1", gomock.Any()).Return(nil, fmt.Errorf("%w: %q", entity.ErrIdempotencyKeyReused, "a1"))
+			},
+			expectedStatusCode: 422,
+			expectedBody:       `{"error":"idempotency key already used for a different request: \"a1\""}`,
+		},
+		{
+			name: "IN PROGRESS",
+			key:  "a1",
+			mockBehavior: func(u *mock_entity.MockIdempotencyUseCase) {
+				u.EXPECT().Begin(gomock.Any(), "a1", gomock.Any()).Return(nil, fmt.Errorf("%w: %q", entity.ErrRequestInProgress, "a1"))
+			},
+			expectedStatusCode: 409,
+			expectedBody:       `{"error":"a request with the idempotency key is in progress: \"a1\""}`,
+		},
+		{
+			name:   "SERVER ERROR",
+			key:    "a1",
+			status: 500,
+			mockBehavior: func(u *mock_entity.MockIdempotencyUseCase) {
+				key := &entity.IdempotencyKey{ID: 1, Key: "a1"}
+				u.EXPECT().Begin(gomock.Any(), "a1", gomock.Any()).Return(key, nil)
+				u.EXPECT().Finish(gomock.Any(), key, 500, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: 500,
+			expectedBody:       `{"error":"create task"}`,
+			expectedCalls:      1,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			idempotencyUseCase := mock_entity.NewMockIdempotencyUseCase(ctrl)
+			testCase.mockBehavior(idempotencyUseCase)
+
+			calls := 0
+			r := gin.New()
+			r.POST("/todo", Idempotency(idempotencyUseCase), func(gc *gin.Context) {
+				calls++
+				body, _ := io.ReadAll(gc.Request.Body)
+				assert.Equal(t, `{"description":"buy milk"}`, string(body))
+				if testCase.status == http.StatusInternalServerError {
+					gc.JSON(http.StatusInternalServerError, gin.H{"error": "create task"})
+					return
+				}
+				gc.Header("ETag", `"1"`)
+				gc.JSON(http.StatusOK, gin.H{"id": 1})
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/todo", bytes.NewBufferString(`{"description":"buy milk"}`))
+			if testCase.key != "" {
+				req.Header.Set("Idempotency-Key", testCase.key)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedCalls, calls)
+			assert.Equal(t, testCase.expectedReplayed, w.Header().Get("Idempotent-Replayed"))
+		})
+	}
+}
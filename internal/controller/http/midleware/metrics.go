@@ -0,0 +1,43 @@
+package midleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+	"time"
+)
+
+const _unmatchedRoute = "unmatched"
+
+// Metrics records request counts and latencies labelled by route template, so /todo/1 and /todo/2 share a series.
+func Metrics(reg prometheus.Registerer) gin.HandlerFunc {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	reg.MustRegister(requests, duration)
+
+	return func(gc *gin.Context) {
+		start := time.Now()
+
+		gc.Next()
+
+		route := gc.FullPath()
+		if route == "" {
+			route = _unmatchedRoute
+		}
+		status := strconv.Itoa(gc.Writer.Status())
+
+		requests.WithLabelValues(gc.Request.Method, route, status).Inc()
+		duration.WithLabelValues(gc.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,56 @@
+package midleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	testTable := []struct {
+		name          string
+		path          string
+		expectedCount string
+	}{
+		{
+			name: "ROUTE TEMPLATE",
+			path: "/todo/3",
+			expectedCount: `
+				# HELP http_requests_total Number of HTTP requests by method, route and status.
+				# TYPE http_requests_total counter
+				http_requests_total{method="GET",route="/todo/:id",status="200"} 1
+			`,
+		},
+		{
+			name: "UNMATCHED",
+			path: "/unknown",
+			expectedCount: `
+				# HELP http_requests_total Number of HTTP requests by method, route and status.
+				# TYPE http_requests_total counter
+				http_requests_total{method="GET",route="unmatched",status="404"} 1
+			`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			reg := prometheus.NewRegistry()
+
+			r := gin.New()
+			r.Use(Metrics(reg))
+			r.GET("/todo/:id", func(gc *gin.Context) {})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			err := testutil.GatherAndCompare(reg, strings.NewReader(testCase.expectedCount), "http_requests_total")
+			assert.NoError(t, err)
+		})
+	}
+}
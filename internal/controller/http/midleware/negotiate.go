@@ -0,0 +1,58 @@
+package midleware
+
+import (
+	"github.com/Vaixle/crud-golang/pkg/mediatype"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// _responseTypeKey is the gin context key of the media type negotiated for the response.
+const _responseTypeKey = "response_type"
+
+// Negotiate chooses the media type of the response among mediatype.All: the format query param names it, or
+// else the Accept header decides, and 406 answers a request none of them suits. The format param is then
+// removed from the query, so that it never reads as a filter. Routes in skipPaths, keyed by route template,
+// choose their formats themselves.
+func Negotiate(skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return func(gc *gin.Context) {
+		if _, ok := skip[gc.FullPath()]; ok {
+			gc.Next()
+			return
+		}
+		gc.Header("Vary", "Accept")
+
+		var responseType string
+		var ok bool
+		query := gc.Request.URL.Query()
+		if query.Has("format") {
+			responseType, ok = mediatype.Lookup(query.Get("format"))
+			query.Del("format")
+			gc.Request.URL.RawQuery = query.Encode()
+		} else {
+			responseType, ok = mediatype.Negotiate(gc.GetHeader("Accept"), mediatype.All)
+		}
+		if !ok {
+			gc.AbortWithStatusJSON(http.StatusNotAcceptable, gin.H{
+				"error": "not acceptable, available types: " + strings.Join(mediatype.All, ", "),
+			})
+			return
+		}
+
+		gc.Set(_responseTypeKey, responseType)
+		gc.Next()
+	}
+}
+
+// ResponseType is the media type negotiated for the response, JSON on routes that do not negotiate.
+func ResponseType(gc *gin.Context) string {
+	if responseType := gc.GetString(_responseTypeKey); responseType != "" {
+		return responseType
+	}
+	return mediatype.JSON
+}
@@ -0,0 +1,83 @@
+package midleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	testTable := []struct {
+		name                 string
+		path                 string
+		accept               string
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "DEFAULT",
+			path:                 "/todo?status=eq:open",
+			expectedStatusCode:   200,
+			expectedResponseBody: "application/json status=eq:open",
+		},
+		{
+			name:                 "ACCEPT",
+			path:                 "/todo",
+			accept:               "application/xml;q=0.5, application/yaml",
+			expectedStatusCode:   200,
+			expectedResponseBody: "application/yaml ",
+		},
+		{
+			name:                 "FORMAT OVERRIDES ACCEPT",
+			path:                 "/todo?format=csv&page=2",
+			accept:               "application/json",
+			expectedStatusCode:   200,
+			expectedResponseBody: "text/csv page=2",
+		},
+		{
+			name:                 "NOT ACCEPTABLE",
+			path:                 "/todo",
+			accept:               "text/html",
+			expectedStatusCode:   406,
+			expectedResponseBody: `{"error":"not acceptable, available types: application/json, application/yaml, application/xml, application/msgpack, text/csv"}`,
+		},
+		{
+			name:                 "UNKNOWN FORMAT",
+			path:                 "/todo?format=html",
+			expectedStatusCode:   406,
+			expectedResponseBody: `{"error":"not acceptable, available types: application/json, application/yaml, application/xml, application/msgpack, text/csv"}`,
+		},
+		{
+			name:                 "SKIPPED ROUTE",
+			path:                 "/todo/export?format=ndjson",
+			accept:               "application/x-ndjson",
+			expectedStatusCode:   200,
+			expectedResponseBody: "application/json format=ndjson",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Negotiate("/todo/export"))
+			handler := func(gc *gin.Context) {
+				gc.String(http.StatusOK, ResponseType(gc)+" "+gc.Request.URL.RawQuery)
+			}
+			r.GET("/todo", handler)
+			r.GET("/todo/export", handler)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.path, nil)
+			if testCase.accept != "" {
+				req.Header.Set("Accept", testCase.accept)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
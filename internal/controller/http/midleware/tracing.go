@@ -0,0 +1,48 @@
+package midleware
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"time"
+)
+
+// Tracing starts a server span per request, continuing the trace from an incoming traceparent header.
+func Tracing(service string, skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return otelgin.Middleware(service, otelgin.WithFilter(func(r *http.Request) bool {
+		_, ok := skip[r.URL.Path]
+		return !ok
+	}))
+}
+
+// Logger is gin.Logger with the trace id of the request appended to every line.
+func Logger() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		if param.Latency > time.Minute {
+			param.Latency = param.Latency.Truncate(time.Second)
+		}
+
+		traceID := "-"
+		if sc := trace.SpanContextFromContext(param.Request.Context()); sc.IsValid() {
+			traceID = sc.TraceID().String()
+		}
+
+		return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v | trace_id=%s\n%s",
+			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			param.StatusCode,
+			param.Latency,
+			param.ClientIP,
+			param.Method,
+			param.Path,
+			traceID,
+			param.ErrorMessage,
+		)
+	})
+}
@@ -0,0 +1,65 @@
+package midleware
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/pkg/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	testTable := []struct {
+		name            string
+		path            string
+		traceparent     string
+		expectedSpans   int
+		expectedTraceID string
+	}{
+		{
+			name:            "PROPAGATED",
+			path:            "/todo/3",
+			traceparent:     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedSpans:   1,
+			expectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:          "SKIPPED",
+			path:          "/healthz",
+			expectedSpans: 0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp, err := tracing.New(tracing.SpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter)))
+			require.NoError(t, err)
+			defer tp.Shutdown(context.Background())
+
+			r := gin.New()
+			r.Use(Tracing("test", "/healthz"))
+			r.GET("/todo/:id", func(gc *gin.Context) {})
+			r.GET("/healthz", func(gc *gin.Context) {})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.path, nil)
+			if testCase.traceparent != "" {
+				req.Header.Set("traceparent", testCase.traceparent)
+			}
+
+			r.ServeHTTP(w, req)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, testCase.expectedSpans)
+			if testCase.expectedSpans > 0 {
+				assert.Equal(t, "/todo/:id", spans[0].Name)
+				assert.Equal(t, testCase.expectedTraceID, spans[0].SpanContext.TraceID().String())
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package v1
+
+import (
+	"errors"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// _multipartOverhead is the room left for the multipart framing around the file when the request body is capped.
+const _multipartOverhead = 64 << 10
+
+type attachmentController struct {
+	l       logger.Interface
+	useCase entity.AttachmentUseCase
+	maxSize int64
+}
+
+func newAttachmentRoutes(handler *gin.RouterGroup, useCase entity.AttachmentUseCase, maxSize int64, l logger.Interface) {
+	r := &attachmentController{l: l, useCase: useCase, maxSize: maxSize}
+
+	h := handler.Group("/todo/:id/attachments")
+	{
+		h.GET("", r.getAttachments)
+		h.GET("/:attachment_id", r.getAttachment)
+		h.GET("/:attachment_id/content", r.downloadAttachment)
+		h.POST("", r.uploadAttachment)
+		h.DELETE("/:attachment_id", r.deleteAttachment)
+	}
+}
+
+// @Summary      Get attachments
+// @Description  Get the attachments of a todo task, oldest first
+// @Tags         attachments
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Success      200  {array}   entity.Attachment
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/attachments [get]
+func (a *attachmentController) getAttachments(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		a.l.Error(err, "http - v1 - get attachments")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	attachments, err := a.useCase.GetAttachments(gc.Request.Context(), uint(id))
+	if err != nil {
+		a.l.Error(err, "http - v1 - get attachments")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &attachments)
+}
+
+// @Summary      Get attachment
+// @Description  Get the metadata of an attachment of a todo task
+// @Tags         attachments
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Param        attachment_id    path      int  true "Attachment ID"
+// @Success      200  {object}   entity.Attachment
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/attachments/{attachment_id} [get]
+func (a *attachmentController) getAttachment(gc *gin.Context) {
+	id, attachmentID, ok := a.ids(gc, "http - v1 - get attachment")
+	if !ok {
+		return
+	}
+
+	attachment, err := a.useCase.GetAttachment(gc.Request.Context(), id, attachmentID)
+	if err != nil {
+		a.l.Error(err, "http - v1 - get attachment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &attachment)
+}
+
+// @Summary      Download attachment
+// @Description  Download the content of an attachment; Range and If-Range requests are supported
+// @Tags         attachments
+// @Produce      octet-stream
+// @Param        id    path      int  true "Todo task ID"
+// @Param        attachment_id    path      int  true "Attachment ID"
+// @Param        Range    header     string  false  "Byte range" example(bytes=0-1023)
+// @Success      200  {file}   file
+// @Success      206  {file}   file
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Failure 416 {string} string "invalid range: failed to overlap"
+// @Router       /todo/{id}/attachments/{attachment_id}/content [get]
+func (a *attachmentController) downloadAttachment(gc *gin.Context) {
+	id, attachmentID, ok := a.ids(gc, "http - v1 - download attachment")
+	if !ok {
+		return
+	}
+
+	attachment, content, err := a.useCase.OpenAttachment(gc.Request.Context(), id, attachmentID)
+	if err != nil {
+		a.l.Error(err, "http - v1 - download attachment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	defer content.Close()
+
+	// The content is immutable, so its digest is a strong validator for If-Range and If-None-Match.
+	gc.Header("Content-Type", attachment.ContentType)
+	gc.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	gc.Header("X-Content-Type-Options", "nosniff")
+	gc.Header("ETag", `"`+attachment.SHA256+`"`)
+	http.ServeContent(gc.Writer, gc.Request, attachment.Filename, attachment.CreatedAt, content)
+}
+
+// @Summary      Upload attachment
+// @Description  Attach a file to a todo task. The content type is sniffed from the content and checked, along with the size, against the configured limits.
+// @Tags         attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Param        file  formData  file  true "File to attach"
+// @Success      200  {object}   entity.Attachment
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Failure 413 {string} string "{"error": "attachment too large: larger than 10485760 bytes"}"
+// @Failure 415 {string} string "{"error": "unsupported attachment content type: application/zip"}"
+// @Router       /todo/{id}/attachments [post]
+func (a *attachmentController) uploadAttachment(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		a.l.Error(err, "http - v1 - upload attachment")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	if a.maxSize > 0 {
+		gc.Request.Body = http.MaxBytesReader(gc.Writer, gc.Request.Body, a.maxSize+_multipartOverhead)
+	}
+
+	header, err := gc.FormFile("file")
+	if err != nil {
+		a.l.Error(err, "http - v1 - upload attachment")
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			gc.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": entity.ErrAttachmentTooLarge.Error(),
+			})
+			return
+		}
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		a.l.Error(err, "http - v1 - upload attachment")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	attachment := entity.Attachment{TodoID: uint(id), Filename: filename(header.Filename)}
+	if err = a.useCase.SaveAttachment(gc.Request.Context(), &attachment, file); err != nil {
+		a.l.Error(err, "http - v1 - upload attachment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &attachment)
+}
+
+// @Summary      Delete attachment
+// @Description  Delete an attachment of a todo task
+// @Tags         attachments
+// @Param        id    path      int  true "Todo task ID"
+// @Param        attachment_id    path      int  true "Attachment ID"
+// @Success      204
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/attachments/{attachment_id} [delete]
+func (a *attachmentController) deleteAttachment(gc *gin.Context) {
+	id, attachmentID, ok := a.ids(gc, "http - v1 - delete attachment")
+	if !ok {
+		return
+	}
+
+	if err := a.useCase.DeleteAttachment(gc.Request.Context(), id, attachmentID); err != nil {
+		a.l.Error(err, "http - v1 - delete attachment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	gc.Status(http.StatusNoContent)
+}
+
+// ids parses the task and attachment ids of the path, answering 400 when either is not a number.
+func (a *attachmentController) ids(gc *gin.Context, operation string) (uint, uint, bool) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err == nil {
+		var attachmentID int
+		if attachmentID, err = strconv.Atoi(gc.Param("attachment_id")); err == nil {
+			return uint(id), uint(attachmentID), true
+		}
+	}
+
+	a.l.Error(err, operation)
+	gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+		"error": "error id param",
+	})
+	return 0, 0, false
+}
+
+// filename keeps the base name of an uploaded file, whichever path separator the client used, cut to 255 bytes.
+func filename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "." || name == "/" {
+		return "attachment"
+	}
+	for len(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
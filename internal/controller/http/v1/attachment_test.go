@@ -0,0 +1,196 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestController_UploadAttachment(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		field              string
+		content            string
+		mockBehavior       func(u *mock_entity.MockAttachmentUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:    "OK",
+			field:   "file",
+			content: "some log",
+			mockBehavior: func(u *mock_entity.MockAttachmentUseCase) {
+				u.EXPECT().SaveAttachment(gomock.Any(), &entity.Attachment{TodoID: 1, Filename: "app.log"}, gomock.Any()).
+					DoAndReturn(func(_ interface{}, attachment *entity.Attachment, content io.ReadSeeker) error {
+						data, err := io.ReadAll(content)
+						if err != nil || string(data) != "some log" {
+							return fmt.Errorf("unexpected content %q: %v", data, err)
+						}
+						attachment.ID = 5
+						attachment.ContentType = "text/plain; charset=utf-8"
+						attachment.Size = 8
+						attachment.SHA256 = "abc"
+						return nil
+					})
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"id":5,"todo_id":1,"filename":"app.log","content_type":"text/plain; charset=utf-8","size":8,"sha256":"abc","created_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:    "UNSUPPORTED CONTENT TYPE",
+			field:   "file",
+			content: "some log",
+			mockBehavior: func(u *mock_entity.MockAttachmentUseCase) {
+				u.EXPECT().SaveAttachment(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("%w: %s", entity.ErrUnsupportedContent, "application/zip"))
+			},
+			expectedStatusCode: 415,
+			expectedBody:       `{"error":"unsupported attachment content type: application/zip"}`,
+		},
+		{
+			name:               "BODY TOO LARGE",
+			field:              "file",
+			content:            strings.Repeat("x", _multipartOverhead+100),
+			mockBehavior:       func(u *mock_entity.MockAttachmentUseCase) {},
+			expectedStatusCode: 413,
+			expectedBody:       `{"error":"attachment too large"}`,
+		},
+		{
+			name:               "NO FILE",
+			field:              "upload",
+			content:            "some log",
+			mockBehavior:       func(u *mock_entity.MockAttachmentUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"http: no such file"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			attachmentUseCase := mock_entity.NewMockAttachmentUseCase(ctrl)
+
+			testCase.mockBehavior(attachmentUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &attachmentController{l: l, useCase: attachmentUseCase, maxSize: 10}
+
+			r.POST("/api/v1/todo/:id/attachments", c.uploadAttachment)
+
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			part, err := form.CreateFormFile(testCase.field, `C:\logs\app.log`)
+			require.NoError(t, err)
+			_, err = part.Write([]byte(testCase.content))
+			require.NoError(t, err)
+			require.NoError(t, form.Close())
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/todo/1/attachments", &body)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestController_DownloadAttachment(t *testing.T) {
+
+	testTable := []struct {
+		name                 string
+		rangeHeader          string
+		mockBehavior         func(u *mock_entity.MockAttachmentUseCase)
+		expectedStatusCode   int
+		expectedBody         string
+		expectedContentRange string
+	}{
+		{
+			name: "WHOLE",
+			mockBehavior: func(u *mock_entity.MockAttachmentUseCase) {
+				u.EXPECT().OpenAttachment(gomock.Any(), uint(1), uint(5)).Return(
+					&entity.Attachment{ID: 5, TodoID: 1, Filename: "app.log", ContentType: "text/plain; charset=utf-8", SHA256: "abc", CreatedAt: time.Now()},
+					nopCloser{strings.NewReader("0123456789")}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       "0123456789",
+		},
+		{
+			name:        "RANGE",
+			rangeHeader: "bytes=2-5",
+			mockBehavior: func(u *mock_entity.MockAttachmentUseCase) {
+				u.EXPECT().OpenAttachment(gomock.Any(), uint(1), uint(5)).Return(
+					&entity.Attachment{ID: 5, TodoID: 1, Filename: "app.log", ContentType: "text/plain; charset=utf-8", SHA256: "abc", CreatedAt: time.Now()},
+					nopCloser{strings.NewReader("0123456789")}, nil)
+			},
+			expectedStatusCode:   206,
+			expectedBody:         "2345",
+			expectedContentRange: "bytes 2-5/10",
+		},
+		{
+			name: "NOT FOUND",
+			mockBehavior: func(u *mock_entity.MockAttachmentUseCase) {
+				u.EXPECT().OpenAttachment(gomock.Any(), uint(1), uint(5)).Return(nil, nil, fmt.Errorf("%w: %d", entity.ErrAttachmentNotFound, 5))
+			},
+			expectedStatusCode: 404,
+			expectedBody:       `{"error":"attachment not found: 5"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			attachmentUseCase := mock_entity.NewMockAttachmentUseCase(ctrl)
+
+			testCase.mockBehavior(attachmentUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &attachmentController{l: l, useCase: attachmentUseCase}
+
+			r.GET("/api/v1/todo/:id/attachments/:attachment_id/content", c.downloadAttachment)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/v1/todo/1/attachments/5/content", nil)
+			if testCase.rangeHeader != "" {
+				req.Header.Set("Range", testCase.rangeHeader)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedContentRange, w.Header().Get("Content-Range"))
+			if w.Code < 300 {
+				assert.Equal(t, `attachment; filename=app.log`, w.Header().Get("Content-Disposition"))
+				assert.Equal(t, `"abc"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type auditController struct {
+	l       logger.Interface
+	useCase entity.AuditUseCase
+}
+
+func newAuditRoutes(handler *gin.RouterGroup, useCase entity.AuditUseCase, l logger.Interface) {
+	r := &auditController{l: l, useCase: useCase}
+
+	handler.GET("/todo/:id/history", r.getTaskHistory)
+}
+
+// @Summary      Get task history
+// @Description  Get a page of the audit log of a todo task, oldest first: who created, changed, deleted or restored it, when, and which fields changed
+// @Tags         todo
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Param        page    query     string  false  "page" example(2)
+// @Param        limit    query     string  false  "limit" example(3)
+// @Success      200  {array}   entity.AuditEntry
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/history [get]
+func (a *auditController) getTaskHistory(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		a.l.Error(err, "http - v1 - get task history")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	_, pagination, err := httpquery.ParseQueryParams(gc.Request.URL.Query())
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error query params",
+		})
+		return
+	}
+
+	entries, err := a.useCase.GetTaskHistory(gc.Request.Context(), uint(id), pagination)
+	if err != nil {
+		a.l.Error(err, "http - v1 - get task history")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &entries)
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestController_GetTaskHistory(t *testing.T) {
+	createdAt := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name               string
+		url                string
+		mockBehavior       func(u *mock_entity.MockAuditUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "OK",
+			url:  "/api/v1/todo/1/history?page=1&limit=1",
+			mockBehavior: func(u *mock_entity.MockAuditUseCase) {
+				u.EXPECT().GetTaskHistory(gomock.Any(), uint(1), httpquery.Pagination{Page: 1, Limit: 1}).Return([]entity.AuditEntry{{
+					ID: 2, TodoID: 1, Operation: entity.AuditUpdate, Actor: "admin", CreatedAt: createdAt,
+					Changes: entity.FieldChanges{{Field: "status", Old: []byte(`"open"`), New: []byte(`"done"`)}},
+				}}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `[{"id":2,"todo_id":1,"operation":"update","actor":"admin","changes":[{"field":"status","old":"open","new":"done"}],"created_at":"2024-03-01T09:00:00Z"}]`,
+		},
+		{
+			name: "NOT FOUND",
+			url:  "/api/v1/todo/1/history",
+			mockBehavior: func(u *mock_entity.MockAuditUseCase) {
+				u.EXPECT().GetTaskHistory(gomock.Any(), uint(1), gomock.Any()).Return(nil, fmt.Errorf("%w: %d", entity.ErrTaskNotFound, 1))
+			},
+			expectedStatusCode: 404,
+			expectedBody:       `{"error":"task not found: 1"}`,
+		},
+		{
+			name:               "BAD ID",
+			url:                "/api/v1/todo/one/history",
+			mockBehavior:       func(u *mock_entity.MockAuditUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"error id param"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditUseCase := mock_entity.NewMockAuditUseCase(ctrl)
+
+			testCase.mockBehavior(auditUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			newAuditRoutes(r.Group("/api/v1"), auditUseCase, l)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type commentController struct {
+	l       logger.Interface
+	useCase entity.CommentUseCase
+}
+
+type commentRequest struct {
+	Body string `json:"body" binding:"required" example:"Looks good to me"`
+}
+
+func newCommentRoutes(handler *gin.RouterGroup, useCase entity.CommentUseCase, l logger.Interface) {
+	r := &commentController{l: l, useCase: useCase}
+
+	h := handler.Group("/todo/:id/comments")
+	{
+		h.GET("", r.getComments)
+		h.GET("/:comment_id", r.getComment)
+		h.POST("", r.createComment)
+		h.PATCH("/:comment_id", r.updateComment)
+		h.DELETE("/:comment_id", r.deleteComment)
+	}
+}
+
+// @Summary      Get comments
+// @Description  Get a page of the top-level comments of a todo task, oldest first, each with its replies
+// @Tags         comments
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Param        page    query     string  false  "page" example(2)
+// @Param        limit    query     string  false  "limit" example(3)
+// @Success      200  {array}   entity.Comment
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/comments [get]
+func (c *commentController) getComments(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		c.l.Error(err, "http - v1 - get comments")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	_, pagination, err := httpquery.ParseQueryParams(gc.Request.URL.Query())
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error query params",
+		})
+		return
+	}
+
+	comments, err := c.useCase.GetComments(gc.Request.Context(), uint(id), pagination)
+	if err != nil {
+		c.l.Error(err, "http - v1 - get comments")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &comments)
+}
+
+// @Summary      Get comment
+// @Description  Get a comment of a todo task
+// @Tags         comments
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Param        comment_id    path      int  true "Comment ID"
+// @Success      200  {object}   entity.Comment
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/comments/{comment_id} [get]
+func (c *commentController) getComment(gc *gin.Context) {
+	id, commentID, ok := c.ids(gc, "http - v1 - get comment")
+	if !ok {
+		return
+	}
+
+	comment, err := c.useCase.GetComment(gc.Request.Context(), id, commentID)
+	if err != nil {
+		c.l.Error(err, "http - v1 - get comment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &comment)
+}
+
+// @Summary      Create comment
+// @Description  Comment on a todo task as the current user; set parent_id to reply to a top-level comment
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Param        comment  body      entity.Comment  true "Comment"
+// @Success      200  {object}   entity.Comment
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 401 {string} string "{"error": "no authenticated user"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/comments [post]
+func (c *commentController) createComment(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		c.l.Error(err, "http - v1 - create comment")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	var comment entity.Comment
+	if err = bind(gc, &comment); err != nil {
+		c.l.Error(err, "http - v1 - create comment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	comment.TodoID = uint(id)
+
+	if err = c.useCase.SaveComment(gc.Request.Context(), &comment); err != nil {
+		c.l.Error(err, "http - v1 - create comment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &comment)
+}
+
+// @Summary      Edit comment
+// @Description  Change the body of a comment; only its author may
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Param        comment_id    path      int  true "Comment ID"
+// @Param        comment  body      commentRequest  true "New body"
+// @Success      200  {object}   entity.Comment
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 403 {string} string "{"error": "only the author can change a comment: comment 1"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/comments/{comment_id} [patch]
+func (c *commentController) updateComment(gc *gin.Context) {
+	id, commentID, ok := c.ids(gc, "http - v1 - update comment")
+	if !ok {
+		return
+	}
+
+	var request commentRequest
+	if err := bind(gc, &request); err != nil {
+		c.l.Error(err, "http - v1 - update comment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	comment, err := c.useCase.UpdateComment(gc.Request.Context(), id, commentID, request.Body)
+	if err != nil {
+		c.l.Error(err, "http - v1 - update comment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &comment)
+}
+
+// @Summary      Delete comment
+// @Description  Delete a comment and its replies; only its author may
+// @Tags         comments
+// @Param        id    path      int  true "Todo task ID"
+// @Param        comment_id    path      int  true "Comment ID"
+// @Success      204
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 403 {string} string "{"error": "only the author can change a comment: comment 1"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/comments/{comment_id} [delete]
+func (c *commentController) deleteComment(gc *gin.Context) {
+	id, commentID, ok := c.ids(gc, "http - v1 - delete comment")
+	if !ok {
+		return
+	}
+
+	if err := c.useCase.DeleteComment(gc.Request.Context(), id, commentID); err != nil {
+		c.l.Error(err, "http - v1 - delete comment")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	gc.Status(http.StatusNoContent)
+}
+
+// ids parses the task and comment ids of the path, answering 400 when either is not a number.
+func (c *commentController) ids(gc *gin.Context, operation string) (uint, uint, bool) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err == nil {
+		var commentID int
+		if commentID, err = strconv.Atoi(gc.Param("comment_id")); err == nil {
+			return uint(id), uint(commentID), true
+		}
+	}
+
+	c.l.Error(err, operation)
+	gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+		"error": "error id param",
+	})
+	return 0, 0, false
+}
@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestController_CreateComment(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		inputBody          string
+		mockBehavior       func(u *mock_entity.MockCommentUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"body":"Looks good"}`,
+			mockBehavior: func(u *mock_entity.MockCommentUseCase) {
+				u.EXPECT().SaveComment(gomock.Any(), &entity.Comment{TodoID: 1, Body: "Looks good"}).
+					DoAndReturn(func(_ interface{}, comment *entity.Comment) error {
+						comment.ID = 10
+						comment.AuthorID = 7
+						return nil
+					})
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"ID":10,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"todo_id":1,"author_id":7,"body":"Looks good","parent_id":null}`,
+		},
+		{
+			name:      "TASK NOT FOUND",
+			inputBody: `{"body":"Looks good"}`,
+			mockBehavior: func(u *mock_entity.MockCommentUseCase) {
+				u.EXPECT().SaveComment(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: %d", entity.ErrTaskNotFound, 1))
+			},
+			expectedStatusCode: 404,
+			expectedBody:       `{"error":"task not found: 1"}`,
+		},
+		{
+			name:               "NO BODY",
+			inputBody:          `{}`,
+			mockBehavior:       func(u *mock_entity.MockCommentUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"Key: 'Comment.Body' Error:Field validation for 'Body' failed on the 'required' tag"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			commentUseCase := mock_entity.NewMockCommentUseCase(ctrl)
+
+			testCase.mockBehavior(commentUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &commentController{l: l, useCase: commentUseCase}
+
+			r.POST("/api/v1/todo/:id/comments", c.createComment)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/todo/1/comments", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestController_UpdateComment(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		path               string
+		mockBehavior       func(u *mock_entity.MockCommentUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "OK",
+			path: "/api/v1/todo/1/comments/10",
+			mockBehavior: func(u *mock_entity.MockCommentUseCase) {
+				u.EXPECT().UpdateComment(gomock.Any(), uint(1), uint(10), "Edited").
+					Return(&entity.Comment{Model: gorm.Model{ID: 10}, TodoID: 1, AuthorID: 7, Body: "Edited"}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"ID":10,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"todo_id":1,"author_id":7,"body":"Edited","parent_id":null}`,
+		},
+		{
+			name: "NOT AUTHOR",
+			path: "/api/v1/todo/1/comments/10",
+			mockBehavior: func(u *mock_entity.MockCommentUseCase) {
+				u.EXPECT().UpdateComment(gomock.Any(), uint(1), uint(10), "Edited").
+					Return(nil, fmt.Errorf("%w: comment %d", entity.ErrNotCommentAuthor, 10))
+			},
+			expectedStatusCode: 403,
+			expectedBody:       `{"error":"only the author can change a comment: comment 10"}`,
+		},
+		{
+			name:               "BAD COMMENT ID",
+			path:               "/api/v1/todo/1/comments/abc",
+			mockBehavior:       func(u *mock_entity.MockCommentUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"error id param"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			commentUseCase := mock_entity.NewMockCommentUseCase(ctrl)
+
+			testCase.mockBehavior(commentUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &commentController{l: l, useCase: commentUseCase}
+
+			r.PATCH("/api/v1/todo/:id/comments/:comment_id", c.updateComment)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", testCase.path, bytes.NewBufferString(`{"body":"Edited"}`))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
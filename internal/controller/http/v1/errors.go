@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"errors"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/mediatype"
+	"net/http"
+)
+
+// errorStatus maps domain errors onto HTTP status codes; anything else is a bad request.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrTaskNotFound), errors.Is(err, entity.ErrTagNotFound),
+		errors.Is(err, entity.ErrNoDependency), errors.Is(err, entity.ErrProjectNotFound),
+		errors.Is(err, entity.ErrUserNotFound), errors.Is(err, entity.ErrCommentNotFound),
+		errors.Is(err, entity.ErrAttachmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrIllegalTransition), errors.Is(err, entity.ErrTagExists),
+		errors.Is(err, entity.ErrOpenChildren), errors.Is(err, entity.ErrDependencyCycle),
+		errors.Is(err, entity.ErrProjectArchived), errors.Is(err, entity.ErrHasChildren):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, entity.ErrAttachmentTooLarge), errors.Is(err, entity.ErrBatchTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, entity.ErrUnsupportedContent), errors.Is(err, mediatype.ErrUnsupported):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, entity.ErrBatchRolledBack):
+		return http.StatusFailedDependency
+	case errors.Is(err, entity.ErrAnonymous):
+		return http.StatusUnauthorized
+	case errors.Is(err, entity.ErrNotCommentAuthor):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
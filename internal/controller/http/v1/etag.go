@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/Vaixle/crud-golang/internal/controller/http/midleware"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// etag is the strong entity tag of a task in the media type and projection it is answered in: its version, then a
+// hash of those and of the fields computed from its comments, blockers and subtasks, which change without a new
+// version of the task.
+func etag(gc *gin.Context, task *entity.Todo, projection entity.Projection) string {
+	representation := midleware.ResponseType(gc)
+	if projection.Sparse() {
+		representation += ";" + strings.Join(projection.Fields, ",") + ";" + strings.Join(projection.Include, ",")
+	}
+	sum := sha256.Sum256([]byte(representation + "\n" + computedState(task)))
+	return `"` + strconv.FormatUint(uint64(task.Version), 10) + "-" + hex.EncodeToString(sum[:4]) + `"`
+}
+
+// computedState lists the comment count, blocked flag and progress of a task.
+func computedState(task *entity.Todo) string {
+	progress := "-"
+	if task.Progress != nil {
+		progress = strconv.Itoa(*task.Progress)
+	}
+	return strconv.Itoa(task.CommentCount) + "," + strconv.FormatBool(task.Blocked) + "," + progress
+}
+
+// ifMatch reads the version a write expects from the If-Match header: AnyVersion without the header or for "*".
+// Only the version of the tag counts, a write does not conflict with new comments or subtasks. ok is false for
+// anything but a single strong tag of a version, which no task can match.
+func ifMatch(gc *gin.Context) (version uint, ok bool) {
+	header := strings.TrimSpace(gc.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return entity.AnyVersion, true
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
+	}
+	value, _, _ := strings.Cut(header[1:len(header)-1], "-")
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || parsed == 0 {
+		return 0, false
+	}
+	return uint(parsed), true
+}
+
+// listETag is the weak entity tag of a page of tasks. It changes when the query, the media type or the actor it
+// is answered for changes, when a task joins or leaves the page and when any task on it is written or has its
+// computed fields change.
+func listETag(gc *gin.Context, tasks []entity.Todo) (string, time.Time) {
+	var lastModified time.Time
+	hash := sha256.New()
+	hash.Write([]byte(gc.Request.URL.RawQuery + "\n" + midleware.ResponseType(gc) + "\n" +
+		entity.ActorFromContext(gc.Request.Context()) + "\n" + strconv.Itoa(len(tasks))))
+	for _, task := range tasks {
+		hash.Write([]byte("\n" + strconv.FormatUint(uint64(task.ID), 10) + ":" + computedState(&task)))
+		if task.UpdatedAt.After(lastModified) {
+			lastModified = task.UpdatedAt
+		}
+	}
+	hash.Write([]byte("\n" + lastModified.UTC().Format(time.RFC3339Nano)))
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`, lastModified
+}
+
+// notModified sets the ETag and Last-Modified headers of a read and answers 304 when the client already has
+// that representation: If-None-Match is compared weakly and, only when it is absent, If-Modified-Since is
+// compared to the second.
+func notModified(gc *gin.Context, tag string, lastModified time.Time) bool {
+	gc.Header("ETag", tag)
+	if !lastModified.IsZero() {
+		gc.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if header := gc.GetHeader("If-None-Match"); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+				gc.Status(http.StatusNotModified)
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := gc.GetHeader("If-Modified-Since"); header != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(header)
+		if err == nil && !lastModified.Truncate(time.Second).After(since) {
+			gc.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// _importChunk is the number of rows of an import file checked and created together.
+	_importChunk = 500
+	// _maxReportedErrors bounds the errors listed in an import summary; all rejected rows are still counted.
+	_maxReportedErrors = 1000
+)
+
+// importSummary is the outcome of an import. Error is set when the file could not be read to the end: the
+// counts then cover the rows before it, which have been imported unless it is a dry run.
+type importSummary struct {
+	DryRun   bool          `json:"dry_run" example:"false"`
+	Total    int           `json:"total" example:"120"`
+	Accepted int           `json:"accepted" example:"118"`
+	Rejected int           `json:"rejected" example:"2"`
+	Errors   []importError `json:"errors"`
+	Error    string        `json:"error,omitempty" example:"unexpected EOF"`
+}
+
+type importError struct {
+	Row   int    `json:"row" example:"7"`
+	Error string `json:"error" example:"unknown status \"later\""`
+}
+
+// @Summary      Export todo tasks
+// @Description  Stream every task matching the filters, whatever the page, as CSV, JSON Lines or a JSON array. Tasks come in id order; sort and order_by are ignored.
+// @Tags         todo
+// @Produce      json
+// @Produce      plain
+// @Param        format    query     string  false  "File format" Enums(csv, ndjson, json)
+// @Param        filedName1    query     string  false  "any filter of GET /todo" example(eq:open)
+// @Success      200  {file}   file
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Router       /todo/export [get]
+func (t *todoController) exportTasks(gc *gin.Context) {
+	format, err := fileFormat(gc.DefaultQuery("format", formatJSON), "")
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error format param",
+		})
+		return
+	}
+
+	// An export always holds whole tasks.
+	values := gc.Request.URL.Query()
+	for _, param := range []string{"format", "fields", "include"} {
+		values.Del(param)
+	}
+	filterOptions, _, err := httpquery.ParseQueryParams(values)
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error query params",
+		})
+		return
+	}
+
+	// The response starts with the first batch of tasks, so that an error before it still gets a status.
+	var writer taskWriter
+	start := func() {
+		gc.Header("Content-Type", _fileContentTypes[format])
+		gc.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks.%s"`, format))
+		gc.Status(http.StatusOK)
+		writer = newTaskWriter(format, gc.Writer)
+	}
+
+	err = t.useCase.ExportTasks(gc.Request.Context(), filterOptions, func(tasks []entity.Todo) error {
+		if writer == nil {
+			start()
+		}
+		if err := writer.Write(tasks); err != nil {
+			return err
+		}
+		gc.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		t.l.Error(err, "http - v1 - export tasks")
+		if writer == nil {
+			gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "error export tasks",
+			})
+			return
+		}
+		// The file is left unfinished, which a JSON array at least shows.
+		gc.Abort()
+		return
+	}
+
+	if writer == nil {
+		start()
+	}
+	if err = writer.Close(); err != nil {
+		t.l.Error(err, "http - v1 - export tasks")
+	}
+}
+
+// @Summary      Import todo tasks
+// @Description  Create a task from each row of a CSV, JSON Lines or JSON array file, in the columns of an export; ids, versions and timestamps of the file are ignored. Rows are checked and created on their own: a rejected row does not stop the others. The summary lists why rows were rejected; with report=errors the response is instead the rejected rows, in the format of the file, with their row number and error.
+// @Tags         todo
+// @Accept       json
+// @Accept       plain
+// @Produce      json
+// @Param        format    query     string  false  "File format, by default taken from Content-Type" Enums(csv, ndjson, json)
+// @Param        map    query     string  false  "Columns or keys of the file to read into task fields" example(Title:description,State:status)
+// @Param        dry_run    query     bool  false  "Only check the rows" example(true)
+// @Param        report    query     string  false  "summary or the error file" Enums(summary, errors)
+// @Success      200  {object}   importSummary
+// @Failure 400 {object} importSummary
+// @Failure 415 {string} string "{"error": "unknown file format: \"text/plain\""}"
+// @Router       /todo/import [post]
+func (t *todoController) importTasks(gc *gin.Context) {
+	format, err := fileFormat(gc.Query("format"), gc.ContentType())
+	if err != nil {
+		status, message := http.StatusUnsupportedMediaType, err.Error()
+		if gc.Query("format") != "" {
+			status, message = http.StatusBadRequest, "error format param"
+		}
+		gc.AbortWithStatusJSON(status, gin.H{
+			"error": message,
+		})
+		return
+	}
+
+	dryRun := false
+	if value := gc.Query("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "error dry_run param",
+			})
+			return
+		}
+	}
+
+	report := gc.DefaultQuery("report", "summary")
+	if report != "summary" && report != "errors" {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error report param",
+		})
+		return
+	}
+
+	mapping, err := parseColumnMap(gc.Query("map"))
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	reader, err := newRowReader(format, gc.Request.Body, mapping)
+	if err != nil {
+		t.l.Error(err, "http - v1 - import tasks")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	summary := importSummary{DryRun: dryRun, Errors: []importError{}}
+	var rejects rejectWriter
+	if report == "errors" {
+		gc.Header("Content-Type", _fileContentTypes[format])
+		gc.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="rejected.%s"`, format))
+		gc.Status(http.StatusOK)
+		rejects = newRejectWriter(format, gc.Writer, reader)
+	}
+
+	chunk := make([]*importRow, 0, _importChunk)
+	flush := func() error {
+		rows := chunk
+		chunk = make([]*importRow, 0, _importChunk)
+
+		tasks := make([]*entity.Todo, 0, len(rows))
+		for _, row := range rows {
+			if row.err == nil {
+				tasks = append(tasks, row.task)
+			}
+		}
+		var results []entity.BatchResult
+		if len(tasks) > 0 {
+			var err error
+			if results, err = t.useCase.ImportTasks(gc.Request.Context(), tasks, dryRun); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range rows {
+			if row.err == nil {
+				result := results[0]
+				results = results[1:]
+				if result.Err == nil {
+					summary.Accepted++
+					continue
+				}
+				row.err = errors.New(createMessage(result.Err))
+			}
+
+			summary.Rejected++
+			if len(summary.Errors) < _maxReportedErrors {
+				summary.Errors = append(summary.Errors, importError{Row: row.number, Error: row.err.Error()})
+			}
+			if rejects != nil {
+				if err := rejects.Reject(row, row.err.Error()); err != nil {
+					return err
+				}
+			}
+		}
+		if rejects != nil {
+			gc.Writer.Flush()
+		}
+		return nil
+	}
+
+	for err == nil {
+		var row *importRow
+		if row, err = reader.Next(); err != nil {
+			break
+		}
+		summary.Total++
+		if row.err == nil {
+			forImport(row.task)
+			row.err = binding.Validator.ValidateStruct(row.task)
+		}
+		if chunk = append(chunk, row); len(chunk) == _importChunk {
+			err = flush()
+		}
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	// Rows read before the end of the file, or before it could not be read on, are imported all the same.
+	if len(chunk) > 0 {
+		if flushErr := flush(); err == nil {
+			err = flushErr
+		}
+	}
+
+	if err != nil {
+		t.l.Error(err, "http - v1 - import tasks")
+		summary.Error = err.Error()
+	}
+
+	if rejects == nil {
+		status := http.StatusOK
+		if summary.Error != "" {
+			status = http.StatusBadRequest
+		}
+		gc.JSON(status, summary)
+		return
+	}
+
+	if summary.Error != "" {
+		// The rest of the file is lost: the last row of the error file says where reading stopped.
+		_ = rejects.Reject(&importRow{number: summary.Total + 1}, summary.Error)
+	}
+	if err = rejects.Close(); err != nil {
+		t.l.Error(err, "http - v1 - import tasks")
+	}
+}
@@ -0,0 +1,428 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestController_ExportTasks(t *testing.T) {
+	due := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	created := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	parent := uint(1)
+	tasks := []entity.Todo{
+		{Model: gorm.Model{ID: 1, CreatedAt: created, UpdatedAt: created}, Description: "write docs", Status: "open", Priority: "high", DueAt: &due, Version: 1,
+			Tags: []entity.Tag{{Model: gorm.Model{ID: 3}}, {Model: gorm.Model{ID: 4}}}},
+		{Model: gorm.Model{ID: 2, CreatedAt: created, UpdatedAt: created}, Description: "say \"hi\", twice", Status: "done", Priority: "normal", ParentID: &parent, Version: 2},
+	}
+
+	testTable := []struct {
+		name               string
+		query              string
+		mockBehavior       func(u *mock_entity.MockTodoUseCase)
+		expectedStatusCode int
+		expectedType       string
+		expectedBody       string
+	}{
+		{
+			name:  "CSV",
+			query: "?format=csv&status=eq:open",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ExportTasks(gomock.Any(), []httpquery.FilterOption{{Operator: "eq", Field: "status", Value: "open"}}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ []httpquery.FilterOption, fn func([]entity.Todo) error) error {
+						return fn(tasks)
+					})
+			},
+			expectedStatusCode: 200,
+			expectedType:       "text/csv; charset=utf-8",
+			expectedBody: "id,description,status,priority,due_at,parent_id,project_id,recurrence,timezone,occurrence,series_id,tag_ids,assignee_ids,progress,blocked,comment_count,created_by,updated_by,version,created_at,updated_at\n" +
+				`1,write docs,open,high,2024-03-01T09:00:00Z,,,,,0,,"3,4",,,false,0,,,1,2024-01-01T12:00:00Z,2024-01-01T12:00:00Z` + "\n" +
+				`2,"say ""hi"", twice",done,normal,,1,,,,0,,,,,false,0,,,2,2024-01-01T12:00:00Z,2024-01-01T12:00:00Z` + "\n",
+		},
+		{
+			name:  "CSV FORMULAS",
+			query: "?format=csv",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ExportTasks(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ []httpquery.FilterOption, fn func([]entity.Todo) error) error {
+						return fn([]entity.Todo{
+							{Model: gorm.Model{ID: 1}, Description: "=HYPERLINK(\"http://example.com\")", Status: "open"},
+							{Model: gorm.Model{ID: 2}, Description: "@SUM(A1)", Status: "open"},
+							{Model: gorm.Model{ID: 3}, Description: "'quoted", Status: "open"},
+						})
+					})
+			},
+			expectedStatusCode: 200,
+			expectedType:       "text/csv; charset=utf-8",
+			expectedBody: "id,description,status,priority,due_at,parent_id,project_id,recurrence,timezone,occurrence,series_id,tag_ids,assignee_ids,progress,blocked,comment_count,created_by,updated_by,version,created_at,updated_at\n" +
+				`1,"'=HYPERLINK(""http://example.com"")",open,,,,,,,0,,,,,false,0,,,0,,` + "\n" +
+				`2,'@SUM(A1),open,,,,,,,0,,,,,false,0,,,0,,` + "\n" +
+				`3,'quoted,open,,,,,,,0,,,,,false,0,,,0,,` + "\n",
+		},
+		{
+			name:  "NDJSON",
+			query: "?format=ndjson",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ExportTasks(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ []httpquery.FilterOption, fn func([]entity.Todo) error) error {
+						if err := fn([]entity.Todo{{Model: gorm.Model{ID: 1}, Description: "a"}}); err != nil {
+							return err
+						}
+						return fn([]entity.Todo{{Model: gorm.Model{ID: 2}, Description: "b"}})
+					})
+			},
+			expectedStatusCode: 200,
+			expectedType:       "application/x-ndjson",
+			expectedBody: `{"ID":1,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"description":"a","status":"","priority":"","due_at":null,"parent_id":null,"project_id":null,"blocked":false,"comment_count":0,"version":0}` + "\n" +
+				`{"ID":2,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"description":"b","status":"","priority":"","due_at":null,"parent_id":null,"project_id":null,"blocked":false,"comment_count":0,"version":0}` + "\n",
+		},
+		{
+			name: "EMPTY JSON",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ExportTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: 200,
+			expectedType:       "application/json; charset=utf-8",
+			expectedBody:       `[]`,
+		},
+		{
+			name:  "EMPTY CSV",
+			query: "?format=csv",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ExportTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: 200,
+			expectedType:       "text/csv; charset=utf-8",
+			expectedBody:       "id,description,status,priority,due_at,parent_id,project_id,recurrence,timezone,occurrence,series_id,tag_ids,assignee_ids,progress,blocked,comment_count,created_by,updated_by,version,created_at,updated_at\n",
+		},
+		{
+			name: "ERROR",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ExportTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			},
+			expectedStatusCode: 400,
+			expectedType:       "application/json; charset=utf-8",
+			expectedBody:       `{"error":"error export tasks"}`,
+		},
+		{
+			name:               "BAD FORMAT",
+			query:              "?format=xlsx",
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 400,
+			expectedType:       "application/json; charset=utf-8",
+			expectedBody:       `{"error":"error format param"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+			testCase.mockBehavior(todoUseCase)
+
+			r := gin.New()
+			c := &todoController{l: logger.New("info"), useCase: todoUseCase}
+			r.GET("/api/v1/todo/export", c.exportTasks)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/v1/todo/export"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedType, w.Header().Get("Content-Type"))
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+// TestController_ExportTasksStreams checks that each batch reaches the client before the next one is read.
+func TestController_ExportTasksStreams(t *testing.T) {
+	const batches, batchSize = 5, 500
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+	todoUseCase.EXPECT().ExportTasks(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ []httpquery.FilterOption, fn func([]entity.Todo) error) error {
+			for batch := 0; batch < batches; batch++ {
+				tasks := make([]entity.Todo, batchSize)
+				for i := range tasks {
+					tasks[i] = entity.Todo{Model: gorm.Model{ID: uint(batch*batchSize + i + 1)}, Description: "task"}
+				}
+				if err := fn(tasks); err != nil {
+					return err
+				}
+				// The header and every task so far have been flushed.
+				require.True(t, w.Flushed)
+				require.Equal(t, 1+(batch+1)*batchSize, strings.Count(w.Body.String(), "\n"))
+			}
+			return nil
+		})
+
+	r := gin.New()
+	c := &todoController{l: logger.New("info"), useCase: todoUseCase}
+	r.GET("/api/v1/todo/export", c.exportTasks)
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/todo/export?format=csv", nil))
+
+	assert.Equal(t, 200, w.Code)
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	assert.Len(t, lines, 1+batches*batchSize)
+	assert.True(t, strings.HasPrefix(lines[len(lines)-1], fmt.Sprintf("%d,task,", batches*batchSize)))
+}
+
+// importResults accepts every task but those described as "rejected".
+func importResults(_ context.Context, tasks []*entity.Todo, _ bool) ([]entity.BatchResult, error) {
+	results := make([]entity.BatchResult, len(tasks))
+	for i, task := range tasks {
+		results[i] = entity.BatchResult{Op: entity.BatchCreate, Task: task}
+		if task.Description == "rejected" {
+			results[i] = entity.BatchResult{Op: entity.BatchCreate, Err: fmt.Errorf("%w %q", entity.ErrUnknownStatus, task.Status)}
+		}
+	}
+	return results, nil
+}
+
+func TestController_ImportTasks(t *testing.T) {
+	due := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name               string
+		query              string
+		contentType        string
+		inputBody          string
+		mockBehavior       func(u *mock_entity.MockTodoUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:        "CSV WITH MAPPING",
+			query:       "?map=Title:description,State:status&dry_run=true",
+			contentType: "text/csv",
+			inputBody: "Title,State,due_at,tag_ids,Owner\n" +
+				"write docs,open,2024-03-01T09:00:00Z,\"3,4\",bob\n" +
+				"rejected,later,,,\n" +
+				"no date,open,tomorrow,,\n" +
+				",open,,,\n",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ImportTasks(gomock.Any(), []*entity.Todo{
+					{Description: "write docs", Status: "open", DueAt: &due, TagIDs: []uint{3, 4}},
+					{Description: "rejected", Status: "later"},
+				}, true).DoAndReturn(importResults)
+			},
+			expectedStatusCode: 200,
+			expectedBody: `{"dry_run":true,"total":4,"accepted":1,"rejected":3,"errors":[` +
+				`{"row":2,"error":"unknown status \"later\""},` +
+				`{"row":3,"error":"due_at: parsing time \"tomorrow\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"tomorrow\" as \"2006\""},` +
+				`{"row":4,"error":"Key: 'Todo.Description' Error:Field validation for 'Description' failed on the 'required' tag"}]}`,
+		},
+		{
+			name:        "NDJSON",
+			contentType: "application/x-ndjson",
+			inputBody: `{"ID":9,"description":"a","version":4,"tags":[{"ID":3}]}` + "\n" +
+				"\n" +
+				`{"description":` + "\n" +
+				`{"text":"rejected"}` + "\n",
+			query: "?map=text:description",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ImportTasks(gomock.Any(), []*entity.Todo{
+					{Description: "a", TagIDs: []uint{3}},
+					{Description: "rejected"},
+				}, false).DoAndReturn(importResults)
+			},
+			expectedStatusCode: 200,
+			expectedBody: `{"dry_run":false,"total":3,"accepted":1,"rejected":2,"errors":[` +
+				`{"row":2,"error":"unexpected end of JSON input"},` +
+				`{"row":3,"error":"unknown status \"\""}]}`,
+		},
+		{
+			name:        "JSON ARRAY",
+			query:       "?format=json",
+			contentType: "text/plain",
+			inputBody:   `[{"description":"a"},{"description":"b","priority":"someday"}]`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ImportTasks(gomock.Any(), []*entity.Todo{{Description: "a"}}, false).DoAndReturn(importResults)
+			},
+			expectedStatusCode: 200,
+			expectedBody: `{"dry_run":false,"total":2,"accepted":1,"rejected":1,"errors":[` +
+				`{"row":2,"error":"Key: 'Todo.Priority' Error:Field validation for 'Priority' failed on the 'oneof' tag"}]}`,
+		},
+		{
+			name:        "TRUNCATED JSON ARRAY",
+			contentType: "application/json",
+			inputBody:   `[{"description":"a"},{"descr`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ImportTasks(gomock.Any(), []*entity.Todo{{Description: "a"}}, false).DoAndReturn(importResults)
+			},
+			expectedStatusCode: 400,
+			expectedBody:       `{"dry_run":false,"total":1,"accepted":1,"rejected":0,"errors":[],"error":"unexpected EOF"}`,
+		},
+		{
+			name:        "CSV ERROR FILE",
+			query:       "?report=errors",
+			contentType: "text/csv; charset=utf-8",
+			inputBody:   "description,status\nrejected,later\nfine,open\n\"broken,open\n",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ImportTasks(gomock.Any(), gomock.Len(2), false).DoAndReturn(importResults)
+			},
+			expectedStatusCode: 200,
+			expectedBody: "description,status,row,error\n" +
+				"rejected,later,1,\"unknown status \"\"later\"\"\"\n" +
+				",,3,\"extraneous or missing \"\" in quoted-field\"\n",
+		},
+		{
+			name:        "CSV FORMULAS",
+			contentType: "text/csv",
+			inputBody:   "description,status\n'=1+1,open\n'+x,open\n'quoted,open\n",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ImportTasks(gomock.Any(), []*entity.Todo{
+					{Description: "=1+1", Status: "open"},
+					{Description: "+x", Status: "open"},
+					{Description: "'quoted", Status: "open"},
+				}, false).DoAndReturn(importResults)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"dry_run":false,"total":3,"accepted":3,"rejected":0,"errors":[]}`,
+		},
+		{
+			name:               "CSV ERROR FILE FORMULAS",
+			query:              "?report=errors",
+			contentType:        "text/csv",
+			inputBody:          "description,due_at\n-1,=NOW()\n",
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 200,
+			expectedBody: "description,due_at,row,error\n" +
+				`'-1,'=NOW(),1,"due_at: parsing time ""=NOW()"" as ""2006-01-02T15:04:05Z07:00"": cannot parse ""=NOW()"" as ""2006"""` + "\n",
+		},
+		{
+			name:        "NDJSON ERROR FILE",
+			query:       "?report=errors",
+			contentType: "application/x-ndjson",
+			inputBody:   `{"description":"rejected","status":"later"}` + "\n" + "oops\n",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().ImportTasks(gomock.Any(), gomock.Len(1), false).DoAndReturn(importResults)
+			},
+			expectedStatusCode: 200,
+			expectedBody: `{"description":"rejected","error":"unknown status \"later\"","row":1,"status":"later"}` + "\n" +
+				`{"error":"invalid character 'o' looking for beginning of value","raw":"oops","row":2}` + "\n",
+		},
+		{
+			name:               "NO DESCRIPTION COLUMN",
+			contentType:        "text/csv",
+			inputBody:          "title,status\nwrite docs,open\n",
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"error import header: no description column"}`,
+		},
+		{
+			name:               "BAD MAPPING",
+			query:              "?map=Title:id",
+			contentType:        "text/csv",
+			inputBody:          "Title\nwrite docs\n",
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"column \"id\" cannot be imported"}`,
+		},
+		{
+			name:               "UNSUPPORTED CONTENT TYPE",
+			contentType:        "application/xml",
+			inputBody:          "<tasks/>",
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 415,
+			expectedBody:       `{"error":"unknown file format: \"application/xml\""}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+			testCase.mockBehavior(todoUseCase)
+
+			r := gin.New()
+			c := &todoController{l: logger.New("info"), useCase: todoUseCase}
+			r.POST("/api/v1/todo/import", c.importTasks)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/todo/import"+testCase.query, bytes.NewBufferString(testCase.inputBody))
+			req.Header.Set("Content-Type", testCase.contentType)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+// TestController_ImportTasksStreams checks that a large file is imported chunk by chunk while it is still being
+// sent, rather than read whole first.
+func TestController_ImportTasksStreams(t *testing.T) {
+	const rows = 2*_importChunk + 200
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body, writer := io.Pipe()
+	var sent int64
+	go func() {
+		_, _ = io.WriteString(writer, "description,status\n")
+		for i := 1; i <= rows; i++ {
+			description := fmt.Sprintf("task %d", i)
+			if i%100 == 0 {
+				description = "rejected"
+			}
+			_, _ = fmt.Fprintf(writer, "%s,open\n", description)
+			atomic.AddInt64(&sent, 1)
+		}
+		_ = writer.Close()
+	}()
+
+	todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+	gomock.InOrder(
+		todoUseCase.EXPECT().ImportTasks(gomock.Any(), gomock.Len(_importChunk), false).
+			DoAndReturn(func(ctx context.Context, tasks []*entity.Todo, dryRun bool) ([]entity.BatchResult, error) {
+				assert.Less(t, atomic.LoadInt64(&sent), int64(rows), "the whole file was read before the first chunk")
+				return importResults(ctx, tasks, dryRun)
+			}),
+		todoUseCase.EXPECT().ImportTasks(gomock.Any(), gomock.Len(_importChunk), false).DoAndReturn(importResults),
+		todoUseCase.EXPECT().ImportTasks(gomock.Any(), gomock.Len(200), false).DoAndReturn(importResults),
+	)
+
+	r := gin.New()
+	c := &todoController{l: logger.New("info"), useCase: todoUseCase}
+	r.POST("/api/v1/todo/import", c.importTasks)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/v1/todo/import?report=errors", body)
+	req.Header.Set("Content-Type", "text/csv")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	require.Len(t, lines, 1+rows/100)
+	assert.Equal(t, "description,status,row,error", lines[0])
+	assert.Equal(t, `rejected,open,1200,"unknown status ""open"""`, lines[len(lines)-1])
+}
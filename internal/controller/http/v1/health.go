@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"github.com/Vaixle/crud-golang/pkg/health"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type healthController struct {
+	h *health.Health
+}
+
+func newHealthRoutes(handler *gin.Engine, h *health.Health) {
+	r := &healthController{h: h}
+
+	handler.GET("/healthz", r.liveness)
+	handler.GET("/readyz", r.readiness)
+}
+
+// liveness is served outside the API base path, so it is not part of the swagger spec.
+func (r *healthController) liveness(gc *gin.Context) {
+	report := r.h.Liveness(gc.Request.Context())
+	gc.JSON(statusOf(report), &report)
+}
+
+// readiness fails while the database is unreachable or not migrated, and during shutdown.
+func (r *healthController) readiness(gc *gin.Context) {
+	report := r.h.Readiness(gc.Request.Context())
+	gc.JSON(statusOf(report), &report)
+}
+
+func statusOf(report health.Report) int {
+	if report.Up() {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
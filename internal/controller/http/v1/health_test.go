@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/Vaixle/crud-golang/pkg/health"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestController_Health(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		path               string
+		ready              bool
+		check              health.Check
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "LIVENESS",
+			path:               "/healthz",
+			ready:              false,
+			check:              func(ctx context.Context) error { return errors.New("connection refused") },
+			expectedStatusCode: 200,
+			expectedBody:       `{"status":"up","checks":{}}`,
+		},
+		{
+			name:               "READY",
+			path:               "/readyz",
+			ready:              true,
+			check:              func(ctx context.Context) error { return nil },
+			expectedStatusCode: 200,
+			expectedBody:       `{"status":"up","checks":{"postgres":{"status":"up"}}}`,
+		},
+		{
+			name:               "CHECK FAILED",
+			path:               "/readyz",
+			ready:              true,
+			check:              func(ctx context.Context) error { return errors.New("connection refused") },
+			expectedStatusCode: 503,
+			expectedBody:       `{"status":"down","checks":{"postgres":{"status":"down","error":"connection refused"}}}`,
+		},
+		{
+			name:               "SHUTTING DOWN",
+			path:               "/readyz",
+			ready:              false,
+			check:              func(ctx context.Context) error { return nil },
+			expectedStatusCode: 503,
+			expectedBody:       `{"status":"down","checks":{"lifecycle":{"status":"down","error":"not accepting traffic"},"postgres":{"status":"up"}}}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			hc := health.New()
+			hc.AddCheck("postgres", testCase.check)
+			hc.SetReady(testCase.ready)
+
+			r := gin.New()
+			newHealthRoutes(r, hc)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type projectController struct {
+	l           logger.Interface
+	useCase     entity.ProjectUseCase
+	todoUseCase entity.TodoUseCase
+}
+
+func newProjectRoutes(handler *gin.RouterGroup, useCase entity.ProjectUseCase, todoUseCase entity.TodoUseCase, l logger.Interface) {
+	r := &projectController{l: l, useCase: useCase, todoUseCase: todoUseCase}
+
+	h := handler.Group("/projects")
+	{
+		h.GET("", r.getProjects)
+		h.GET("/:id", r.getProjectById)
+		h.POST("", r.createProject)
+		h.PATCH("/:id", r.updateProject)
+		h.GET("/:id/todo", r.getProjectTasks)
+		h.POST("/:id/todo", r.createProjectTask)
+	}
+}
+
+// @Summary      Get projects
+// @Description  Get projects ordered by name
+// @Tags         projects
+// @Produce      json
+// @Param        include_archived    query     bool  false  "list archived projects too" example(true)
+// @Success      200  {array}   entity.Project
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Router       /projects [get]
+func (p *projectController) getProjects(gc *gin.Context) {
+	includeArchived := false
+	if value := gc.Query("include_archived"); value != "" {
+		var err error
+		if includeArchived, err = strconv.ParseBool(value); err != nil {
+			gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "error query params",
+			})
+			return
+		}
+	}
+
+	projects, err := p.useCase.GetProjects(gc.Request.Context(), includeArchived)
+	if err != nil {
+		p.l.Error(err, "http - v1 - get projects")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error get projects",
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &projects)
+}
+
+// @Summary      Get project
+// @Description  Get project by id
+// @Tags         projects
+// @Produce      json
+// @Param        id    path      int  true "Project ID"
+// @Success      200  {object}   entity.Project
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /projects/{id} [get]
+func (p *projectController) getProjectById(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		p.l.Error(err, "http - v1 - get project")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	project, err := p.useCase.GetProjectById(gc.Request.Context(), uint(id))
+	if err != nil {
+		p.l.Error(err, "http - v1 - get project")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &project)
+}
+
+// @Summary      Create project
+// @Description  Create project with optional defaults for the status and priority of its new tasks
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project  body      entity.Project  true "Project"
+// @Success      200  {object}   entity.Project
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Router       /projects [post]
+func (p *projectController) createProject(gc *gin.Context) {
+	var project entity.Project
+	if err := bind(gc, &project); err != nil {
+		p.l.Error(err, "http - v1 - create project")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := p.useCase.SaveProject(gc.Request.Context(), &project); err != nil {
+		p.l.Error(err, "http - v1 - create project")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &project)
+}
+
+// @Summary      Update project
+// @Description  Update some fields of a project; archiving hides its tasks from the task list
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true "Project ID"
+// @Param        project  body      entity.ProjectPatch  true "Fields to change"
+// @Success      200  {object}   entity.Project
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /projects/{id} [patch]
+func (p *projectController) updateProject(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		p.l.Error(err, "http - v1 - update project")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	var patch entity.ProjectPatch
+	if err = bind(gc, &patch); err != nil {
+		p.l.Error(err, "http - v1 - update project")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	project, err := p.useCase.UpdateProject(gc.Request.Context(), uint(id), patch)
+	if err != nil {
+		p.l.Error(err, "http - v1 - update project")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &project)
+}
+
+// @Summary      Get project tasks
+// @Description  Get the tasks of a project, archived or not; takes the same filters as the task list
+// @Tags         projects
+// @Produce      json
+// @Param        id    path      int  true "Project ID"
+// @Param        page    query     string  false  "page" example(2)
+// @Param        limit    query     string  false  "limit" example(3)
+// @Param        fields    query     string  false  "comma separated fields to return" example(id,description,status)
+// @Param        include    query     string  false  "comma separated related resources to return: tags, assignees, comment_count" example(tags)
+// @Success      200  {array}   entity.Todo
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /projects/{id}/todo [get]
+func (p *projectController) getProjectTasks(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		p.l.Error(err, "http - v1 - get project tasks")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	values := gc.Request.URL.Query()
+	projection, err := taskProjection(values)
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	filterOptions, pagination, err := httpquery.ParseQueryParams(values)
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error query params",
+		})
+		return
+	}
+
+	if _, err = p.useCase.GetProjectById(gc.Request.Context(), uint(id)); err != nil {
+		p.l.Error(err, "http - v1 - get project tasks")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	filterOptions = append(filterOptions,
+		httpquery.FilterOption{Operator: "eq", Field: "project_id", Value: strconv.Itoa(id)},
+		httpquery.FilterOption{Field: "include_archived", Value: "true"},
+	)
+	tasks, err := p.todoUseCase.GetTasks(gc.Request.Context(), filterOptions, pagination, projection)
+	if err != nil {
+		p.l.Error(err, "http - v1 - get project tasks")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error get tasks",
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, sparseTasks(tasks, projection))
+}
+
+// @Summary      Create project task
+// @Description  Create a todo task in the project; status and priority default to those of the project
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true "Project ID"
+// @Param        task  body      entity.Todo  true "Todo task"
+// @Success      200  {object}   entity.Todo
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Failure 409 {string} string "{"error": "project is archived: 1"}"
+// @Router       /projects/{id}/todo [post]
+func (p *projectController) createProjectTask(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		p.l.Error(err, "http - v1 - create project task")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	var task entity.Todo
+	if err = bind(gc, &task); err != nil {
+		p.l.Error(err, "http - v1 - create project task")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	projectID := uint(id)
+	task.ProjectID = &projectID
+
+	if err = p.todoUseCase.SaveTask(gc.Request.Context(), &task); err != nil {
+		p.l.Error(err, "http - v1 - create project task")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &task)
+}
@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestController_CreateProjectTask(t *testing.T) {
+	projectID := uint(1)
+
+	testTable := []struct {
+		name               string
+		inputBody          string
+		mockBehavior       func(u *mock_entity.MockTodoUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"description":"new Task"}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().SaveTask(gomock.Any(), &entity.Todo{Description: "new Task", ProjectID: &projectID}).
+					DoAndReturn(func(_ interface{}, task *entity.Todo) error {
+						task.Status = entity.StatusOpen
+						task.Priority = entity.PriorityHigh
+						return nil
+					})
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"description":"new Task","status":"open","priority":"high","due_at":null,"parent_id":null,"project_id":1,"blocked":false,"comment_count":0,"version":0}`,
+		},
+		{
+			name:      "ARCHIVED",
+			inputBody: `{"description":"new Task"}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().SaveTask(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: %d", entity.ErrProjectArchived, 1))
+			},
+			expectedStatusCode: 409,
+			expectedBody:       `{"error":"project is archived: 1"}`,
+		},
+		{
+			name:      "NOT FOUND",
+			inputBody: `{"description":"new Task"}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().SaveTask(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: %d", entity.ErrProjectNotFound, 1))
+			},
+			expectedStatusCode: 404,
+			expectedBody:       `{"error":"project not found: 1"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+
+			testCase.mockBehavior(todoUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &projectController{l: l, todoUseCase: todoUseCase}
+
+			r.POST("/api/v1/projects/:id/todo", c.createProjectTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/projects/1/todo", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestController_GetProjectTasks(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		mockBehavior       func(u *mock_entity.MockProjectUseCase, todo *mock_entity.MockTodoUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(u *mock_entity.MockProjectUseCase, todo *mock_entity.MockTodoUseCase) {
+				u.EXPECT().GetProjectById(gomock.Any(), uint(1)).Return(&entity.Project{Name: "legacy", Archived: true}, nil)
+				todo.EXPECT().GetTasks(gomock.Any(), []httpquery.FilterOption{
+					{Operator: "eq", Field: "status", Value: "open"},
+					{Operator: "eq", Field: "project_id", Value: "1"},
+					{Field: "include_archived", Value: "true"},
+				}, httpquery.Pagination{Limit: 100}, entity.Projection{}).Return([]entity.Todo{}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `[]`,
+		},
+		{
+			name: "NOT FOUND",
+			mockBehavior: func(u *mock_entity.MockProjectUseCase, todo *mock_entity.MockTodoUseCase) {
+				u.EXPECT().GetProjectById(gomock.Any(), uint(1)).Return(nil, fmt.Errorf("%w: %d", entity.ErrProjectNotFound, 1))
+			},
+			expectedStatusCode: 404,
+			expectedBody:       `{"error":"project not found: 1"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			projectUseCase := mock_entity.NewMockProjectUseCase(ctrl)
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+
+			testCase.mockBehavior(projectUseCase, todoUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &projectController{l: l, useCase: projectUseCase, todoUseCase: todoUseCase}
+
+			r.GET("/api/v1/projects/:id/todo", c.getProjectTasks)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/v1/projects/1/todo?status=eq:open", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
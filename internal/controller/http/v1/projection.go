@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"net/url"
+	"strings"
+)
+
+// _taskKeys are the keys of task fields in the JSON form of a task, where they differ from the field names.
+var _taskKeys = map[string]string{"id": "ID", "created_at": "CreatedAt", "updated_at": "UpdatedAt"}
+
+// taskProjection takes the fields and include params of a task read out of its query, so that they are not read
+// as filters; each may be given more than once.
+func taskProjection(values url.Values) (entity.Projection, error) {
+	projection, err := entity.ParseProjection(strings.Join(values["fields"], ","), strings.Join(values["include"], ","))
+	values.Del("fields")
+	values.Del("include")
+	return projection, err
+}
+
+// sparseTask is a task written with only the fields and related resources of a projection, in that order.
+// Fields asked for are always written, null when the task has no value, and related resources empty.
+type sparseTask struct {
+	task       *entity.Todo
+	projection entity.Projection
+}
+
+func (s sparseTask) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(s.task)
+	if err != nil {
+		return nil, err
+	}
+	var members map[string]json.RawMessage
+	if err = json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	fields := s.projection.Fields
+	if len(fields) == 0 {
+		fields = entity.TaskFields
+	}
+
+	var buf bytes.Buffer
+	write := func(key string, fallback string) {
+		if buf.Len() > 0 {
+			buf.WriteByte(',')
+		} else {
+			buf.WriteByte('{')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		if value, ok := members[key]; ok {
+			buf.Write(value)
+		} else {
+			buf.WriteString(fallback)
+		}
+	}
+	for _, field := range fields {
+		key := field
+		if k, ok := _taskKeys[field]; ok {
+			key = k
+		}
+		write(key, "null")
+	}
+	for _, include := range entity.TaskIncludes {
+		if s.projection.Includes(include) {
+			write(include, "[]")
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// sparseTasks narrows the tasks to the projection for writing; without one they are written whole.
+func sparseTasks(tasks []entity.Todo, projection entity.Projection) interface{} {
+	if !projection.Sparse() {
+		return tasks
+	}
+	sparse := make([]sparseTask, len(tasks))
+	for i := range tasks {
+		sparse[i] = sparseTask{task: &tasks[i], projection: projection}
+	}
+	return sparse
+}
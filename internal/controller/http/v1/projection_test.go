@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"github.com/Vaixle/crud-golang/internal/controller/http/midleware"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestController_SparseTasks(t *testing.T) {
+	task := entity.Todo{
+		Model: gorm.Model{
+			ID:        3,
+			CreatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+		},
+		Description:  "new Task3",
+		Status:       "open",
+		Tags:         []entity.Tag{{Model: gorm.Model{ID: 1}, Name: "home"}},
+		CommentCount: 2,
+		Version:      2,
+	}
+
+	testTable := []struct {
+		name               string
+		path               string
+		accept             string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "FIELDS OF LIST",
+			path:               "/api/v1/todo?fields=id,description,status",
+			expectedStatusCode: 200,
+			expectedBody:       `[{"ID":3,"description":"new Task3","status":"open"}]`,
+		},
+		{
+			name:               "REPEATED FIELDS",
+			path:               "/api/v1/todo?fields=id&fields=status&include=tags&include=comment_count",
+			expectedStatusCode: 200,
+			expectedBody:       `[{"ID":3,"status":"open","tags":[{"ID":1,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"name":"home"}],"comment_count":2}]`,
+		},
+		{
+			name:               "FIELDS AND INCLUDE OF TASK",
+			path:               "/api/v1/todo/3?fields=id,status&include=comment_count,tags",
+			expectedStatusCode: 200,
+			expectedBody:       `{"ID":3,"status":"open","tags":[{"ID":1,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"name":"home"}],"comment_count":2}`,
+		},
+		{
+			name:               "YAML",
+			path:               "/api/v1/todo?fields=id,description",
+			accept:             "application/yaml",
+			expectedStatusCode: 200,
+			expectedBody:       "- ID: 3\n  description: new Task3\n",
+		},
+		{
+			name:               "UNKNOWN FIELD",
+			path:               "/api/v1/todo?fields=id,deleted_at",
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"unknown field: \"deleted_at\""}`,
+		},
+		{
+			name:               "UNKNOWN INCLUDE",
+			path:               "/api/v1/todo/3?include=project",
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"unknown field: \"project\""}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+			// The params are read as a projection, never as filters.
+			todoUseCase.EXPECT().GetTasks(gomock.Any(), gomock.Len(0), gomock.Any(), gomock.Any()).Return([]entity.Todo{task}, nil).AnyTimes()
+			todoUseCase.EXPECT().GetTaskById(gomock.Any(), uint(3)).Return(&task, nil).AnyTimes()
+
+			r := gin.New()
+			r.Use(midleware.Negotiate())
+			c := &todoController{l: logger.New("info"), useCase: todoUseCase}
+			r.GET("/api/v1/todo", c.getTodoTasks)
+			r.GET("/api/v1/todo/:id", c.getTaskById)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.path, nil)
+			if testCase.accept != "" {
+				req.Header.Set("Accept", testCase.accept)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/controller/http/midleware"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/mediatype"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"io"
+	"net/http"
+)
+
+// respond writes the value in the media type negotiated for the request. Tasks are written to CSV in the
+// columns of an export, anything else in the columns of its JSON form.
+func respond(gc *gin.Context, status int, value interface{}) {
+	responseType := midleware.ResponseType(gc)
+	if responseType == mediatype.JSON {
+		gc.JSON(status, value)
+		return
+	}
+
+	var data []byte
+	var err error
+	if tasks, ok := taskRows(value); ok && responseType == mediatype.CSV {
+		var buf bytes.Buffer
+		writer := newTaskWriter(formatCSV, &buf)
+		if err = writer.Write(tasks); err == nil {
+			err = writer.Close()
+		}
+		data = buf.Bytes()
+	} else {
+		data, err = mediatype.Marshal(responseType, value)
+	}
+	if err != nil {
+		_ = gc.Error(err)
+		gc.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "error encode response",
+		})
+		return
+	}
+
+	gc.Data(status, mediatype.ContentType(responseType), data)
+}
+
+func taskRows(value interface{}) ([]entity.Todo, bool) {
+	switch value := value.(type) {
+	case []entity.Todo:
+		return value, true
+	case *entity.Todo:
+		return []entity.Todo{*value}, true
+	default:
+		return nil, false
+	}
+}
+
+// bind decodes the request body by its Content-Type, as JSON when it has none, and validates it the way
+// ShouldBindJSON does. A body of another type than JSON, YAML, XML or MessagePack is mediatype.ErrUnsupported.
+func bind(gc *gin.Context, obj interface{}) error {
+	contentType := gc.ContentType()
+	if contentType == "" {
+		return gc.ShouldBindJSON(obj)
+	}
+	mediaType, ok := mediatype.Lookup(contentType)
+	if !ok || mediaType == mediatype.CSV {
+		return fmt.Errorf("%w: %s", mediatype.ErrUnsupported, contentType)
+	}
+	if mediaType == mediatype.JSON {
+		return gc.ShouldBindJSON(obj)
+	}
+
+	body, err := io.ReadAll(gc.Request.Body)
+	if err != nil {
+		return err
+	}
+	if err = mediatype.Unmarshal(mediaType, body, obj); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
@@ -0,0 +1,239 @@
+package v1
+
+import (
+	"bytes"
+	"github.com/Vaixle/crud-golang/internal/controller/http/midleware"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/Vaixle/crud-golang/pkg/mediatype"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestController_RenderTasks(t *testing.T) {
+	task := entity.Todo{
+		Model: gorm.Model{
+			ID:        3,
+			CreatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+		},
+		Description: "new Task3",
+		Status:      "open",
+		Version:     2,
+	}
+
+	testTable := []struct {
+		name                string
+		path                string
+		accept              string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "JSON",
+			path:                "/api/v1/todo",
+			expectedStatusCode:  200,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `[{"ID":3,"CreatedAt":"2023-01-01T12:00:00Z","UpdatedAt":"2023-01-01T12:00:00Z","DeletedAt":null,"description":"new Task3","status":"open","priority":"","due_at":null,"parent_id":null,"project_id":null,"blocked":false,"comment_count":0,"version":2}]`,
+		},
+		{
+			name:                "YAML",
+			path:                "/api/v1/todo",
+			accept:              "application/yaml",
+			expectedStatusCode:  200,
+			expectedContentType: "application/yaml; charset=utf-8",
+			expectedBody: "- ID: 3\n  CreatedAt: \"2023-01-01T12:00:00Z\"\n  UpdatedAt: \"2023-01-01T12:00:00Z\"\n  DeletedAt: null\n" +
+				"  description: new Task3\n  status: open\n  priority: \"\"\n  due_at: null\n  parent_id: null\n  project_id: null\n" +
+				"  blocked: false\n  comment_count: 0\n  version: 2\n",
+		},
+		{
+			name:                "XML",
+			path:                "/api/v1/todo",
+			accept:              "text/xml",
+			expectedStatusCode:  200,
+			expectedContentType: "application/xml; charset=utf-8",
+			expectedBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<response><item><ID>3</ID><CreatedAt>2023-01-01T12:00:00Z</CreatedAt><UpdatedAt>2023-01-01T12:00:00Z</UpdatedAt><DeletedAt></DeletedAt>` +
+				`<description>new Task3</description><status>open</status><priority></priority><due_at></due_at><parent_id></parent_id><project_id></project_id>` +
+				`<blocked>false</blocked><comment_count>0</comment_count><version>2</version></item></response>`,
+		},
+		{
+			name:                "CSV BY FORMAT PARAM",
+			path:                "/api/v1/todo?format=csv",
+			accept:              "application/json",
+			expectedStatusCode:  200,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "id,description,status,priority,due_at,parent_id,project_id,recurrence,timezone,occurrence,series_id,tag_ids,assignee_ids,progress,blocked,comment_count,created_by,updated_by,version,created_at,updated_at\n" +
+				"3,new Task3,open,,,,,,,0,,,,,false,0,,,2,2023-01-01T12:00:00Z,2023-01-01T12:00:00Z\n",
+		},
+		{
+			name:                "CSV OF ONE TASK",
+			path:                "/api/v1/todo/3",
+			accept:              "text/csv",
+			expectedStatusCode:  200,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "id,description,status,priority,due_at,parent_id,project_id,recurrence,timezone,occurrence,series_id,tag_ids,assignee_ids,progress,blocked,comment_count,created_by,updated_by,version,created_at,updated_at\n" +
+				"3,new Task3,open,,,,,,,0,,,,,false,0,,,2,2023-01-01T12:00:00Z,2023-01-01T12:00:00Z\n",
+		},
+		{
+			name:                "NOT ACCEPTABLE",
+			path:                "/api/v1/todo",
+			accept:              "text/html",
+			expectedStatusCode:  406,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"not acceptable, available types: application/json, application/yaml, application/xml, application/msgpack, text/csv"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+			todoUseCase.EXPECT().GetTasks(gomock.Any(), gomock.Len(0), gomock.Any(), gomock.Any()).Return([]entity.Todo{task}, nil).AnyTimes()
+			todoUseCase.EXPECT().GetTaskById(gomock.Any(), uint(3)).Return(&task, nil).AnyTimes()
+
+			r := gin.New()
+			r.Use(midleware.Negotiate())
+			c := &todoController{l: logger.New("info"), useCase: todoUseCase}
+			r.GET("/api/v1/todo", c.getTodoTasks)
+			r.GET("/api/v1/todo/:id", c.getTaskById)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.path, nil)
+			if testCase.accept != "" {
+				req.Header.Set("Accept", testCase.accept)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestController_RenderMsgPack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tasks := []entity.Todo{{Model: gorm.Model{ID: 3}, Description: "new Task3", Status: "open", Version: 2}}
+	todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+	todoUseCase.EXPECT().GetTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tasks, nil)
+
+	r := gin.New()
+	r.Use(midleware.Negotiate())
+	c := &todoController{l: logger.New("info"), useCase: todoUseCase}
+	r.GET("/api/v1/todo", c.getTodoTasks)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/v1/todo", nil)
+	req.Header.Set("Accept", "application/x-msgpack")
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "application/msgpack", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	var got []entity.Todo
+	require.NoError(t, mediatype.Unmarshal(mediatype.MsgPack, w.Body.Bytes(), &got))
+	assert.Equal(t, tasks, got)
+}
+
+func TestController_BindTask(t *testing.T) {
+	testTable := []struct {
+		name               string
+		contentType        string
+		inputBody          []byte
+		inputTodoTask      *entity.Todo
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "YAML",
+			contentType:        "application/yaml",
+			inputBody:          []byte("description: new Task3\nstatus: open\ntag_ids: [1, 2]\n"),
+			inputTodoTask:      &entity.Todo{Description: "new Task3", Status: "open", TagIDs: []uint{1, 2}},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "XML",
+			contentType:        "application/xml; charset=utf-8",
+			inputBody:          []byte(`<task><description>new Task3</description><status>open</status><tag_ids><id>1</id><id>2</id></tag_ids><due_at/></task>`),
+			inputTodoTask:      &entity.Todo{Description: "new Task3", Status: "open", TagIDs: []uint{1, 2}},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "MSGPACK",
+			contentType:        "application/msgpack",
+			inputBody:          msgpackBody(t, map[string]interface{}{"description": "new Task3", "status": "open"}),
+			inputTodoTask:      &entity.Todo{Description: "new Task3", Status: "open"},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "INVALID XML",
+			contentType:        "application/xml",
+			inputBody:          []byte(`<task><description>new Task3</description><tag_ids><id>one</id></tag_ids></task>`),
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"tag_ids: strconv.ParseUint: parsing \"one\": invalid syntax"}`,
+		},
+		{
+			name:               "VALIDATION",
+			contentType:        "application/yaml",
+			inputBody:          []byte("status: open\n"),
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"Key: 'Todo.Description' Error:Field validation for 'Description' failed on the 'required' tag"}`,
+		},
+		{
+			name:               "UNSUPPORTED",
+			contentType:        "text/plain",
+			inputBody:          []byte("new Task3"),
+			expectedStatusCode: 415,
+			expectedBody:       `{"error":"unsupported media type: text/plain"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+			if testCase.inputTodoTask != nil {
+				todoUseCase.EXPECT().SaveTask(gomock.Any(), testCase.inputTodoTask).Return(nil)
+			}
+
+			r := gin.New()
+			r.Use(midleware.Negotiate())
+			c := &todoController{l: logger.New("info"), useCase: todoUseCase}
+			r.POST("/api/v1/todo", c.createTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/todo", bytes.NewReader(testCase.inputBody))
+			req.Header.Set("Content-Type", testCase.contentType)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedBody != "" {
+				assert.Equal(t, testCase.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func msgpackBody(t *testing.T, value interface{}) []byte {
+	data, err := mediatype.Marshal(mediatype.MsgPack, value)
+	require.NoError(t, err)
+	return data
+}
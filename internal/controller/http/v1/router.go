@@ -3,8 +3,11 @@ package v1
 import (
 	"github.com/Vaixle/crud-golang/internal/controller/http/midleware"
 	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/health"
 	"github.com/Vaixle/crud-golang/pkg/logger"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	// Swagger docs.
@@ -29,19 +32,33 @@ import (
 // @BasePath  /api/v1
 
 // @securityDefinitions.basic  BasicAuth
-func NewRouter(handler *gin.Engine, useCase entity.TodoUseCase, l logger.Interface) {
+func NewRouter(handler *gin.Engine, useCase entity.TodoUseCase, tagUseCase entity.TagUseCase, projectUseCase entity.ProjectUseCase, userUseCase entity.UserUseCase, commentUseCase entity.CommentUseCase, attachmentUseCase entity.AttachmentUseCase, auditUseCase entity.AuditUseCase, idempotencyUseCase entity.IdempotencyUseCase, hc *health.Health, reg prometheus.Registerer, l logger.Interface) {
 	// Options
-	handler.Use(gin.Logger())
+	handler.Use(midleware.Tracing(viper.GetString("tracing.service_name"), "/healthz", "/readyz", "/metrics"))
+	handler.Use(midleware.Logger())
 	handler.Use(gin.Recovery())
+	handler.Use(midleware.Metrics(reg))
 
 	// Swagger
 	swaggerHandler := ginSwagger.DisablingWrapHandler(swaggerFiles.Handler, "DISABLE_SWAGGER_HTTP_HANDLER")
 	handler.GET("/swagger/*any", swaggerHandler)
 
+	// Probes
+	newHealthRoutes(handler, hc)
+
 	// Routers
 	h := handler.Group("/api/v1")
-	h.Use(midleware.BasicAuth())
+	h.Use(midleware.BasicAuth(), midleware.Actor(userUseCase))
+	// Export, import and attachment content have file formats of their own.
+	h.Use(midleware.Negotiate("/api/v1/todo/export", "/api/v1/todo/import", "/api/v1/todo/:id/attachments/:attachment_id/content"))
+	h.Use(midleware.CacheControl(viper.GetStringMapString("http.cache_control")))
 	{
-		newTODORoutes(h, useCase, l)
+		newTODORoutes(h, useCase, idempotencyUseCase, l)
+		newTagRoutes(h, tagUseCase, l)
+		newProjectRoutes(h, projectUseCase, useCase, l)
+		newUserRoutes(h, userUseCase, useCase, l)
+		newCommentRoutes(h, commentUseCase, l)
+		newAttachmentRoutes(h, attachmentUseCase, viper.GetInt64("attachments.max_size"), l)
+		newAuditRoutes(h, auditUseCase, l)
 	}
 }
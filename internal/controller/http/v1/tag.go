@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type tagController struct {
+	l       logger.Interface
+	useCase entity.TagUseCase
+}
+
+func newTagRoutes(handler *gin.RouterGroup, useCase entity.TagUseCase, l logger.Interface) {
+	r := &tagController{l: l, useCase: useCase}
+
+	h := handler.Group("/tags")
+	{
+		h.GET("", r.getTags)
+		h.GET("/:id", r.getTagById)
+		h.POST("", r.createTag)
+		h.PUT("/:id", r.updateTag)
+		h.DELETE("/:id", r.deleteTag)
+	}
+}
+
+// @Summary      Get tags
+// @Description  Get all tags ordered by name
+// @Tags         tags
+// @Produce      json
+// @Success      200  {array}   entity.Tag
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Router       /tags [get]
+func (t *tagController) getTags(gc *gin.Context) {
+	tags, err := t.useCase.GetTags(gc.Request.Context())
+	if err != nil {
+		t.l.Error(err, "http - v1 - get tags")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error get tags",
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &tags)
+}
+
+// @Summary      Get tag
+// @Description  Get tag by id
+// @Tags         tags
+// @Produce      json
+// @Param        id    path      int  true "Tag ID"
+// @Success      200  {object}   entity.Tag
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /tags/{id} [get]
+func (t *tagController) getTagById(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get tag")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	tag, err := t.useCase.GetTagById(gc.Request.Context(), uint(id))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get tag")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &tag)
+}
+
+// @Summary      Create tag
+// @Description  Create tag with a unique name
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        tag  body      entity.Tag  true "Tag"
+// @Success      200  {object}   entity.Tag
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 409 {string} string "{"error": "tag already exists: \"bug\""}"
+// @Router       /tags [post]
+func (t *tagController) createTag(gc *gin.Context) {
+	var tag entity.Tag
+	if err := bind(gc, &tag); err != nil {
+		t.l.Error(err, "http - v1 - create tag")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := t.useCase.SaveTag(gc.Request.Context(), &tag); err != nil {
+		t.l.Error(err, "http - v1 - create tag")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &tag)
+}
+
+// @Summary      Rename tag
+// @Description  Rename tag; tasks keep it
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true "Tag ID"
+// @Param        tag  body      entity.Tag  true "Tag"
+// @Success      200  {object}   entity.Tag
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Failure 409 {string} string "{"error": "tag already exists: \"bug\""}"
+// @Router       /tags/{id} [put]
+func (t *tagController) updateTag(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - update tag")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	var tag entity.Tag
+	if err = bind(gc, &tag); err != nil {
+		t.l.Error(err, "http - v1 - update tag")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	tag.ID = uint(id)
+
+	if err = t.useCase.UpdateTag(gc.Request.Context(), &tag); err != nil {
+		t.l.Error(err, "http - v1 - update tag")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &tag)
+}
+
+// @Summary      Delete tag
+// @Description  Delete tag and remove it from every task
+// @Tags         tags
+// @Param        id    path      int  true "Tag ID"
+// @Success      204
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /tags/{id} [delete]
+func (t *tagController) deleteTag(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - delete tag")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	if err = t.useCase.DeleteTag(gc.Request.Context(), uint(id)); err != nil {
+		t.l.Error(err, "http - v1 - delete tag")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	gc.Status(http.StatusNoContent)
+}
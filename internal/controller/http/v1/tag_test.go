@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestController_CreateTag(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		inputBody          string
+		mockBehavior       func(u *mock_entity.MockTagUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"name":"bug"}`,
+			mockBehavior: func(u *mock_entity.MockTagUseCase) {
+				u.EXPECT().SaveTag(gomock.Any(), &entity.Tag{Name: "bug"}).DoAndReturn(func(_ interface{}, tag *entity.Tag) error {
+					tag.ID = 1
+					return nil
+				})
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"ID":1,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"name":"bug"}`,
+		},
+		{
+			name:      "EXISTS",
+			inputBody: `{"name":"bug"}`,
+			mockBehavior: func(u *mock_entity.MockTagUseCase) {
+				u.EXPECT().SaveTag(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: %q", entity.ErrTagExists, "bug"))
+			},
+			expectedStatusCode: 409,
+			expectedBody:       `{"error":"tag already exists: \"bug\""}`,
+		},
+		{
+			name:               "NO NAME",
+			inputBody:          `{}`,
+			mockBehavior:       func(u *mock_entity.MockTagUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"Key: 'Tag.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tagUseCase := mock_entity.NewMockTagUseCase(ctrl)
+
+			testCase.mockBehavior(tagUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &tagController{l: l, useCase: tagUseCase}
+
+			r.POST("/api/v1/tags", c.createTag)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/tags", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestController_DeleteTag(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		inputId            uint
+		mockBehavior       func(u *mock_entity.MockTagUseCase, id uint)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:    "OK",
+			inputId: 1,
+			mockBehavior: func(u *mock_entity.MockTagUseCase, id uint) {
+				u.EXPECT().DeleteTag(gomock.Any(), id).Return(nil)
+			},
+			expectedStatusCode: 204,
+			expectedBody:       ``,
+		},
+		{
+			name:    "NOT FOUND",
+			inputId: 2,
+			mockBehavior: func(u *mock_entity.MockTagUseCase, id uint) {
+				u.EXPECT().DeleteTag(gomock.Any(), id).Return(fmt.Errorf("%w: %d", entity.ErrTagNotFound, id))
+			},
+			expectedStatusCode: 404,
+			expectedBody:       `{"error":"tag not found: 2"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tagUseCase := mock_entity.NewMockTagUseCase(ctrl)
+
+			testCase.mockBehavior(tagUseCase, testCase.inputId)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &tagController{l: l, useCase: tagUseCase}
+
+			r.DELETE("/api/v1/tags/:id", c.deleteTag)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/v1/tags/%d", testCase.inputId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
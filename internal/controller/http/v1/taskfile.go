@@ -0,0 +1,607 @@
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/mediatype"
+	"gorm.io/gorm"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of task files.
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+	formatJSON   = "json"
+)
+
+var _fileContentTypes = map[string]string{
+	formatCSV:    "text/csv; charset=utf-8",
+	formatNDJSON: "application/x-ndjson",
+	formatJSON:   "application/json; charset=utf-8",
+}
+
+var errFileFormat = errors.New("unknown file format")
+
+// fileFormat is the format named by the format param or, failing that, by the media type of contentType.
+func fileFormat(format, contentType string) (string, error) {
+	if format == "" {
+		contentType, _, _ = strings.Cut(contentType, ";")
+		switch strings.TrimSpace(strings.ToLower(contentType)) {
+		case "text/csv":
+			return formatCSV, nil
+		case "application/x-ndjson", "application/jsonl":
+			return formatNDJSON, nil
+		case "application/json":
+			return formatJSON, nil
+		}
+		return "", fmt.Errorf("%w: %q", errFileFormat, contentType)
+	}
+	if _, ok := _fileContentTypes[format]; !ok {
+		return "", fmt.Errorf("%w: %q", errFileFormat, format)
+	}
+	return format, nil
+}
+
+// taskColumn is a column of a CSV task file. Columns without parse are only exported.
+type taskColumn struct {
+	name  string
+	value func(task *entity.Todo) string
+	parse func(task *entity.Todo, value string) error
+}
+
+// _taskColumns are the columns of an exported CSV file, in order; their names are the JSON fields of a task.
+var _taskColumns = []taskColumn{
+	{"id", func(task *entity.Todo) string { return formatUint(task.ID) }, nil},
+	{"description", func(task *entity.Todo) string { return task.Description }, func(task *entity.Todo, value string) error {
+		task.Description = value
+		return nil
+	}},
+	{"status", func(task *entity.Todo) string { return task.Status }, func(task *entity.Todo, value string) error {
+		task.Status = value
+		return nil
+	}},
+	{"priority", func(task *entity.Todo) string { return task.Priority }, func(task *entity.Todo, value string) error {
+		task.Priority = value
+		return nil
+	}},
+	{"due_at", func(task *entity.Todo) string { return formatTime(task.DueAt) }, func(task *entity.Todo, value string) (err error) {
+		task.DueAt, err = parseTime(value)
+		return err
+	}},
+	{"parent_id", func(task *entity.Todo) string { return formatID(task.ParentID) }, func(task *entity.Todo, value string) (err error) {
+		task.ParentID, err = parseID(value)
+		return err
+	}},
+	{"project_id", func(task *entity.Todo) string { return formatID(task.ProjectID) }, func(task *entity.Todo, value string) (err error) {
+		task.ProjectID, err = parseID(value)
+		return err
+	}},
+	{"recurrence", func(task *entity.Todo) string { return task.Recurrence }, func(task *entity.Todo, value string) error {
+		task.Recurrence = value
+		return nil
+	}},
+	{"timezone", func(task *entity.Todo) string { return task.Timezone }, func(task *entity.Todo, value string) error {
+		task.Timezone = value
+		return nil
+	}},
+	{"occurrence", func(task *entity.Todo) string { return strconv.Itoa(task.Occurrence) }, nil},
+	{"series_id", func(task *entity.Todo) string { return formatID(task.SeriesID) }, nil},
+	{"tag_ids", func(task *entity.Todo) string {
+		ids := make([]uint, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			ids = append(ids, tag.ID)
+		}
+		return formatIDs(ids)
+	}, func(task *entity.Todo, value string) (err error) {
+		task.TagIDs, err = parseIDs(value)
+		return err
+	}},
+	{"assignee_ids", func(task *entity.Todo) string {
+		ids := make([]uint, 0, len(task.Assignees))
+		for _, user := range task.Assignees {
+			ids = append(ids, user.ID)
+		}
+		return formatIDs(ids)
+	}, func(task *entity.Todo, value string) (err error) {
+		task.AssigneeIDs, err = parseIDs(value)
+		return err
+	}},
+	{"progress", func(task *entity.Todo) string {
+		if task.Progress == nil {
+			return ""
+		}
+		return strconv.Itoa(*task.Progress)
+	}, nil},
+	{"blocked", func(task *entity.Todo) string { return strconv.FormatBool(task.Blocked) }, nil},
+	{"comment_count", func(task *entity.Todo) string { return strconv.Itoa(task.CommentCount) }, nil},
+	{"created_by", func(task *entity.Todo) string { return formatID(task.CreatedBy) }, nil},
+	{"updated_by", func(task *entity.Todo) string { return formatID(task.UpdatedBy) }, nil},
+	{"version", func(task *entity.Todo) string { return formatUint(task.Version) }, nil},
+	{"created_at", func(task *entity.Todo) string { return formatTime(&task.CreatedAt) }, nil},
+	{"updated_at", func(task *entity.Todo) string { return formatTime(&task.UpdatedAt) }, nil},
+}
+
+// importColumn returns the column a field of an import file is read into.
+func importColumn(name string) (taskColumn, bool) {
+	for _, column := range _taskColumns {
+		if column.name == name && column.parse != nil {
+			return column, true
+		}
+	}
+	return taskColumn{}, false
+}
+
+func formatUint(value uint) string {
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+func formatID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return formatUint(*id)
+}
+
+func parseID(value string) (*uint, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		return nil, err
+	}
+	result := uint(id)
+	return &result, nil
+}
+
+// formatIDs lists ids separated by commas.
+func formatIDs(ids []uint) string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, formatUint(id))
+	}
+	return strings.Join(values, ",")
+}
+
+func parseIDs(value string) ([]uint, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var ids []uint
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 0)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// parseColumnMap reads a map param such as Title:description,Due:due_at, which names the field each source
+// column or key of an import file is read into.
+func parseColumnMap(value string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if value == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		source, target, ok := strings.Cut(pair, ":")
+		if !ok || source == "" {
+			return nil, fmt.Errorf("malformed column mapping %q", pair)
+		}
+		if _, ok := importColumn(target); !ok {
+			return nil, fmt.Errorf("column %q cannot be imported", target)
+		}
+		mapping[source] = target
+	}
+	return mapping, nil
+}
+
+// taskWriter streams tasks to an export file.
+type taskWriter interface {
+	Write(tasks []entity.Todo) error
+	// Close ends the file; it must be called even if no task was written.
+	Close() error
+}
+
+func newTaskWriter(format string, w io.Writer) taskWriter {
+	switch format {
+	case formatCSV:
+		return &csvTaskWriter{w: csv.NewWriter(w)}
+	case formatNDJSON:
+		return &jsonTaskWriter{stream: newJSONStream(w, false)}
+	default:
+		return &jsonTaskWriter{stream: newJSONStream(w, true)}
+	}
+}
+
+type csvTaskWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvTaskWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+	c.header = true
+	names := make([]string, len(_taskColumns))
+	for i, column := range _taskColumns {
+		names[i] = column.name
+	}
+	return c.w.Write(names)
+}
+
+func (c *csvTaskWriter) Write(tasks []entity.Todo) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	record := make([]string, len(_taskColumns))
+	for i := range tasks {
+		for j, column := range _taskColumns {
+			record[j] = mediatype.EscapeCSVCell(column.value(&tasks[i]))
+		}
+		if err := c.w.Write(record); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvTaskWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonTaskWriter struct {
+	stream *jsonStream
+}
+
+func (j *jsonTaskWriter) Write(tasks []entity.Todo) error {
+	for i := range tasks {
+		if err := j.stream.Write(&tasks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jsonTaskWriter) Close() error {
+	return j.stream.Close()
+}
+
+// jsonStream writes values one at a time either as a JSON array or as JSON Lines.
+type jsonStream struct {
+	w      io.Writer
+	array  bool
+	values int
+}
+
+func newJSONStream(w io.Writer, array bool) *jsonStream {
+	return &jsonStream{w: w, array: array}
+}
+
+func (j *jsonStream) Write(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !j.array:
+		data = append(data, '\n')
+	case j.values == 0:
+		data = append([]byte("["), data...)
+	default:
+		data = append([]byte(","), data...)
+	}
+	j.values++
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonStream) Close() error {
+	if !j.array {
+		return nil
+	}
+	end := "]"
+	if j.values == 0 {
+		end = "[]"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+// importRow is a record of an import file: the task read from it, or why it could not be read. The record
+// itself is kept to write it back to the error file.
+type importRow struct {
+	number int
+	task   *entity.Todo
+	err    error
+	record []string
+	raw    json.RawMessage
+}
+
+// rowReader reads an import file one row at a time.
+type rowReader interface {
+	// Next returns io.EOF after the last row; any other error means the rest of the file cannot be read.
+	Next() (*importRow, error)
+}
+
+func newRowReader(format string, r io.Reader, mapping map[string]string) (rowReader, error) {
+	switch format {
+	case formatCSV:
+		return newCSVRowReader(r, mapping)
+	case formatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), _maxImportLine)
+		return &ndjsonRowReader{scanner: scanner, mapping: mapping}, nil
+	default:
+		decoder := json.NewDecoder(r)
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return nil, errors.New("a JSON import file must hold an array of tasks")
+		}
+		return &jsonRowReader{decoder: decoder, mapping: mapping}, nil
+	}
+}
+
+// _maxImportLine is the longest line of a JSON Lines import file.
+const _maxImportLine = 1 << 20
+
+type csvRowReader struct {
+	r       *csv.Reader
+	header  []string
+	columns []*taskColumn
+	rows    int
+}
+
+func newCSVRowReader(r io.Reader, mapping map[string]string) (*csvRowReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = false
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error import header: %w", err)
+	}
+
+	columns := make([]*taskColumn, len(header))
+	described := false
+	for i, name := range header {
+		if target, ok := mapping[name]; ok {
+			name = target
+		}
+		if column, ok := importColumn(name); ok {
+			columns[i] = &column
+			described = described || column.name == "description"
+		}
+	}
+	if !described {
+		return nil, errors.New("error import header: no description column")
+	}
+	return &csvRowReader{r: reader, header: header, columns: columns}, nil
+}
+
+func (c *csvRowReader) Next() (*importRow, error) {
+	record, err := c.r.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	c.rows++
+	row := &importRow{number: c.rows, record: record}
+	if err != nil {
+		// A malformed record is rejected; the reader carries on with the next one.
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		row.err = parseErr.Err
+		return row, nil
+	}
+
+	task := &entity.Todo{}
+	for i, value := range record {
+		if i >= len(c.columns) || c.columns[i] == nil {
+			continue
+		}
+		if err := c.columns[i].parse(task, mediatype.UnescapeCSVCell(strings.TrimSpace(value))); err != nil {
+			row.err = fmt.Errorf("%s: %w", c.columns[i].name, err)
+			return row, nil
+		}
+	}
+	row.task = task
+	return row, nil
+}
+
+type ndjsonRowReader struct {
+	scanner *bufio.Scanner
+	mapping map[string]string
+	rows    int
+}
+
+func (n *ndjsonRowReader) Next() (*importRow, error) {
+	for n.scanner.Scan() {
+		line := bytes.TrimSpace(n.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		n.rows++
+		raw := append(json.RawMessage(nil), line...)
+		row := &importRow{number: n.rows, raw: raw}
+		row.task, row.err = decodeTask(raw, n.mapping)
+		return row, nil
+	}
+	if err := n.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+type jsonRowReader struct {
+	decoder *json.Decoder
+	mapping map[string]string
+	rows    int
+}
+
+func (j *jsonRowReader) Next() (*importRow, error) {
+	if !j.decoder.More() {
+		if _, err := j.decoder.Token(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	var raw json.RawMessage
+	if err := j.decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+	j.rows++
+	row := &importRow{number: j.rows, raw: raw}
+	row.task, row.err = decodeTask(raw, j.mapping)
+	return row, nil
+}
+
+// decodeTask reads a task from a JSON object whose keys are renamed by the mapping.
+func decodeTask(raw json.RawMessage, mapping map[string]string) (*entity.Todo, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	for source, target := range mapping {
+		if value, ok := fields[source]; ok {
+			delete(fields, source)
+			fields[target] = value
+		}
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	task := &entity.Todo{}
+	if err = json.Unmarshal(data, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// forImport keeps what a client may set on a new task, so that a file exported elsewhere can be imported:
+// ids, versions and timestamps are left to the server, and tags and assignees are taken by id.
+func forImport(task *entity.Todo) {
+	if len(task.TagIDs) == 0 {
+		for _, tag := range task.Tags {
+			task.TagIDs = append(task.TagIDs, tag.ID)
+		}
+	}
+	if len(task.AssigneeIDs) == 0 {
+		for _, user := range task.Assignees {
+			task.AssigneeIDs = append(task.AssigneeIDs, user.ID)
+		}
+	}
+	task.Model = gorm.Model{}
+	task.Version = 0
+	task.SeriesID = nil
+	task.CreatedBy, task.UpdatedBy = nil, nil
+	task.CommentCount = 0
+	task.Tags, task.Assignees = nil, nil
+}
+
+// rejectWriter streams the rejected rows of an import to an error file in the format of the import file, each
+// row followed by its number and the reason it was rejected, so that it can be fixed and imported again.
+type rejectWriter interface {
+	Reject(row *importRow, message string) error
+	Close() error
+}
+
+func newRejectWriter(format string, w io.Writer, reader rowReader) rejectWriter {
+	if csvReader, ok := reader.(*csvRowReader); ok && format == formatCSV {
+		return &csvRejectWriter{w: csv.NewWriter(w), header: csvReader.header}
+	}
+	return &jsonRejectWriter{stream: newJSONStream(w, format == formatJSON)}
+}
+
+type csvRejectWriter struct {
+	w      *csv.Writer
+	header []string
+	began  bool
+}
+
+func (c *csvRejectWriter) begin() error {
+	if c.began {
+		return nil
+	}
+	c.began = true
+	return c.w.Write(append(append([]string(nil), c.header...), "row", "error"))
+}
+
+func (c *csvRejectWriter) Reject(row *importRow, message string) error {
+	if err := c.begin(); err != nil {
+		return err
+	}
+	record := make([]string, len(c.header), len(c.header)+2)
+	for i := range row.record {
+		if i < len(record) {
+			record[i] = mediatype.EscapeCSVCell(row.record[i])
+		}
+	}
+	return c.w.Write(append(record, strconv.Itoa(row.number), mediatype.EscapeCSVCell(message)))
+}
+
+func (c *csvRejectWriter) Close() error {
+	if err := c.begin(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonRejectWriter struct {
+	stream *jsonStream
+}
+
+func (j *jsonRejectWriter) Reject(row *importRow, message string) error {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(row.raw, &fields); err != nil {
+		// Not an object: the text of the row is kept as it was.
+		text, _ := json.Marshal(string(row.raw))
+		fields = map[string]json.RawMessage{"raw": text}
+	}
+	fields["row"] = json.RawMessage(strconv.Itoa(row.number))
+	fields["error"], _ = json.Marshal(message)
+	return j.stream.Write(fields)
+}
+
+func (j *jsonRejectWriter) Close() error {
+	return j.stream.Close()
+}
@@ -1,13 +1,16 @@
 package v1
 
 import (
+	"errors"
 	"fmt"
+	"github.com/Vaixle/crud-golang/internal/controller/http/midleware"
 	"github.com/Vaixle/crud-golang/internal/entity"
 	"github.com/Vaixle/crud-golang/pkg/httpquery"
 	"github.com/Vaixle/crud-golang/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type todoController struct {
@@ -15,14 +18,50 @@ type todoController struct {
 	useCase entity.TodoUseCase
 }
 
-func newTODORoutes(handler *gin.RouterGroup, useCase entity.TodoUseCase, l logger.Interface) {
+type blockerRequest struct {
+	BlockerID uint `json:"blocker_id" binding:"required" example:"2"`
+}
+
+type batchRequest struct {
+	Operations []entity.BatchOperation `json:"operations" binding:"required,min=1,dive"`
+}
+
+// batchItem is the result of the operation at Index of a batch request.
+type batchItem struct {
+	Index  int          `json:"index" example:"0"`
+	Op     string       `json:"op" example:"update"`
+	ID     uint         `json:"id,omitempty" example:"1"`
+	Status int          `json:"status" example:"200"`
+	Task   *entity.Todo `json:"task,omitempty"`
+	Error  string       `json:"error,omitempty" example:"task not found"`
+}
+
+type batchResponse struct {
+	Results []batchItem `json:"results"`
+}
+
+func newTODORoutes(handler *gin.RouterGroup, useCase entity.TodoUseCase, idempotency entity.IdempotencyUseCase, l logger.Interface) {
 	r := &todoController{l: l, useCase: useCase}
 
 	h := handler.Group("/todo")
 	{
 		h.GET("", r.getTodoTasks)
+		h.GET("/order", r.getTopologicalOrder)
+		h.GET("/export", r.exportTasks)
+		h.POST("/import", r.importTasks)
 		h.GET("/:id", r.getTaskById)
-		h.POST("", r.createTask)
+		h.POST("", midleware.Idempotency(idempotency), r.createTask)
+		h.POST("/batch", midleware.Idempotency(idempotency), r.batchTasks)
+		h.PATCH("/:id", r.updateTask)
+		h.DELETE("/:id", r.deleteTask)
+		h.POST("/:id/restore", r.restoreTask)
+		h.GET("/:id/transitions", r.getTransitions)
+		h.GET("/:id/children", r.getChildren)
+		h.GET("/:id/tree", r.getTree)
+		h.GET("/:id/blockers", r.getBlockers)
+		h.POST("/:id/blockers", r.addBlocker)
+		h.DELETE("/:id/blockers/:blocker_id", r.removeBlocker)
+		h.GET("/:id/blocking", r.getBlocked)
 	}
 }
 
@@ -30,6 +69,11 @@ func newTODORoutes(handler *gin.RouterGroup, useCase entity.TodoUseCase, l logge
 // @Description  Get todo tasks
 // @Tags         todo
 // @Produce      json
+// @Produce      application/xml
+// @Produce      application/yaml
+// @Produce      application/msgpack
+// @Produce      text/csv
+// @Param        format    query     string  false  "Response type, instead of Accept" Enums(json, yaml, xml, msgpack, csv)
 // @Param        filedName1    query     string  false  "greater than" example(gt:1)
 // @Param        filedName2   query     string  false  "lower than" example(lt:1)
 // @Param        filedName3    query     string  false  "greater and equal than" example(ge:1)
@@ -38,13 +82,35 @@ func newTODORoutes(handler *gin.RouterGroup, useCase entity.TodoUseCase, l logge
 // @Param        filedName6    query     string  false  "not equal than" example(ne:1)
 // @Param        filedName7    query     string  false  "greater than" example(order_by:1)
 // @Param        filedName8    query     string  false  "like something" example(like:1)
+// @Param        overdue    query     bool  false  "open tasks past their due date" example(true)
+// @Param        due_within    query     string  false  "tasks due from now within the duration" example(24h)
+// @Param        sort    query     string  false  "priority (then due date) or due_at" Enums(priority, due_at)
+// @Param        tags    query     string  false  "tasks with any or all of the tag names" example(any:bug,urgent)
+// @Param        ready    query     bool  false  "open tasks whose blockers are all closed" example(true)
+// @Param        assignee    query     string  false  "tasks assigned to the user id, or to the current user with me" example(me)
+// @Param        unassigned    query     bool  false  "tasks nobody is assigned to" example(true)
+// @Param        include_archived    query     bool  false  "include tasks of archived projects" example(true)
+// @Param        fields    query     string  false  "comma separated fields to return" example(id,description,status)
+// @Param        include    query     string  false  "comma separated related resources to return: tags, assignees, comment_count" example(tags)
 // @Param        page    query     string  false  "page" example(2)
 // @Param        limit    query     string  false  "limit" example(3)
+// @Param        If-None-Match    header     string  false  "ETag of a page already held" example(W/"5d41402abc4b2a76b9719d911017c592")
 // @Success      200  {array}   entity.Todo
+// @Header       200  {string}  ETag  "Weak tag of the page"
+// @Success      304
 // @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 406 {string} string "{"error": "not acceptable, available types: application/json, application/yaml, application/xml, application/msgpack, text/csv"}"
 // @Router       /todo [get]
 func (t *todoController) getTodoTasks(gc *gin.Context) {
-	filterOptions, pagination, err := httpquery.ParseQueryParams(gc.Request.URL.Query())
+	values := gc.Request.URL.Query()
+	projection, err := taskProjection(values)
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	filterOptions, pagination, err := httpquery.ParseQueryParams(values)
 	if err != nil {
 		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": "error query params",
@@ -52,7 +118,7 @@ func (t *todoController) getTodoTasks(gc *gin.Context) {
 		return
 	}
 
-	tasks, err := t.useCase.GetTasks(filterOptions, pagination)
+	tasks, err := t.useCase.GetTasks(gc.Request.Context(), filterOptions, pagination, projection)
 	if err != nil {
 		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": "error get tasks",
@@ -60,16 +126,33 @@ func (t *todoController) getTodoTasks(gc *gin.Context) {
 		return
 	}
 
-	gc.JSON(http.StatusOK, &tasks)
+	tag, lastModified := listETag(gc, tasks)
+	if notModified(gc, tag, lastModified) {
+		return
+	}
+	respond(gc, http.StatusOK, sparseTasks(tasks, projection))
 }
 
 // @Summary      Get todo task
-// @Description  Get todo task by id
+// @Description  Get todo task by id; the ETag header carries the task version for If-Match and If-None-Match
 // @Tags         todo
 // @Produce      json
+// @Produce      application/xml
+// @Produce      application/yaml
+// @Produce      application/msgpack
+// @Produce      text/csv
+// @Param        format    query     string  false  "Response type, instead of Accept" Enums(json, yaml, xml, msgpack, csv)
 // @Param        id    path      int  true "Todo task ID"
+// @Param        fields    query     string  false  "comma separated fields to return" example(id,description,status)
+// @Param        include    query     string  false  "comma separated related resources to return: tags, assignees, comment_count" example(tags)
+// @Param        If-None-Match    header     string  false  "ETag of the representation already held" example("3-1747d27d")
+// @Param        If-Modified-Since    header     string  false  "Last-Modified of the version already held"
 // @Success      200  {object}   entity.Todo
+// @Header       200  {string}  ETag  "Task version"
+// @Header       200  {string}  Last-Modified  "Time of the last change"
+// @Success      304
 // @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 406 {string} string "{"error": "not acceptable, available types: application/json, application/yaml, application/xml, application/msgpack, text/csv"}"
 // @Router       /todo/{id} [get]
 func (t *todoController) getTaskById(gc *gin.Context) {
 	idStr := gc.Param("id")
@@ -79,9 +162,17 @@ func (t *todoController) getTaskById(gc *gin.Context) {
 		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": "error id param",
 		})
+		return
+	}
+	projection, err := taskProjection(gc.Request.URL.Query())
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
 	}
 
-	task, err := t.useCase.GetTaskById(uint(id))
+	task, err := t.useCase.GetTaskById(gc.Request.Context(), uint(id))
 	if err != nil {
 		t.l.Error(err, "http - v1 - get task")
 		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
@@ -90,34 +181,551 @@ func (t *todoController) getTaskById(gc *gin.Context) {
 		return
 	}
 
-	gc.JSON(http.StatusOK, &task)
+	if notModified(gc, etag(gc, task, projection), task.UpdatedAt) {
+		return
+	}
+	if projection.Sparse() {
+		respond(gc, http.StatusOK, sparseTask{task: task, projection: projection})
+		return
+	}
+	respond(gc, http.StatusOK, task)
 }
 
 // @Summary      Create todo task
-// @Description  Create todo task
+// @Description  Create todo task. A retry with the same Idempotency-Key and body gets the first response again, marked with Idempotent-Replayed, instead of creating another task.
 // @Tags         todo
 // @Accept       json
+// @Accept       application/xml
+// @Accept       application/yaml
+// @Accept       application/msgpack
 // @Produce      json
+// @Param        Idempotency-Key    header     string  false  "Key that makes retries of the request safe" example(5f0c1a4e-7f3b-4a8e-9a57-1f0e5f6c2b9d)
 // @Success      200  {object}   entity.Todo
 // @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 409 {string} string "{"error": "a request with the idempotency key is in progress: \"5f0c\""}"
+// @Failure 415 {string} string "{"error": "unsupported media type: text/plain"}"
+// @Failure 422 {string} string "{"error": "idempotency key already used for a different request: \"5f0c\""}"
 // @Router       /todo [post]
 func (t *todoController) createTask(gc *gin.Context) {
 	var task entity.Todo
-	if err := gc.ShouldBindJSON(&task); err != nil {
+	if err := bind(gc, &task); err != nil {
 		t.l.Error(err, "http - v1 - create task")
-		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
 
-	if err := t.useCase.SaveTask(&task); err != nil {
+	if err := t.useCase.SaveTask(gc.Request.Context(), &task); err != nil {
 		t.l.Error(err, "http - v1 - save task")
 		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"error": "create task",
+			"error": createMessage(err),
+		})
+		return
+	}
+
+	gc.Header("ETag", etag(gc, &task, entity.Projection{}))
+	respond(gc, http.StatusOK, &task)
+}
+
+// createMessage is the message of an error creating a task; errors that are not down to the task are not detailed.
+func createMessage(err error) string {
+	if errors.Is(err, entity.ErrUnknownStatus) || errors.Is(err, entity.ErrUnknownTag) ||
+		errors.Is(err, entity.ErrInvalidParent) || errors.Is(err, entity.ErrInvalidRecurrence) ||
+		errors.Is(err, entity.ErrProjectNotFound) || errors.Is(err, entity.ErrProjectArchived) ||
+		errors.Is(err, entity.ErrUnknownUser) || errors.Is(err, entity.ErrInvalidOperation) ||
+		errors.Is(err, entity.ErrBatchRolledBack) {
+		return err.Error()
+	}
+	return "create task"
+}
+
+// @Summary      Batch create, update and delete todo tasks
+// @Description  Apply a list of operations. By default they all succeed or none is applied, the response taking the status of the failed operation; with atomic=false each operation stands on its own and partial success is answered with 207. Each result carries the status the operation would have had on its own.
+// @Tags         todo
+// @Accept       json
+// @Produce      json
+// @Param        atomic    query     bool  false  "Apply all operations or none" example(false)
+// @Param        Idempotency-Key    header     string  false  "Key that makes retries of the request safe" example(5f0c1a4e-7f3b-4a8e-9a57-1f0e5f6c2b9d)
+// @Param        batch  body      batchRequest  true "Operations in the order they are applied"
+// @Success      200  {object}   batchResponse
+// @Success      207  {object}   batchResponse
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 413 {string} string "{"error": "too many operations in the batch: 1500, the limit is 1000"}"
+// @Router       /todo/batch [post]
+func (t *todoController) batchTasks(gc *gin.Context) {
+	atomic := true
+	if value := gc.Query("atomic"); value != "" {
+		var err error
+		if atomic, err = strconv.ParseBool(value); err != nil {
+			gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "error atomic param",
+			})
+			return
+		}
+	}
+
+	var request batchRequest
+	if err := bind(gc, &request); err != nil {
+		t.l.Error(err, "http - v1 - batch tasks")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	results, err := t.useCase.Batch(gc.Request.Context(), request.Operations, atomic)
+	if err != nil {
+		t.l.Error(err, "http - v1 - batch tasks")
+		if results == nil {
+			gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+	}
+
+	status := http.StatusOK
+	response := batchResponse{Results: make([]batchItem, 0, len(results))}
+	for i, result := range results {
+		item := batchItem{Index: i, Op: result.Op, ID: result.ID, Task: result.Task, Status: batchStatus(result.Op)}
+		if result.Err != nil {
+			status = http.StatusMultiStatus
+			item.Status, item.Error = errorStatus(result.Err), result.Err.Error()
+			if result.Op == entity.BatchCreate {
+				item.Error = createMessage(result.Err)
+			}
+		}
+		response.Results = append(response.Results, item)
+	}
+	if err != nil {
+		status = errorStatus(err)
+	}
+	respond(gc, status, response)
+}
+
+// batchStatus is the status of a successful operation of a batch.
+func batchStatus(op string) int {
+	switch op {
+	case entity.BatchCreate:
+		return http.StatusCreated
+	case entity.BatchDelete:
+		return http.StatusNoContent
+	default:
+		return http.StatusOK
+	}
+}
+
+// @Summary      Update todo task
+// @Description  Update some fields of a todo task. A status change must be allowed by the workflow.
+// @Tags         todo
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Param        If-Match    header     string  false  "ETag of the task version the change applies to" example("3-1747d27d")
+// @Param        task  body      entity.TodoPatch  true "Fields to change"
+// @Success      200  {object}   entity.Todo
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Failure 409 {string} string "{"error": "illegal status transition from \"open\" to \"done\""}"
+// @Failure 409 {string} string "{"error": "task has open children: 2"}"
+// @Failure 412 {string} string "{"error": "task version mismatch: 1 is at version 4"}"
+// @Router       /todo/{id} [patch]
+func (t *todoController) updateTask(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - update task")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	version, ok := ifMatch(gc)
+	if !ok {
+		gc.AbortWithStatusJSON(http.StatusPreconditionFailed, gin.H{
+			"error": entity.ErrVersionMismatch.Error(),
+		})
+		return
+	}
+
+	var patch entity.TodoPatch
+	if err = bind(gc, &patch); err != nil {
+		t.l.Error(err, "http - v1 - update task")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	task, err := t.useCase.UpdateTask(gc.Request.Context(), uint(id), version, patch)
+	if err != nil {
+		t.l.Error(err, "http - v1 - update task")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	gc.Header("ETag", etag(gc, task, entity.Projection{}))
+	respond(gc, http.StatusOK, task)
+}
+
+// @Summary      Delete todo task
+// @Description  Delete a todo task; it can be restored until it is purged. With purge=true the task, its comments and its attachments are deleted for good.
+// @Tags         todo
+// @Param        id    path      int  true "Todo task ID"
+// @Param        purge    query     bool  false  "Delete for good" example(true)
+// @Param        If-Match    header     string  false  "ETag of the task version to delete" example("3-1747d27d")
+// @Success      204
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Failure 409 {string} string "{"error": "task has subtasks: 2 left"}"
+// @Failure 412 {string} string "{"error": "task version mismatch: 1"}"
+// @Router       /todo/{id} [delete]
+func (t *todoController) deleteTask(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - delete task")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	purge := false
+	if value := gc.Query("purge"); value != "" {
+		if purge, err = strconv.ParseBool(value); err != nil {
+			gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "error purge param",
+			})
+			return
+		}
+	}
+
+	version, ok := ifMatch(gc)
+	if !ok {
+		gc.AbortWithStatusJSON(http.StatusPreconditionFailed, gin.H{
+			"error": entity.ErrVersionMismatch.Error(),
+		})
+		return
+	}
+
+	if purge {
+		err = t.useCase.PurgeTask(gc.Request.Context(), uint(id), version)
+	} else {
+		err = t.useCase.DeleteTask(gc.Request.Context(), uint(id), version)
+	}
+	if err != nil {
+		t.l.Error(err, "http - v1 - delete task")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	gc.Status(http.StatusNoContent)
+}
+
+// @Summary      Restore todo task
+// @Description  Bring back a deleted todo task that has not been purged
+// @Tags         todo
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Success      200  {object}   entity.Todo
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/restore [post]
+func (t *todoController) restoreTask(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - restore task")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	task, err := t.useCase.RestoreTask(gc.Request.Context(), uint(id))
+	if err != nil {
+		t.l.Error(err, "http - v1 - restore task")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	gc.Header("ETag", etag(gc, task, entity.Projection{}))
+	respond(gc, http.StatusOK, task)
+}
+
+// @Summary      Get todo task transitions
+// @Description  Get the status changes of a todo task with who made them and when
+// @Tags         todo
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Success      200  {array}   entity.TodoTransition
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/transitions [get]
+func (t *todoController) getTransitions(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get transitions")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	transitions, err := t.useCase.GetTransitions(gc.Request.Context(), uint(id))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get transitions")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &transitions)
+}
+
+// @Summary      Get subtasks
+// @Description  Get the direct subtasks of a todo task
+// @Tags         todo
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Success      200  {array}   entity.Todo
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/children [get]
+func (t *todoController) getChildren(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get children")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	children, err := t.useCase.GetChildren(gc.Request.Context(), uint(id))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get children")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, children)
+}
+
+// @Summary      Get task tree
+// @Description  Get a todo task with all of its subtasks nested below it
+// @Tags         todo
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Success      200  {object}   entity.TodoNode
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/tree [get]
+func (t *todoController) getTree(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get tree")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	tree, err := t.useCase.GetTree(gc.Request.Context(), uint(id))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get tree")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, tree)
+}
+
+// @Summary      Get blockers
+// @Description  Get the tasks that must be closed before this one can start
+// @Tags         todo
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Success      200  {array}   entity.Todo
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/blockers [get]
+func (t *todoController) getBlockers(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get blockers")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	blockers, err := t.useCase.GetBlockers(gc.Request.Context(), uint(id))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get blockers")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, blockers)
+}
+
+// @Summary      Get blocked tasks
+// @Description  Get the tasks that cannot start before this one is closed
+// @Tags         todo
+// @Produce      json
+// @Param        id    path      int  true "Todo task ID"
+// @Success      200  {array}   entity.Todo
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/blocking [get]
+func (t *todoController) getBlocked(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get blocked")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	blocked, err := t.useCase.GetBlocked(gc.Request.Context(), uint(id))
+	if err != nil {
+		t.l.Error(err, "http - v1 - get blocked")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, blocked)
+}
+
+// @Summary      Add blocker
+// @Description  Make a task wait for another one; dependencies that would form a loop are refused
+// @Tags         todo
+// @Accept       json
+// @Param        id       path      int  true "Todo task ID"
+// @Param        blocker  body      blockerRequest  true "Blocking task"
+// @Success      204
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Failure 409 {string} string "{"error": "dependency cycle: task 2 already depends on task 1"}"
+// @Router       /todo/{id}/blockers [post]
+func (t *todoController) addBlocker(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - add blocker")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	var request blockerRequest
+	if err = bind(gc, &request); err != nil {
+		t.l.Error(err, "http - v1 - add blocker")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err = t.useCase.AddBlocker(gc.Request.Context(), uint(id), request.BlockerID); err != nil {
+		t.l.Error(err, "http - v1 - add blocker")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	gc.Status(http.StatusNoContent)
+}
+
+// @Summary      Remove blocker
+// @Description  Stop a task from waiting for another one
+// @Tags         todo
+// @Param        id          path      int  true "Todo task ID"
+// @Param        blocker_id  path      int  true "Blocking task ID"
+// @Success      204
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /todo/{id}/blockers/{blocker_id} [delete]
+func (t *todoController) removeBlocker(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - remove blocker")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	blockerID, err := strconv.Atoi(gc.Param("blocker_id"))
+	if err != nil {
+		t.l.Error(err, "http - v1 - remove blocker")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error blocker_id param",
+		})
+		return
+	}
+
+	if err = t.useCase.RemoveBlocker(gc.Request.Context(), uint(id), uint(blockerID)); err != nil {
+		t.l.Error(err, "http - v1 - remove blocker")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	gc.Status(http.StatusNoContent)
+}
+
+// @Summary      Order tasks by dependencies
+// @Description  Get the given tasks ordered so that every task comes after the tasks blocking it
+// @Tags         todo
+// @Produce      json
+// @Param        ids    query     string  true  "comma separated task IDs" example(3,1,2)
+// @Success      200  {array}   entity.Todo
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Failure 409 {string} string "{"error": "dependency cycle among tasks [1 2]"}"
+// @Router       /todo/order [get]
+func (t *todoController) getTopologicalOrder(gc *gin.Context) {
+	var ids []uint
+	for _, value := range strings.Split(gc.Query("ids"), ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 0)
+		if err != nil {
+			t.l.Error(err, "http - v1 - get topological order")
+			gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "error ids param",
+			})
+			return
+		}
+		ids = append(ids, uint(id))
+	}
+
+	tasks, err := t.useCase.GetTopologicalOrder(gc.Request.Context(), ids)
+	if err != nil {
+		t.l.Error(err, "http - v1 - get topological order")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
 		})
 		return
 	}
 
-	gc.JSON(http.StatusOK, &task)
+	respond(gc, http.StatusOK, tasks)
 }
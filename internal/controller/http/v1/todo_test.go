@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"github.com/Vaixle/crud-golang/internal/controller/http/midleware"
 	"github.com/Vaixle/crud-golang/internal/entity"
 	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
 	"github.com/Vaixle/crud-golang/pkg/httpquery"
@@ -35,10 +36,10 @@ func TestController_CreateTask(t *testing.T) {
 				Status:      "open",
 			},
 			mockBehavior: func(u *mock_entity.MockTodoUseCase, task *entity.Todo) {
-				u.EXPECT().SaveTask(task).Return(nil)
+				u.EXPECT().SaveTask(gomock.Any(), task).Return(nil)
 			},
 			expectedStatusCode: 200,
-			expectedBody:       `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"description":"new Task3","status":"open"}`,
+			expectedBody:       `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"description":"new Task3","status":"open","priority":"","due_at":null,"parent_id":null,"project_id":null,"blocked":false,"comment_count":0,"version":0}`,
 		},
 		{
 			name:      "ERROR",
@@ -48,7 +49,7 @@ func TestController_CreateTask(t *testing.T) {
 				Status:      "open",
 			},
 			mockBehavior: func(u *mock_entity.MockTodoUseCase, task *entity.Todo) {
-				u.EXPECT().SaveTask(task).Return(errors.New("error save to data base"))
+				u.EXPECT().SaveTask(gomock.Any(), task).Return(errors.New("error save to data base"))
 			},
 			expectedStatusCode: 400,
 			expectedBody:       `{"error":"create task"}`,
@@ -94,7 +95,7 @@ func TestController_GetTaskById(t *testing.T) {
 			name:    "OK",
 			inputId: 3,
 			mockBehavior: func(u *mock_entity.MockTodoUseCase, id uint) {
-				u.EXPECT().GetTaskById(id).Return(&entity.Todo{
+				u.EXPECT().GetTaskById(gomock.Any(), id).Return(&entity.Todo{
 					Model: gorm.Model{
 						ID:        3,
 						CreatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
@@ -105,13 +106,13 @@ func TestController_GetTaskById(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode: 200,
-			expectedBody:       `{"ID":3,"CreatedAt":"2023-01-01T12:00:00Z","UpdatedAt":"2023-01-01T12:00:00Z","DeletedAt":null,"description":"new Task3","status":"open"}`,
+			expectedBody:       `{"ID":3,"CreatedAt":"2023-01-01T12:00:00Z","UpdatedAt":"2023-01-01T12:00:00Z","DeletedAt":null,"description":"new Task3","status":"open","priority":"","due_at":null,"parent_id":null,"project_id":null,"blocked":false,"comment_count":0,"version":0}`,
 		},
 		{
 			name:    "ERROR",
 			inputId: 1,
 			mockBehavior: func(u *mock_entity.MockTodoUseCase, id uint) {
-				u.EXPECT().GetTaskById(id).Return(nil, errors.New("id not found"))
+				u.EXPECT().GetTaskById(gomock.Any(), id).Return(nil, errors.New("id not found"))
 			},
 			expectedStatusCode: 400,
 			expectedBody:       `{"error":"error get task by id 1"}`,
@@ -161,7 +162,7 @@ func TestController_GetTasks(t *testing.T) {
 			inputFilterOptions:    []httpquery.FilterOption{{Operator: "gt", Field: "id", Value: "0"}},
 			inputFilterPagination: httpquery.Pagination{Limit: 100, Page: 0},
 			mockBehavior: func(u *mock_entity.MockTodoUseCase, filters []httpquery.FilterOption, pagination httpquery.Pagination) {
-				u.EXPECT().GetTasks(filters, pagination).Return([]entity.Todo{{
+				u.EXPECT().GetTasks(gomock.Any(), filters, pagination, entity.Projection{}).Return([]entity.Todo{{
 					Model: gorm.Model{
 						ID:        3,
 						CreatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
@@ -172,7 +173,7 @@ func TestController_GetTasks(t *testing.T) {
 				}}, nil)
 			},
 			expectedStatusCode: 200,
-			expectedBody:       `[{"ID":3,"CreatedAt":"2023-01-01T12:00:00Z","UpdatedAt":"2023-01-01T12:00:00Z","DeletedAt":null,"description":"new Task3","status":"open"}]`,
+			expectedBody:       `[{"ID":3,"CreatedAt":"2023-01-01T12:00:00Z","UpdatedAt":"2023-01-01T12:00:00Z","DeletedAt":null,"description":"new Task3","status":"open","priority":"","due_at":null,"parent_id":null,"project_id":null,"blocked":false,"comment_count":0,"version":0}]`,
 		},
 		{
 			name:                  "ERROR",
@@ -180,7 +181,7 @@ func TestController_GetTasks(t *testing.T) {
 			inputFilterOptions:    []httpquery.FilterOption{{Operator: "gt", Field: "id", Value: "0"}},
 			inputFilterPagination: httpquery.Pagination{Limit: 100, Page: 0},
 			mockBehavior: func(u *mock_entity.MockTodoUseCase, filters []httpquery.FilterOption, pagination httpquery.Pagination) {
-				u.EXPECT().GetTasks(filters, pagination).Return(nil, errors.New("some error message"))
+				u.EXPECT().GetTasks(gomock.Any(), filters, pagination, entity.Projection{}).Return(nil, errors.New("some error message"))
 			},
 			expectedStatusCode: 400,
 			expectedBody:       `{"error":"error get tasks"}`,
@@ -212,3 +213,481 @@ func TestController_GetTasks(t *testing.T) {
 		})
 	}
 }
+
+func TestController_ConditionalGet(t *testing.T) {
+	updatedAt := time.Date(2023, time.January, 1, 12, 0, 30, 500, time.UTC)
+	task := entity.Todo{Model: gorm.Model{ID: 3, UpdatedAt: updatedAt}, Description: "new Task3", Status: "open", Version: 4}
+
+	testTable := []struct {
+		name               string
+		url                string
+		header             string
+		value              string
+		expectedStatusCode int
+	}{
+		{
+			name:               "MATCHING ETAG",
+			url:                "/api/v1/todo/3",
+			header:             "If-None-Match",
+			value:              `"3-b7adf2d3", W/"4-b7adf2d3"`,
+			expectedStatusCode: 304,
+		},
+		{
+			name:               "STALE COMPUTED FIELDS",
+			url:                "/api/v1/todo/3",
+			header:             "If-None-Match",
+			value:              `"4-6ba7a14b"`,
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "OTHER MEDIA TYPE",
+			url:                "/api/v1/todo/3?format=yaml",
+			header:             "If-None-Match",
+			value:              `"4-b7adf2d3"`,
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "OTHER PROJECTION",
+			url:                "/api/v1/todo/3?fields=id,status",
+			header:             "If-None-Match",
+			value:              `"4-b7adf2d3"`,
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "STALE ETAG",
+			url:                "/api/v1/todo/3",
+			header:             "If-None-Match",
+			value:              `"3-b7adf2d3"`,
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "NOT MODIFIED SINCE",
+			url:                "/api/v1/todo/3",
+			header:             "If-Modified-Since",
+			value:              "Sun, 01 Jan 2023 12:00:30 GMT",
+			expectedStatusCode: 304,
+		},
+		{
+			name:               "MODIFIED SINCE",
+			url:                "/api/v1/todo/3",
+			header:             "If-Modified-Since",
+			value:              "Sun, 01 Jan 2023 12:00:29 GMT",
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "LIST",
+			url:                "/api/v1/todo?status=eq:open",
+			expectedStatusCode: 304,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+			todoUseCase.EXPECT().GetTaskById(gomock.Any(), uint(3)).Return(&task, nil).AnyTimes()
+			todoUseCase.EXPECT().GetTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.Todo{task}, nil).AnyTimes()
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &todoController{l: l, useCase: todoUseCase}
+
+			r.Use(midleware.Negotiate())
+			r.GET("/api/v1/todo", c.getTodoTasks)
+			r.GET("/api/v1/todo/:id", c.getTaskById)
+
+			header, value := testCase.header, testCase.value
+			if header == "" {
+				// The tag of a page is opaque: take it from a first read.
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest("GET", testCase.url, nil))
+				header, value = "If-None-Match", w.Header().Get("ETag")
+				assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, value)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.url, nil)
+			req.Header.Set(header, value)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, "Sun, 01 Jan 2023 12:00:30 GMT", w.Header().Get("Last-Modified"))
+			if w.Code == 304 {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestController_UpdateTask(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		inputBody          string
+		ifMatch            string
+		mockBehavior       func(u *mock_entity.MockTodoUseCase)
+		expectedStatusCode int
+		expectedBody       string
+		expectedETag       string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"status":"close"}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				status := "close"
+				u.EXPECT().UpdateTask(gomock.Any(), uint(3), entity.AnyVersion, entity.TodoPatch{Status: &status}).Return(&entity.Todo{
+					Model: gorm.Model{
+						ID:        3,
+						CreatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+						UpdatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+					},
+					Description: "new Task3",
+					Status:      "close",
+					Priority:    "normal",
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"ID":3,"CreatedAt":"2023-01-01T12:00:00Z","UpdatedAt":"2023-01-01T12:00:00Z","DeletedAt":null,"description":"new Task3","status":"close","priority":"normal","due_at":null,"parent_id":null,"project_id":null,"blocked":false,"comment_count":0,"version":0}`,
+			expectedETag:       `"0-b7adf2d3"`,
+		},
+		{
+			name:      "IF MATCH",
+			inputBody: `{"status":"close"}`,
+			ifMatch:   `"4-b7adf2d3"`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().UpdateTask(gomock.Any(), uint(3), uint(4), gomock.Any()).Return(&entity.Todo{
+					Model:       gorm.Model{ID: 3},
+					Description: "new Task3",
+					Status:      "close",
+					Version:     5,
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"ID":3,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"description":"new Task3","status":"close","priority":"","due_at":null,"parent_id":null,"project_id":null,"blocked":false,"comment_count":0,"version":5}`,
+			expectedETag:       `"5-b7adf2d3"`,
+		},
+		{
+			name:      "VERSION MISMATCH",
+			inputBody: `{"status":"close"}`,
+			ifMatch:   `"4"`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().UpdateTask(gomock.Any(), uint(3), uint(4), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: %d is at version %d", entity.ErrVersionMismatch, 3, 5))
+			},
+			expectedStatusCode: 412,
+			expectedBody:       `{"error":"task version mismatch: 3 is at version 5"}`,
+		},
+		{
+			name:               "WEAK IF MATCH",
+			inputBody:          `{"status":"close"}`,
+			ifMatch:            `W/"4"`,
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 412,
+			expectedBody:       `{"error":"task version mismatch"}`,
+		},
+		{
+			name:      "ILLEGAL TRANSITION",
+			inputBody: `{"status":"done"}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().UpdateTask(gomock.Any(), uint(3), entity.AnyVersion, gomock.Any()).
+					Return(nil, fmt.Errorf("%w from %q to %q", entity.ErrIllegalTransition, "open", "done"))
+			},
+			expectedStatusCode: 409,
+			expectedBody:       `{"error":"illegal status transition from \"open\" to \"done\""}`,
+		},
+		{
+			name:      "NOT FOUND",
+			inputBody: `{"status":"close"}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().UpdateTask(gomock.Any(), uint(3), entity.AnyVersion, gomock.Any()).
+					Return(nil, fmt.Errorf("%w: %d", entity.ErrTaskNotFound, 3))
+			},
+			expectedStatusCode: 404,
+			expectedBody:       `{"error":"task not found: 3"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+
+			testCase.mockBehavior(todoUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &todoController{l: l, useCase: todoUseCase}
+
+			r.PATCH("/api/v1/todo/:id", c.updateTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/api/v1/todo/3", bytes.NewBufferString(testCase.inputBody))
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedETag, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestController_AddBlocker(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		inputBody          string
+		mockBehavior       func(u *mock_entity.MockTodoUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"blocker_id":2}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().AddBlocker(gomock.Any(), uint(1), uint(2)).Return(nil)
+			},
+			expectedStatusCode: 204,
+			expectedBody:       ``,
+		},
+		{
+			name:      "CYCLE",
+			inputBody: `{"blocker_id":2}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().AddBlocker(gomock.Any(), uint(1), uint(2)).
+					Return(fmt.Errorf("%w: task %d already depends on task %d", entity.ErrDependencyCycle, 2, 1))
+			},
+			expectedStatusCode: 409,
+			expectedBody:       `{"error":"dependency cycle: task 2 already depends on task 1"}`,
+		},
+		{
+			name:               "NO BLOCKER",
+			inputBody:          `{}`,
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"Key: 'blockerRequest.BlockerID' Error:Field validation for 'BlockerID' failed on the 'required' tag"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+
+			testCase.mockBehavior(todoUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &todoController{l: l, useCase: todoUseCase}
+
+			r.POST("/api/v1/todo/:id/blockers", c.addBlocker)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/todo/1/blockers", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestController_DeleteTask(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		query              string
+		mockBehavior       func(u *mock_entity.MockTodoUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "SOFT",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().DeleteTask(gomock.Any(), uint(3), entity.AnyVersion).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:  "PURGE",
+			query: "?purge=true",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().PurgeTask(gomock.Any(), uint(3), entity.AnyVersion).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name: "HAS CHILDREN",
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().DeleteTask(gomock.Any(), uint(3), entity.AnyVersion).Return(fmt.Errorf("%w: %d left", entity.ErrHasChildren, 2))
+			},
+			expectedStatusCode: 409,
+			expectedBody:       `{"error":"task has subtasks: 2 left"}`,
+		},
+		{
+			name:               "BAD PURGE",
+			query:              "?purge=maybe",
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"error purge param"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+
+			testCase.mockBehavior(todoUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &todoController{l: l, useCase: todoUseCase}
+
+			r.DELETE("/api/v1/todo/:id", c.deleteTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/api/v1/todo/3"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestController_BatchTasks(t *testing.T) {
+	operations := []entity.BatchOperation{
+		{Op: entity.BatchUpdate, ID: 1, Version: 2, Patch: &entity.TodoPatch{}},
+		{Op: entity.BatchDelete, ID: 2},
+	}
+
+	testTable := []struct {
+		name               string
+		query              string
+		inputBody          string
+		mockBehavior       func(u *mock_entity.MockTodoUseCase)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"operations":[{"op":"update","id":1,"version":2,"patch":{}},{"op":"delete","id":2}]}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().Batch(gomock.Any(), operations, true).Return([]entity.BatchResult{
+					{Op: entity.BatchUpdate, ID: 1},
+					{Op: entity.BatchDelete, ID: 2},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"results":[{"index":0,"op":"update","id":1,"status":200},{"index":1,"op":"delete","id":2,"status":204}]}`,
+		},
+		{
+			name:      "PARTIAL",
+			query:     "?atomic=false",
+			inputBody: `{"operations":[{"op":"update","id":1,"version":2,"patch":{}},{"op":"delete","id":2}]}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().Batch(gomock.Any(), operations, false).Return([]entity.BatchResult{
+					{Op: entity.BatchUpdate, ID: 1},
+					{Op: entity.BatchDelete, ID: 2, Err: entity.ErrTaskNotFound},
+				}, nil)
+			},
+			expectedStatusCode: 207,
+			expectedBody:       `{"results":[{"index":0,"op":"update","id":1,"status":200},{"index":1,"op":"delete","id":2,"status":404,"error":"task not found"}]}`,
+		},
+		{
+			name:      "ROLLED BACK",
+			inputBody: `{"operations":[{"op":"update","id":1,"version":2,"patch":{}},{"op":"delete","id":2}]}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().Batch(gomock.Any(), operations, true).Return([]entity.BatchResult{
+					{Op: entity.BatchUpdate, ID: 1, Err: entity.ErrBatchRolledBack},
+					{Op: entity.BatchDelete, ID: 2, Err: entity.ErrVersionMismatch},
+				}, entity.ErrVersionMismatch)
+			},
+			expectedStatusCode: 412,
+			expectedBody:       `{"results":[{"index":0,"op":"update","id":1,"status":424,"error":"not applied: another operation of the batch failed"},{"index":1,"op":"delete","id":2,"status":412,"error":"task version mismatch"}]}`,
+		},
+		{
+			name:      "CREATE ERROR NOT DETAILED",
+			query:     "?atomic=false",
+			inputBody: `{"operations":[{"op":"create","task":{"description":"a"}}]}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().Batch(gomock.Any(), gomock.Len(1), false).Return([]entity.BatchResult{
+					{Op: entity.BatchCreate, Err: errors.New("connection refused")},
+				}, nil)
+			},
+			expectedStatusCode: 207,
+			expectedBody:       `{"results":[{"index":0,"op":"create","status":400,"error":"create task"}]}`,
+		},
+		{
+			name:      "TOO LARGE",
+			inputBody: `{"operations":[{"op":"delete","id":2}]}`,
+			mockBehavior: func(u *mock_entity.MockTodoUseCase) {
+				u.EXPECT().Batch(gomock.Any(), gomock.Len(1), true).Return(nil, fmt.Errorf("%w: 1, the limit is 0", entity.ErrBatchTooLarge))
+			},
+			expectedStatusCode: 413,
+			expectedBody:       `{"error":"too many operations in the batch: 1, the limit is 0"}`,
+		},
+		{
+			name:               "UNKNOWN OP",
+			inputBody:          `{"operations":[{"op":"move","id":2}]}`,
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"Key: 'batchRequest.Operations[0].Op' Error:Field validation for 'Op' failed on the 'oneof' tag"}`,
+		},
+		{
+			name:               "EMPTY",
+			inputBody:          `{"operations":[]}`,
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"Key: 'batchRequest.Operations' Error:Field validation for 'Operations' failed on the 'min' tag"}`,
+		},
+		{
+			name:               "BAD ATOMIC",
+			query:              "?atomic=maybe",
+			inputBody:          `{"operations":[{"op":"delete","id":2}]}`,
+			mockBehavior:       func(u *mock_entity.MockTodoUseCase) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"error atomic param"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+
+			testCase.mockBehavior(todoUseCase)
+
+			r := gin.New()
+			l := logger.New("info")
+			c := &todoController{l: l, useCase: todoUseCase}
+
+			r.POST("/api/v1/todo/batch", c.batchTasks)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/todo/batch"+testCase.query, bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
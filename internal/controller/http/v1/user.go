@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type userController struct {
+	l           logger.Interface
+	useCase     entity.UserUseCase
+	todoUseCase entity.TodoUseCase
+}
+
+func newUserRoutes(handler *gin.RouterGroup, useCase entity.UserUseCase, todoUseCase entity.TodoUseCase, l logger.Interface) {
+	r := &userController{l: l, useCase: useCase, todoUseCase: todoUseCase}
+
+	h := handler.Group("/users")
+	{
+		h.GET("", r.getUsers)
+		h.GET("/:id", r.getUserById)
+	}
+
+	me := handler.Group("/me")
+	{
+		me.GET("", r.getMe)
+		me.GET("/tasks", r.getMyTasks)
+	}
+}
+
+// @Summary      Get users
+// @Description  Get every user that has signed in, ordered by username
+// @Tags         users
+// @Produce      json
+// @Success      200  {array}   entity.User
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Router       /users [get]
+func (u *userController) getUsers(gc *gin.Context) {
+	users, err := u.useCase.GetUsers(gc.Request.Context())
+	if err != nil {
+		u.l.Error(err, "http - v1 - get users")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error get users",
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &users)
+}
+
+// @Summary      Get user
+// @Description  Get user by id
+// @Tags         users
+// @Produce      json
+// @Param        id    path      int  true "User ID"
+// @Success      200  {object}   entity.User
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 404 {string} string "{"error": "some error message"}"
+// @Router       /users/{id} [get]
+func (u *userController) getUserById(gc *gin.Context) {
+	id, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		u.l.Error(err, "http - v1 - get user")
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error id param",
+		})
+		return
+	}
+
+	user, err := u.useCase.GetUserById(gc.Request.Context(), uint(id))
+	if err != nil {
+		u.l.Error(err, "http - v1 - get user")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &user)
+}
+
+// @Summary      Get current user
+// @Description  Get the user the request is authenticated as
+// @Tags         users
+// @Produce      json
+// @Success      200  {object}   entity.User
+// @Failure 401 {string} string "{"error": "no authenticated user"}"
+// @Router       /me [get]
+func (u *userController) getMe(gc *gin.Context) {
+	user, ok := entity.UserFromContext(gc.Request.Context())
+	if !ok {
+		gc.AbortWithStatusJSON(errorStatus(entity.ErrAnonymous), gin.H{
+			"error": entity.ErrAnonymous.Error(),
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, &user)
+}
+
+// @Summary      Get my tasks
+// @Description  Get the tasks assigned to the current user; takes the same filters as the task list
+// @Tags         users
+// @Produce      json
+// @Param        page    query     string  false  "page" example(2)
+// @Param        limit    query     string  false  "limit" example(3)
+// @Param        fields    query     string  false  "comma separated fields to return" example(id,description,status)
+// @Param        include    query     string  false  "comma separated related resources to return: tags, assignees, comment_count" example(tags)
+// @Success      200  {array}   entity.Todo
+// @Failure 400 {string} string "{"error": "some error message"}"
+// @Failure 401 {string} string "{"error": "no authenticated user"}"
+// @Router       /me/tasks [get]
+func (u *userController) getMyTasks(gc *gin.Context) {
+	values := gc.Request.URL.Query()
+	projection, err := taskProjection(values)
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	filterOptions, pagination, err := httpquery.ParseQueryParams(values)
+	if err != nil {
+		gc.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "error query params",
+		})
+		return
+	}
+
+	filterOptions = append(filterOptions, httpquery.FilterOption{Field: "assignee", Value: "me"})
+	tasks, err := u.todoUseCase.GetTasks(gc.Request.Context(), filterOptions, pagination, projection)
+	if err != nil {
+		u.l.Error(err, "http - v1 - get my tasks")
+		gc.AbortWithStatusJSON(errorStatus(err), gin.H{
+			"error": "error get tasks",
+		})
+		return
+	}
+
+	respond(gc, http.StatusOK, sparseTasks(tasks, projection))
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestController_GetMe(t *testing.T) {
+
+	testTable := []struct {
+		name               string
+		user               *entity.User
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "OK",
+			user:               &entity.User{Model: gorm.Model{ID: 7}, Username: "admin"},
+			expectedStatusCode: 200,
+			expectedBody:       `{"ID":7,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"username":"admin"}`,
+		},
+		{
+			name:               "ANONYMOUS",
+			expectedStatusCode: 401,
+			expectedBody:       `{"error":"no authenticated user"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := gin.New()
+			l := logger.New("info")
+			c := &userController{l: l}
+
+			r.GET("/api/v1/me", func(gc *gin.Context) {
+				if testCase.user != nil {
+					gc.Request = gc.Request.WithContext(entity.ContextWithUser(gc.Request.Context(), *testCase.user))
+				}
+			}, c.getMe)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/v1/me", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestController_GetMyTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	todoUseCase := mock_entity.NewMockTodoUseCase(ctrl)
+	todoUseCase.EXPECT().GetTasks(gomock.Any(), []httpquery.FilterOption{
+		{Field: "ready", Value: "true"},
+		{Field: "assignee", Value: "me"},
+	}, httpquery.Pagination{Limit: 100}, entity.Projection{}).Return([]entity.Todo{}, nil)
+
+	r := gin.New()
+	l := logger.New("info")
+	c := &userController{l: l, todoUseCase: todoUseCase}
+
+	r.GET("/api/v1/me/tasks", c.getMyTasks)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/v1/me/tasks?ready=true", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, `[]`, w.Body.String())
+	assert.Equal(t, 200, w.Code)
+}
@@ -0,0 +1,59 @@
+// Package metrics exposes domain state as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ prometheus.Collector = (*TodoCollector)(nil)
+
+// TodoCollector reports task counts by status, queried on every scrape.
+type TodoCollector struct {
+	l        logger.Interface
+	useCase  entity.TodoUseCase
+	statuses []string
+	tasks    *prometheus.Desc
+}
+
+// NewTodoCollector -.
+func NewTodoCollector(useCase entity.TodoUseCase, workflow entity.Workflow, l logger.Interface) *TodoCollector {
+	return &TodoCollector{
+		l:        l,
+		useCase:  useCase,
+		statuses: workflow.Statuses,
+		tasks: prometheus.NewDesc(
+			"todo_tasks",
+			"Number of todo tasks by status.",
+			[]string{"status"}, nil,
+		),
+	}
+}
+
+// Describe -.
+func (c *TodoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tasks
+}
+
+// Collect -.
+func (c *TodoCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.useCase.CountTasksByStatus(context.Background())
+	if err != nil {
+		c.l.Error(err, "metrics - todo - collect")
+		ch <- prometheus.NewInvalidMetric(c.tasks, err)
+		return
+	}
+
+	// Workflow statuses are always reported so dashboards see zeros rather than gaps.
+	for _, status := range c.statuses {
+		if _, ok := counts[status]; !ok {
+			counts[status] = 0
+		}
+	}
+
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(count), status)
+	}
+}
@@ -0,0 +1,43 @@
+package entity
+
+import "context"
+
+// AnonymousActor is recorded when a change is made outside an authenticated request.
+const AnonymousActor = "anonymous"
+
+type (
+	actorKey struct{}
+	userKey  struct{}
+)
+
+// ContextWithActor returns a copy of ctx carrying the name of whoever makes the change.
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext -.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// ContextWithUser returns a copy of ctx carrying the authenticated user, who is also the actor.
+func ContextWithUser(ctx context.Context, user User) context.Context {
+	return ContextWithActor(context.WithValue(ctx, userKey{}, user), user.Username)
+}
+
+// UserFromContext -.
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userKey{}).(User)
+	return user, ok
+}
+
+// UserIDFromContext returns the id of the authenticated user, nil outside an authenticated request.
+func UserIDFromContext(ctx context.Context) *uint {
+	if user, ok := UserFromContext(ctx); ok {
+		return &user.ID
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"context"
+	"io"
+	"mime"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen -source=attachment.go -destination=./mocks/attachment_mock.go
+
+// Attachment is a file uploaded to a task. The content lives in the blob store under its SHA-256, so identical
+// uploads share one blob; attachments are deleted outright and the blob goes with the last one referring to it.
+type Attachment struct {
+	ID     uint `json:"id" gorm:"primarykey" example:"1"`
+	TodoID uint `json:"todo_id" gorm:"not null;index" example:"1"`
+	// Filename is the base name given by the uploader.
+	Filename string `json:"filename" gorm:"type:varchar(255);not null" example:"screenshot.png"`
+	// ContentType is sniffed from the content rather than taken from the upload.
+	ContentType string    `json:"content_type" gorm:"type:varchar(255);not null" example:"image/png"`
+	Size        int64     `json:"size" gorm:"not null" example:"52431"`
+	SHA256      string    `json:"sha256" gorm:"column:sha256;type:char(64);not null;index" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	UploadedBy  *uint     `json:"uploaded_by,omitempty" example:"1"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Attachments limits what may be uploaded.
+type Attachments struct {
+	// MaxSize is the largest file accepted, in bytes; 0 means no limit.
+	MaxSize int64 `json:"max_size" mapstructure:"max_size"`
+	// ContentTypes are the media types accepted, such as image/png or image/*; empty accepts any.
+	ContentTypes []string `json:"content_types" mapstructure:"content_types"`
+}
+
+// Allows reports whether a file of the media type may be uploaded; parameters such as charset are ignored.
+func (a Attachments) Allows(contentType string) bool {
+	if len(a.ContentTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range a.ContentTypes {
+		if allowed == mediaType || strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+type AttachmentRepository interface {
+	// Transaction runs fn in a database transaction; repository calls made with the ctx passed to fn join it.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// LockDigest serializes storing and removing the content with the digest until the transaction in ctx ends.
+	LockDigest(ctx context.Context, digest string) error
+	// GetAttachments returns the attachments of the task, oldest first.
+	GetAttachments(ctx context.Context, todoID uint) ([]Attachment, error)
+	GetAttachmentById(ctx context.Context, id uint) (*Attachment, error)
+	SaveAttachment(ctx context.Context, attachment *Attachment) error
+	DeleteAttachment(ctx context.Context, id uint) error
+	// IsReferenced reports whether any attachment still has the content with the digest.
+	IsReferenced(ctx context.Context, digest string) (bool, error)
+}
+
+type AttachmentUseCase interface {
+	GetAttachments(ctx context.Context, todoID uint) ([]Attachment, error)
+	GetAttachment(ctx context.Context, todoID, id uint) (*Attachment, error)
+	// SaveAttachment stores the content, reading it twice: once to hash it and once to store it.
+	SaveAttachment(ctx context.Context, attachment *Attachment, content io.ReadSeeker) error
+	// OpenAttachment returns the attachment with its content, which the caller closes.
+	OpenAttachment(ctx context.Context, todoID, id uint) (*Attachment, io.ReadSeekCloser, error)
+	DeleteAttachment(ctx context.Context, todoID, id uint) error
+}
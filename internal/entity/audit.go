@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:generate mockgen -source=audit.go -destination=./mocks/audit_mock.go
+
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// AuditEntry records one change of a task. Entries are only ever added: they outlive the task, even once it is purged.
+type AuditEntry struct {
+	ID        uint   `json:"id" gorm:"primarykey" example:"1"`
+	TodoID    uint   `json:"todo_id" gorm:"not null;index" example:"1"`
+	Operation string `json:"operation" gorm:"type:varchar(16);not null" example:"update"`
+	Actor     string `json:"actor" gorm:"type:varchar(255);not null;index" example:"admin"`
+	// UserID is the authenticated user behind the change, nil for anonymous changes.
+	UserID    *uint        `json:"user_id,omitempty" example:"1"`
+	Changes   FieldChanges `json:"changes" gorm:"type:jsonb"`
+	CreatedAt time.Time    `json:"created_at" gorm:"index"`
+}
+
+// FieldChange is the value of a task field before and after a change, as JSON; null stands for no value.
+type FieldChange struct {
+	Field string          `json:"field" example:"status"`
+	Old   json.RawMessage `json:"old" swaggertype:"string" example:"\"open\""`
+	New   json.RawMessage `json:"new" swaggertype:"string" example:"\"done\""`
+}
+
+// FieldChanges is stored as a JSON array.
+type FieldChanges []FieldChange
+
+// Value -.
+func (c FieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c)
+}
+
+// Scan -.
+func (c *FieldChanges) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return errors.New("unsupported field changes value")
+	}
+}
+
+// AuditFilter narrows the audit log; zero fields do not filter.
+type AuditFilter struct {
+	Actor string
+	From  *time.Time
+	To    *time.Time
+}
+
+// auditedFields are the task fields an audit entry tracks, in the order their changes are listed.
+var auditedFields = []struct {
+	name  string
+	value func(task *Todo) interface{}
+}{
+	{"description", func(task *Todo) interface{} { return task.Description }},
+	{"status", func(task *Todo) interface{} { return task.Status }},
+	{"priority", func(task *Todo) interface{} { return task.Priority }},
+	{"due_at", func(task *Todo) interface{} {
+		if task.DueAt == nil {
+			return nil
+		}
+		return task.DueAt.UTC()
+	}},
+	{"parent_id", func(task *Todo) interface{} { return task.ParentID }},
+	{"project_id", func(task *Todo) interface{} { return task.ProjectID }},
+	{"recurrence", func(task *Todo) interface{} { return task.Recurrence }},
+	{"timezone", func(task *Todo) interface{} { return task.Timezone }},
+	{"tag_ids", func(task *Todo) interface{} {
+		ids := make([]uint, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			ids = append(ids, tag.ID)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids
+	}},
+	{"assignee_ids", func(task *Todo) interface{} {
+		ids := make([]uint, 0, len(task.Assignees))
+		for _, user := range task.Assignees {
+			ids = append(ids, user.ID)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids
+	}},
+}
+
+// DiffTodos lists the audited fields that differ between before and after. A nil before is a task being
+// created: every field set on after is listed. Fields are compared by their JSON encoding.
+func DiffTodos(before, after *Todo) FieldChanges {
+	var changes FieldChanges
+	for _, field := range auditedFields {
+		newValue, _ := json.Marshal(field.value(after))
+		if before == nil {
+			if isEmptyJSON(newValue) {
+				continue
+			}
+			changes = append(changes, FieldChange{Field: field.name, Old: json.RawMessage("null"), New: newValue})
+			continue
+		}
+
+		oldValue, _ := json.Marshal(field.value(before))
+		if !bytes.Equal(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field.name, Old: oldValue, New: newValue})
+		}
+	}
+	return changes
+}
+
+// DeletionChanges is the change a delete, when deleted, or a restore makes to a task.
+func DeletionChanges(deleted bool) FieldChanges {
+	return FieldChanges{{
+		Field: "deleted",
+		Old:   json.RawMessage(strconv.FormatBool(!deleted)),
+		New:   json.RawMessage(strconv.FormatBool(deleted)),
+	}}
+}
+
+func isEmptyJSON(value []byte) bool {
+	switch string(value) {
+	case "null", `""`, "[]", "0":
+		return true
+	}
+	return false
+}
+
+type AuditRepository interface {
+	SaveAuditEntry(ctx context.Context, entry *AuditEntry) error
+	// SaveAuditEntries inserts the entries batchSize rows at a time.
+	SaveAuditEntries(ctx context.Context, entries []AuditEntry, batchSize int) error
+	// GetTaskHistory returns a page of the entries of the task, oldest first.
+	GetTaskHistory(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]AuditEntry, error)
+	// GetAuditEntries returns a page of the entries of every task, newest first.
+	GetAuditEntries(ctx context.Context, filter AuditFilter, pagination httpquery.Pagination) ([]AuditEntry, error)
+}
+
+type AuditUseCase interface {
+	GetTaskHistory(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]AuditEntry, error)
+	GetAuditEntries(ctx context.Context, filter AuditFilter, pagination httpquery.Pagination) ([]AuditEntry, error)
+}
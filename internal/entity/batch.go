@@ -0,0 +1,52 @@
+package entity
+
+import "fmt"
+
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+// Batch limits POST /todo/batch.
+type Batch struct {
+	// MaxOperations is the most operations a batch may hold; 0 means no limit.
+	MaxOperations int `json:"max_operations" mapstructure:"max_operations"`
+	// InsertSize is the most tasks written by one INSERT when the creates of a batch are inserted together.
+	InsertSize int `json:"insert_size" mapstructure:"insert_size"`
+}
+
+// DefaultInsertSize is used when Batch.InsertSize is not set.
+const DefaultInsertSize = 100
+
+// BatchOperation is one create, update or delete of a batch. Update and delete name the task by ID and,
+// unless Version is AnyVersion, only apply to that version of it.
+type BatchOperation struct {
+	Op      string     `json:"op" binding:"required,oneof=create update delete" example:"update"`
+	ID      uint       `json:"id,omitempty" example:"1"`
+	Version uint       `json:"version,omitempty" example:"3"`
+	Task    *Todo      `json:"task,omitempty"`
+	Patch   *TodoPatch `json:"patch,omitempty"`
+}
+
+// Check reports an operation missing what it needs to be applied.
+func (o BatchOperation) Check() error {
+	switch {
+	case o.Op == BatchCreate && o.Task == nil:
+		return fmt.Errorf("%w: create needs a task", ErrInvalidOperation)
+	case o.Op == BatchUpdate && (o.ID == 0 || o.Patch == nil):
+		return fmt.Errorf("%w: update needs an id and a patch", ErrInvalidOperation)
+	case o.Op == BatchDelete && o.ID == 0:
+		return fmt.Errorf("%w: delete needs an id", ErrInvalidOperation)
+	}
+	return nil
+}
+
+// BatchResult is the outcome of the operation at the same position of a batch. Task is the created or
+// updated task; Err is set when the operation was not applied.
+type BatchResult struct {
+	Op   string
+	ID   uint
+	Task *Todo
+	Err  error
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=comment.go -destination=./mocks/comment_mock.go
+
+// Comment is a message on a task. Comments are threaded one level deep: a reply answers a top-level comment.
+type Comment struct {
+	gorm.Model
+	TodoID   uint   `json:"todo_id" gorm:"not null;index" example:"1"`
+	AuthorID uint   `json:"author_id" gorm:"not null;index" example:"1"`
+	Author   *User  `json:"author,omitempty"`
+	Body     string `json:"body" gorm:"type:text;not null" binding:"required" example:"Looks good to me"`
+	// ParentID is the top-level comment this one replies to.
+	ParentID *uint     `json:"parent_id" gorm:"index" example:"1"`
+	Replies  []Comment `json:"replies,omitempty" gorm:"foreignKey:ParentID"`
+}
+
+type CommentRepository interface {
+	// GetComments returns a page of the top-level comments of the task, oldest first, with their replies.
+	GetComments(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]Comment, error)
+	GetCommentById(ctx context.Context, id uint) (*Comment, error)
+	SaveComment(ctx context.Context, comment *Comment) error
+	UpdateComment(ctx context.Context, comment *Comment) error
+	// DeleteComment deletes the comment along with its replies.
+	DeleteComment(ctx context.Context, id uint) error
+}
+
+type CommentUseCase interface {
+	GetComments(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]Comment, error)
+	GetComment(ctx context.Context, todoID, id uint) (*Comment, error)
+	SaveComment(ctx context.Context, comment *Comment) error
+	UpdateComment(ctx context.Context, todoID, id uint, body string) (*Comment, error)
+	DeleteComment(ctx context.Context, todoID, id uint) error
+}
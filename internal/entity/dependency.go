@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TodoDependency records that a task cannot start until its blocker is in a closed status.
+type TodoDependency struct {
+	TodoID    uint      `json:"todo_id" gorm:"primaryKey;autoIncrement:false"`
+	BlockerID uint      `json:"blocker_id" gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TopologicalOrder sorts ids so that every task comes after its blockers, keeping ascending id order where
+// the dependencies allow. Dependencies on tasks outside ids are ignored.
+func TopologicalOrder(ids []uint, deps []TodoDependency) ([]uint, error) {
+	pending := make(map[uint]int, len(ids))
+	for _, id := range ids {
+		pending[id] = 0
+	}
+
+	blocks := make(map[uint][]uint)
+	for _, dep := range deps {
+		_, todo := pending[dep.TodoID]
+		_, blocker := pending[dep.BlockerID]
+		if todo && blocker {
+			blocks[dep.BlockerID] = append(blocks[dep.BlockerID], dep.TodoID)
+			pending[dep.TodoID]++
+		}
+	}
+
+	var ready []uint
+	for id, blockers := range pending {
+		if blockers == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := make([]uint, 0, len(pending))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return ready[i] < ready[j] })
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		for _, blocked := range blocks[id] {
+			if pending[blocked]--; pending[blocked] == 0 {
+				ready = append(ready, blocked)
+			}
+		}
+	}
+
+	if len(order) < len(pending) {
+		return nil, fmt.Errorf("%w among tasks %v", ErrDependencyCycle, ids)
+	}
+	return order, nil
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "errors"
+
+var (
+	ErrTaskNotFound           = errors.New("task not found")
+	ErrUnknownStatus          = errors.New("unknown status")
+	ErrIllegalTransition      = errors.New("illegal status transition")
+	ErrTagNotFound            = errors.New("tag not found")
+	ErrTagExists              = errors.New("tag already exists")
+	ErrUnknownTag             = errors.New("unknown tag")
+	ErrInvalidParent          = errors.New("invalid parent")
+	ErrOpenChildren           = errors.New("task has open children")
+	ErrDependencyCycle        = errors.New("dependency cycle")
+	ErrSelfDependency         = errors.New("task cannot block itself")
+	ErrNoDependency           = errors.New("dependency not found")
+	ErrInvalidRecurrence      = errors.New("invalid recurrence")
+	ErrProjectNotFound        = errors.New("project not found")
+	ErrProjectArchived        = errors.New("project is archived")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrUnknownUser            = errors.New("unknown user")
+	ErrAnonymous              = errors.New("no authenticated user")
+	ErrCommentNotFound        = errors.New("comment not found")
+	ErrInvalidComment         = errors.New("invalid comment")
+	ErrNotCommentAuthor       = errors.New("only the author can change a comment")
+	ErrHasChildren            = errors.New("task has subtasks")
+	ErrAttachmentNotFound     = errors.New("attachment not found")
+	ErrAttachmentTooLarge     = errors.New("attachment too large")
+	ErrUnsupportedContent     = errors.New("unsupported attachment content type")
+	ErrVersionMismatch        = errors.New("task version mismatch")
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key already used for a different request")
+	ErrRequestInProgress      = errors.New("a request with the idempotency key is in progress")
+	ErrInvalidOperation       = errors.New("invalid batch operation")
+	ErrBatchTooLarge          = errors.New("too many operations in the batch")
+	ErrBatchRolledBack        = errors.New("not applied: another operation of the batch failed")
+	ErrUnknownField           = errors.New("unknown field")
+	ErrInvalidFilter          = errors.New("invalid filter")
+	ErrInvalidTask            = errors.New("invalid task")
+)
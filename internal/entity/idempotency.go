@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=idempotency.go -destination=./mocks/idempotency_mock.go
+
+// IdempotencyKey is a key a client sent with a request, stored with a hash of the request and, once the request
+// is done, its response. The same key sent again by the same actor replays that response instead of repeating
+// the request. Actor and Key are unique together.
+type IdempotencyKey struct {
+	ID          uint   `gorm:"primarykey"`
+	Actor       string `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_actor_key"`
+	Key         string `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_actor_key"`
+	RequestHash string `gorm:"type:char(64);not null"`
+	// StatusCode is 0 while the first request with the key is in progress.
+	StatusCode int
+	Headers    map[string]string `gorm:"serializer:json"`
+	Response   []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	// LockedUntil is when the reservation of a request in progress lapses, so that a retry can take the key
+	// over from a request that crashed or failed to store its response.
+	LockedUntil *time.Time
+}
+
+// Done reports whether the response of the request is stored.
+func (k *IdempotencyKey) Done() bool {
+	return k.StatusCode != 0
+}
+
+// Locked reports whether a request in progress still holds the key at now.
+func (k *IdempotencyKey) Locked(now time.Time) bool {
+	return k.LockedUntil != nil && k.LockedUntil.After(now)
+}
+
+type IdempotencyRepository interface {
+	// SaveIdempotencyKey stores a new key; it reports false, without an error, when the actor already has the key.
+	SaveIdempotencyKey(ctx context.Context, key *IdempotencyKey) (bool, error)
+	GetIdempotencyKey(ctx context.Context, actor, key string) (*IdempotencyKey, error)
+	// CompleteIdempotencyKey stores the response of the request.
+	CompleteIdempotencyKey(ctx context.Context, key *IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, id uint) error
+	// TakeOverIdempotencyKey deletes the key only while it is expired or its request in progress has lost its
+	// lease at now, and reports whether it did.
+	TakeOverIdempotencyKey(ctx context.Context, id uint, now time.Time) (bool, error)
+	// DeleteExpiredIdempotencyKeys deletes the keys that expired by now and returns how many there were.
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+type IdempotencyUseCase interface {
+	// Begin reserves the key for a request with the hash, for the actor of ctx. For a new key it returns the
+	// reserved key, which Finish completes; for an identical retry it returns the key of the earlier request,
+	// which is Done once its response is stored. A different request with the key fails with
+	// ErrIdempotencyKeyReused.
+	Begin(ctx context.Context, key, requestHash string) (*IdempotencyKey, error)
+	// Finish stores the response of the request; a server error releases the key so the request can be retried.
+	Finish(ctx context.Context, key *IdempotencyKey, statusCode int, headers map[string]string, response []byte) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,235 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachment.go
+
+// Package mock_entity is a generated GoMock package.
+package mock_entity
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	entity "github.com/Vaixle/crud-golang/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentRepository is a mock of AttachmentRepository interface.
+type MockAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepositoryMockRecorder
+}
+
+// MockAttachmentRepositoryMockRecorder is the mock recorder for MockAttachmentRepository.
+type MockAttachmentRepositoryMockRecorder struct {
+	mock *MockAttachmentRepository
+}
+
+// NewMockAttachmentRepository creates a new mock instance.
+func NewMockAttachmentRepository(ctrl *gomock.Controller) *MockAttachmentRepository {
+	mock := &MockAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepository) EXPECT() *MockAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentRepository) DeleteAttachment(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentRepositoryMockRecorder) DeleteAttachment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentRepository)(nil).DeleteAttachment), ctx, id)
+}
+
+// GetAttachmentById mocks base method.
+func (m *MockAttachmentRepository) GetAttachmentById(ctx context.Context, id uint) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentById", ctx, id)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentById indicates an expected call of GetAttachmentById.
+func (mr *MockAttachmentRepositoryMockRecorder) GetAttachmentById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentById", reflect.TypeOf((*MockAttachmentRepository)(nil).GetAttachmentById), ctx, id)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachmentRepository) GetAttachments(ctx context.Context, todoID uint) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, todoID)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentRepositoryMockRecorder) GetAttachments(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachmentRepository)(nil).GetAttachments), ctx, todoID)
+}
+
+// IsReferenced mocks base method.
+func (m *MockAttachmentRepository) IsReferenced(ctx context.Context, digest string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReferenced", ctx, digest)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsReferenced indicates an expected call of IsReferenced.
+func (mr *MockAttachmentRepositoryMockRecorder) IsReferenced(ctx, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReferenced", reflect.TypeOf((*MockAttachmentRepository)(nil).IsReferenced), ctx, digest)
+}
+
+// LockDigest mocks base method.
+func (m *MockAttachmentRepository) LockDigest(ctx context.Context, digest string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockDigest", ctx, digest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockDigest indicates an expected call of LockDigest.
+func (mr *MockAttachmentRepositoryMockRecorder) LockDigest(ctx, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockDigest", reflect.TypeOf((*MockAttachmentRepository)(nil).LockDigest), ctx, digest)
+}
+
+// SaveAttachment mocks base method.
+func (m *MockAttachmentRepository) SaveAttachment(ctx context.Context, attachment *entity.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAttachment", ctx, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAttachment indicates an expected call of SaveAttachment.
+func (mr *MockAttachmentRepositoryMockRecorder) SaveAttachment(ctx, attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAttachment", reflect.TypeOf((*MockAttachmentRepository)(nil).SaveAttachment), ctx, attachment)
+}
+
+// Transaction mocks base method.
+func (m *MockAttachmentRepository) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockAttachmentRepositoryMockRecorder) Transaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockAttachmentRepository)(nil).Transaction), ctx, fn)
+}
+
+// MockAttachmentUseCase is a mock of AttachmentUseCase interface.
+type MockAttachmentUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentUseCaseMockRecorder
+}
+
+// MockAttachmentUseCaseMockRecorder is the mock recorder for MockAttachmentUseCase.
+type MockAttachmentUseCaseMockRecorder struct {
+	mock *MockAttachmentUseCase
+}
+
+// NewMockAttachmentUseCase creates a new mock instance.
+func NewMockAttachmentUseCase(ctrl *gomock.Controller) *MockAttachmentUseCase {
+	mock := &MockAttachmentUseCase{ctrl: ctrl}
+	mock.recorder = &MockAttachmentUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentUseCase) EXPECT() *MockAttachmentUseCaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentUseCase) DeleteAttachment(ctx context.Context, todoID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentUseCaseMockRecorder) DeleteAttachment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentUseCase)(nil).DeleteAttachment), ctx, todoID, id)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachmentUseCase) GetAttachment(ctx context.Context, todoID, id uint) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, todoID, id)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentUseCaseMockRecorder) GetAttachment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachmentUseCase)(nil).GetAttachment), ctx, todoID, id)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachmentUseCase) GetAttachments(ctx context.Context, todoID uint) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, todoID)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentUseCaseMockRecorder) GetAttachments(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachmentUseCase)(nil).GetAttachments), ctx, todoID)
+}
+
+// OpenAttachment mocks base method.
+func (m *MockAttachmentUseCase) OpenAttachment(ctx context.Context, todoID, id uint) (*entity.Attachment, io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAttachment", ctx, todoID, id)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(io.ReadSeekCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAttachment indicates an expected call of OpenAttachment.
+func (mr *MockAttachmentUseCaseMockRecorder) OpenAttachment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAttachment", reflect.TypeOf((*MockAttachmentUseCase)(nil).OpenAttachment), ctx, todoID, id)
+}
+
+// SaveAttachment mocks base method.
+func (m *MockAttachmentUseCase) SaveAttachment(ctx context.Context, attachment *entity.Attachment, content io.ReadSeeker) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAttachment", ctx, attachment, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAttachment indicates an expected call of SaveAttachment.
+func (mr *MockAttachmentUseCaseMockRecorder) SaveAttachment(ctx, attachment, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAttachment", reflect.TypeOf((*MockAttachmentUseCase)(nil).SaveAttachment), ctx, attachment, content)
+}
@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit.go
+
+// Package mock_entity is a generated GoMock package.
+package mock_entity
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/Vaixle/crud-golang/internal/entity"
+	httpquery "github.com/Vaixle/crud-golang/pkg/httpquery"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockAuditRepository) GetAuditEntries(ctx context.Context, filter entity.AuditFilter, pagination httpquery.Pagination) ([]entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEntries", ctx, filter, pagination)
+	ret0, _ := ret[0].([]entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockAuditRepositoryMockRecorder) GetAuditEntries(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockAuditRepository)(nil).GetAuditEntries), ctx, filter, pagination)
+}
+
+// GetTaskHistory mocks base method.
+func (m *MockAuditRepository) GetTaskHistory(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskHistory", ctx, todoID, pagination)
+	ret0, _ := ret[0].([]entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskHistory indicates an expected call of GetTaskHistory.
+func (mr *MockAuditRepositoryMockRecorder) GetTaskHistory(ctx, todoID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskHistory", reflect.TypeOf((*MockAuditRepository)(nil).GetTaskHistory), ctx, todoID, pagination)
+}
+
+// SaveAuditEntries mocks base method.
+func (m *MockAuditRepository) SaveAuditEntries(ctx context.Context, entries []entity.AuditEntry, batchSize int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuditEntries", ctx, entries, batchSize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuditEntries indicates an expected call of SaveAuditEntries.
+func (mr *MockAuditRepositoryMockRecorder) SaveAuditEntries(ctx, entries, batchSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuditEntries", reflect.TypeOf((*MockAuditRepository)(nil).SaveAuditEntries), ctx, entries, batchSize)
+}
+
+// SaveAuditEntry mocks base method.
+func (m *MockAuditRepository) SaveAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuditEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuditEntry indicates an expected call of SaveAuditEntry.
+func (mr *MockAuditRepositoryMockRecorder) SaveAuditEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuditEntry", reflect.TypeOf((*MockAuditRepository)(nil).SaveAuditEntry), ctx, entry)
+}
+
+// MockAuditUseCase is a mock of AuditUseCase interface.
+type MockAuditUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditUseCaseMockRecorder
+}
+
+// MockAuditUseCaseMockRecorder is the mock recorder for MockAuditUseCase.
+type MockAuditUseCaseMockRecorder struct {
+	mock *MockAuditUseCase
+}
+
+// NewMockAuditUseCase creates a new mock instance.
+func NewMockAuditUseCase(ctrl *gomock.Controller) *MockAuditUseCase {
+	mock := &MockAuditUseCase{ctrl: ctrl}
+	mock.recorder = &MockAuditUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditUseCase) EXPECT() *MockAuditUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockAuditUseCase) GetAuditEntries(ctx context.Context, filter entity.AuditFilter, pagination httpquery.Pagination) ([]entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEntries", ctx, filter, pagination)
+	ret0, _ := ret[0].([]entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockAuditUseCaseMockRecorder) GetAuditEntries(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockAuditUseCase)(nil).GetAuditEntries), ctx, filter, pagination)
+}
+
+// GetTaskHistory mocks base method.
+func (m *MockAuditUseCase) GetTaskHistory(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskHistory", ctx, todoID, pagination)
+	ret0, _ := ret[0].([]entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskHistory indicates an expected call of GetTaskHistory.
+func (mr *MockAuditUseCaseMockRecorder) GetTaskHistory(ctx, todoID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskHistory", reflect.TypeOf((*MockAuditUseCase)(nil).GetTaskHistory), ctx, todoID, pagination)
+}
@@ -0,0 +1,205 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment.go
+
+// Package mock_entity is a generated GoMock package.
+package mock_entity
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/Vaixle/crud-golang/internal/entity"
+	httpquery "github.com/Vaixle/crud-golang/pkg/httpquery"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentRepository is a mock of CommentRepository interface.
+type MockCommentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentRepositoryMockRecorder
+}
+
+// MockCommentRepositoryMockRecorder is the mock recorder for MockCommentRepository.
+type MockCommentRepositoryMockRecorder struct {
+	mock *MockCommentRepository
+}
+
+// NewMockCommentRepository creates a new mock instance.
+func NewMockCommentRepository(ctrl *gomock.Controller) *MockCommentRepository {
+	mock := &MockCommentRepository{ctrl: ctrl}
+	mock.recorder = &MockCommentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentRepository) EXPECT() *MockCommentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentRepository) DeleteComment(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentRepositoryMockRecorder) DeleteComment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentRepository)(nil).DeleteComment), ctx, id)
+}
+
+// GetCommentById mocks base method.
+func (m *MockCommentRepository) GetCommentById(ctx context.Context, id uint) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentById", ctx, id)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentById indicates an expected call of GetCommentById.
+func (mr *MockCommentRepositoryMockRecorder) GetCommentById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentById", reflect.TypeOf((*MockCommentRepository)(nil).GetCommentById), ctx, id)
+}
+
+// GetComments mocks base method.
+func (m *MockCommentRepository) GetComments(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, todoID, pagination)
+	ret0, _ := ret[0].([]entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentRepositoryMockRecorder) GetComments(ctx, todoID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentRepository)(nil).GetComments), ctx, todoID, pagination)
+}
+
+// SaveComment mocks base method.
+func (m *MockCommentRepository) SaveComment(ctx context.Context, comment *entity.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveComment indicates an expected call of SaveComment.
+func (mr *MockCommentRepositoryMockRecorder) SaveComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveComment", reflect.TypeOf((*MockCommentRepository)(nil).SaveComment), ctx, comment)
+}
+
+// UpdateComment mocks base method.
+func (m *MockCommentRepository) UpdateComment(ctx context.Context, comment *entity.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockCommentRepositoryMockRecorder) UpdateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockCommentRepository)(nil).UpdateComment), ctx, comment)
+}
+
+// MockCommentUseCase is a mock of CommentUseCase interface.
+type MockCommentUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentUseCaseMockRecorder
+}
+
+// MockCommentUseCaseMockRecorder is the mock recorder for MockCommentUseCase.
+type MockCommentUseCaseMockRecorder struct {
+	mock *MockCommentUseCase
+}
+
+// NewMockCommentUseCase creates a new mock instance.
+func NewMockCommentUseCase(ctrl *gomock.Controller) *MockCommentUseCase {
+	mock := &MockCommentUseCase{ctrl: ctrl}
+	mock.recorder = &MockCommentUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentUseCase) EXPECT() *MockCommentUseCaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentUseCase) DeleteComment(ctx context.Context, todoID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentUseCaseMockRecorder) DeleteComment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentUseCase)(nil).DeleteComment), ctx, todoID, id)
+}
+
+// GetComment mocks base method.
+func (m *MockCommentUseCase) GetComment(ctx context.Context, todoID, id uint) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", ctx, todoID, id)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockCommentUseCaseMockRecorder) GetComment(ctx, todoID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockCommentUseCase)(nil).GetComment), ctx, todoID, id)
+}
+
+// GetComments mocks base method.
+func (m *MockCommentUseCase) GetComments(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, todoID, pagination)
+	ret0, _ := ret[0].([]entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentUseCaseMockRecorder) GetComments(ctx, todoID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentUseCase)(nil).GetComments), ctx, todoID, pagination)
+}
+
+// SaveComment mocks base method.
+func (m *MockCommentUseCase) SaveComment(ctx context.Context, comment *entity.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveComment indicates an expected call of SaveComment.
+func (mr *MockCommentUseCaseMockRecorder) SaveComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveComment", reflect.TypeOf((*MockCommentUseCase)(nil).SaveComment), ctx, comment)
+}
+
+// UpdateComment mocks base method.
+func (m *MockCommentUseCase) UpdateComment(ctx context.Context, todoID, id uint, body string) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, todoID, id, body)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockCommentUseCaseMockRecorder) UpdateComment(ctx, todoID, id, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockCommentUseCase)(nil).UpdateComment), ctx, todoID, id, body)
+}
@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: idempotency.go
+
+// Package mock_entity is a generated GoMock package.
+package mock_entity
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/Vaixle/crud-golang/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdempotencyRepository is a mock of IdempotencyRepository interface.
+type MockIdempotencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryMockRecorder
+}
+
+// MockIdempotencyRepositoryMockRecorder is the mock recorder for MockIdempotencyRepository.
+type MockIdempotencyRepositoryMockRecorder struct {
+	mock *MockIdempotencyRepository
+}
+
+// NewMockIdempotencyRepository creates a new mock instance.
+func NewMockIdempotencyRepository(ctrl *gomock.Controller) *MockIdempotencyRepository {
+	mock := &MockIdempotencyRepository{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CompleteIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIdempotencyKey", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteIdempotencyKey indicates an expected call of CompleteIdempotencyKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) CompleteIdempotencyKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).CompleteIdempotencyKey), ctx, key)
+}
+
+// DeleteExpiredIdempotencyKeys mocks base method.
+func (m *MockIdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredIdempotencyKeys", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredIdempotencyKeys indicates an expected call of DeleteExpiredIdempotencyKeys.
+func (mr *MockIdempotencyRepositoryMockRecorder) DeleteExpiredIdempotencyKeys(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIdempotencyKeys", reflect.TypeOf((*MockIdempotencyRepository)(nil).DeleteExpiredIdempotencyKeys), ctx, now)
+}
+
+// DeleteIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdempotencyKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdempotencyKey indicates an expected call of DeleteIdempotencyKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) DeleteIdempotencyKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).DeleteIdempotencyKey), ctx, id)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepository) GetIdempotencyKey(ctx context.Context, actor, key string) (*entity.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, actor, key)
+	ret0, _ := ret[0].(*entity.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) GetIdempotencyKey(ctx, actor, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).GetIdempotencyKey), ctx, actor, key)
+}
+
+// SaveIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepository) SaveIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotencyKey", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveIdempotencyKey indicates an expected call of SaveIdempotencyKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) SaveIdempotencyKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).SaveIdempotencyKey), ctx, key)
+}
+
+// TakeOverIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepository) TakeOverIdempotencyKey(ctx context.Context, id uint, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeOverIdempotencyKey", ctx, id, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeOverIdempotencyKey indicates an expected call of TakeOverIdempotencyKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) TakeOverIdempotencyKey(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeOverIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).TakeOverIdempotencyKey), ctx, id, now)
+}
+
+// MockIdempotencyUseCase is a mock of IdempotencyUseCase interface.
+type MockIdempotencyUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyUseCaseMockRecorder
+}
+
+// MockIdempotencyUseCaseMockRecorder is the mock recorder for MockIdempotencyUseCase.
+type MockIdempotencyUseCaseMockRecorder struct {
+	mock *MockIdempotencyUseCase
+}
+
+// NewMockIdempotencyUseCase creates a new mock instance.
+func NewMockIdempotencyUseCase(ctrl *gomock.Controller) *MockIdempotencyUseCase {
+	mock := &MockIdempotencyUseCase{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyUseCase) EXPECT() *MockIdempotencyUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIdempotencyUseCase) Begin(ctx context.Context, key, requestHash string) (*entity.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, key, requestHash)
+	ret0, _ := ret[0].(*entity.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdempotencyUseCaseMockRecorder) Begin(ctx, key, requestHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdempotencyUseCase)(nil).Begin), ctx, key, requestHash)
+}
+
+// Finish mocks base method.
+func (m *MockIdempotencyUseCase) Finish(ctx context.Context, key *entity.IdempotencyKey, statusCode int, headers map[string]string, response []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, key, statusCode, headers, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockIdempotencyUseCaseMockRecorder) Finish(ctx, key, statusCode, headers, response interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockIdempotencyUseCase)(nil).Finish), ctx, key, statusCode, headers, response)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockIdempotencyUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockIdempotencyUseCaseMockRecorder) PurgeExpired(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockIdempotencyUseCase)(nil).PurgeExpired), ctx)
+}
@@ -5,10 +5,11 @@
 package mock_entity
 
 import (
-	entity "github.com/Vaixle/crud-golang/internal/entity"
-	httpquery "github.com/Vaixle/crud-golang/pkg/httpquery"
+	context "context"
 	reflect "reflect"
 
+	entity "github.com/Vaixle/crud-golang/internal/entity"
+	httpquery "github.com/Vaixle/crud-golang/pkg/httpquery"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,48 +36,410 @@ func (m *MockTodoRepository) EXPECT() *MockTodoRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountChildren mocks base method.
+func (m *MockTodoRepository) CountChildren(ctx context.Context, id uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChildren", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChildren indicates an expected call of CountChildren.
+func (mr *MockTodoRepositoryMockRecorder) CountChildren(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildren", reflect.TypeOf((*MockTodoRepository)(nil).CountChildren), ctx, id)
+}
+
+// CountOpenChildren mocks base method.
+func (m *MockTodoRepository) CountOpenChildren(ctx context.Context, id uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenChildren", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenChildren indicates an expected call of CountOpenChildren.
+func (mr *MockTodoRepositoryMockRecorder) CountOpenChildren(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenChildren", reflect.TypeOf((*MockTodoRepository)(nil).CountOpenChildren), ctx, id)
+}
+
+// CountTasksByStatus mocks base method.
+func (m *MockTodoRepository) CountTasksByStatus(ctx context.Context) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTasksByStatus", ctx)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTasksByStatus indicates an expected call of CountTasksByStatus.
+func (mr *MockTodoRepositoryMockRecorder) CountTasksByStatus(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTasksByStatus", reflect.TypeOf((*MockTodoRepository)(nil).CountTasksByStatus), ctx)
+}
+
+// DeleteDependency mocks base method.
+func (m *MockTodoRepository) DeleteDependency(ctx context.Context, todoID, blockerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDependency", ctx, todoID, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDependency indicates an expected call of DeleteDependency.
+func (mr *MockTodoRepositoryMockRecorder) DeleteDependency(ctx, todoID, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDependency", reflect.TypeOf((*MockTodoRepository)(nil).DeleteDependency), ctx, todoID, blockerID)
+}
+
+// DeleteTask mocks base method.
+func (m *MockTodoRepository) DeleteTask(ctx context.Context, id, version uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockTodoRepositoryMockRecorder) DeleteTask(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTodoRepository)(nil).DeleteTask), ctx, id, version)
+}
+
+// GetAncestorIds mocks base method.
+func (m *MockTodoRepository) GetAncestorIds(ctx context.Context, id uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAncestorIds", ctx, id)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAncestorIds indicates an expected call of GetAncestorIds.
+func (mr *MockTodoRepositoryMockRecorder) GetAncestorIds(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAncestorIds", reflect.TypeOf((*MockTodoRepository)(nil).GetAncestorIds), ctx, id)
+}
+
+// GetBlocked mocks base method.
+func (m *MockTodoRepository) GetBlocked(ctx context.Context, id uint) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocked", ctx, id)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocked indicates an expected call of GetBlocked.
+func (mr *MockTodoRepositoryMockRecorder) GetBlocked(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocked", reflect.TypeOf((*MockTodoRepository)(nil).GetBlocked), ctx, id)
+}
+
+// GetBlockers mocks base method.
+func (m *MockTodoRepository) GetBlockers(ctx context.Context, id uint) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockers", ctx, id)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockers indicates an expected call of GetBlockers.
+func (mr *MockTodoRepositoryMockRecorder) GetBlockers(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockers", reflect.TypeOf((*MockTodoRepository)(nil).GetBlockers), ctx, id)
+}
+
+// GetChildren mocks base method.
+func (m *MockTodoRepository) GetChildren(ctx context.Context, id uint) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", ctx, id)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockTodoRepositoryMockRecorder) GetChildren(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockTodoRepository)(nil).GetChildren), ctx, id)
+}
+
+// GetDependencies mocks base method.
+func (m *MockTodoRepository) GetDependencies(ctx context.Context, ids []uint) ([]entity.TodoDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependencies", ctx, ids)
+	ret0, _ := ret[0].([]entity.TodoDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependencies indicates an expected call of GetDependencies.
+func (mr *MockTodoRepositoryMockRecorder) GetDependencies(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependencies", reflect.TypeOf((*MockTodoRepository)(nil).GetDependencies), ctx, ids)
+}
+
+// GetSubtree mocks base method.
+func (m *MockTodoRepository) GetSubtree(ctx context.Context, id uint) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtree", ctx, id)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtree indicates an expected call of GetSubtree.
+func (mr *MockTodoRepositoryMockRecorder) GetSubtree(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtree", reflect.TypeOf((*MockTodoRepository)(nil).GetSubtree), ctx, id)
+}
+
 // GetTaskById mocks base method.
-func (m *MockTodoRepository) GetTaskById(id uint) (*entity.Todo, error) {
+func (m *MockTodoRepository) GetTaskById(ctx context.Context, id uint) (*entity.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskById", id)
+	ret := m.ctrl.Call(m, "GetTaskById", ctx, id)
 	ret0, _ := ret[0].(*entity.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTaskById indicates an expected call of GetTaskById.
-func (mr *MockTodoRepositoryMockRecorder) GetTaskById(id interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) GetTaskById(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTodoRepository)(nil).GetTaskById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTodoRepository)(nil).GetTaskById), ctx, id)
 }
 
 // GetTasks mocks base method.
-func (m *MockTodoRepository) GetTasks(filters []httpquery.FilterOption, pagination httpquery.Pagination) ([]entity.Todo, error) {
+func (m *MockTodoRepository) GetTasks(ctx context.Context, filters []httpquery.FilterOption, pagination httpquery.Pagination, projection entity.Projection) ([]entity.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", filters, pagination)
+	ret := m.ctrl.Call(m, "GetTasks", ctx, filters, pagination, projection)
 	ret0, _ := ret[0].([]entity.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockTodoRepositoryMockRecorder) GetTasks(filters, pagination interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) GetTasks(ctx, filters, pagination, projection interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTodoRepository)(nil).GetTasks), ctx, filters, pagination, projection)
+}
+
+// GetTasksByIds mocks base method.
+func (m *MockTodoRepository) GetTasksByIds(ctx context.Context, ids []uint) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByIds", ctx, ids)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByIds indicates an expected call of GetTasksByIds.
+func (mr *MockTodoRepositoryMockRecorder) GetTasksByIds(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByIds", reflect.TypeOf((*MockTodoRepository)(nil).GetTasksByIds), ctx, ids)
+}
+
+// GetTransitions mocks base method.
+func (m *MockTodoRepository) GetTransitions(ctx context.Context, todoID uint) ([]entity.TodoTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransitions", ctx, todoID)
+	ret0, _ := ret[0].([]entity.TodoTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransitions indicates an expected call of GetTransitions.
+func (mr *MockTodoRepositoryMockRecorder) GetTransitions(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitions", reflect.TypeOf((*MockTodoRepository)(nil).GetTransitions), ctx, todoID)
+}
+
+// IsBlockedBy mocks base method.
+func (m *MockTodoRepository) IsBlockedBy(ctx context.Context, id, blockerID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlockedBy", ctx, id, blockerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlockedBy indicates an expected call of IsBlockedBy.
+func (mr *MockTodoRepositoryMockRecorder) IsBlockedBy(ctx, id, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlockedBy", reflect.TypeOf((*MockTodoRepository)(nil).IsBlockedBy), ctx, id, blockerID)
+}
+
+// LockDependencies mocks base method.
+func (m *MockTodoRepository) LockDependencies(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockDependencies", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockDependencies indicates an expected call of LockDependencies.
+func (mr *MockTodoRepositoryMockRecorder) LockDependencies(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTodoRepository)(nil).GetTasks), filters, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockDependencies", reflect.TypeOf((*MockTodoRepository)(nil).LockDependencies), ctx)
+}
+
+// PurgeTask mocks base method.
+func (m *MockTodoRepository) PurgeTask(ctx context.Context, id, version uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTask", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeTask indicates an expected call of PurgeTask.
+func (mr *MockTodoRepositoryMockRecorder) PurgeTask(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTask", reflect.TypeOf((*MockTodoRepository)(nil).PurgeTask), ctx, id, version)
+}
+
+// ReplaceAssignees mocks base method.
+func (m *MockTodoRepository) ReplaceAssignees(ctx context.Context, task *entity.Todo, users []entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAssignees", ctx, task, users)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceAssignees indicates an expected call of ReplaceAssignees.
+func (mr *MockTodoRepositoryMockRecorder) ReplaceAssignees(ctx, task, users interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAssignees", reflect.TypeOf((*MockTodoRepository)(nil).ReplaceAssignees), ctx, task, users)
+}
+
+// ReplaceTags mocks base method.
+func (m *MockTodoRepository) ReplaceTags(ctx context.Context, task *entity.Todo, tags []entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTags", ctx, task, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTags indicates an expected call of ReplaceTags.
+func (mr *MockTodoRepositoryMockRecorder) ReplaceTags(ctx, task, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTags", reflect.TypeOf((*MockTodoRepository)(nil).ReplaceTags), ctx, task, tags)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTodoRepository) RestoreTask(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTodoRepositoryMockRecorder) RestoreTask(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTodoRepository)(nil).RestoreTask), ctx, id)
+}
+
+// SaveDependency mocks base method.
+func (m *MockTodoRepository) SaveDependency(ctx context.Context, dependency *entity.TodoDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDependency", ctx, dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDependency indicates an expected call of SaveDependency.
+func (mr *MockTodoRepositoryMockRecorder) SaveDependency(ctx, dependency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDependency", reflect.TypeOf((*MockTodoRepository)(nil).SaveDependency), ctx, dependency)
 }
 
 // SaveTask mocks base method.
-func (m *MockTodoRepository) SaveTask(task *entity.Todo) error {
+func (m *MockTodoRepository) SaveTask(ctx context.Context, task *entity.Todo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveTask", task)
+	ret := m.ctrl.Call(m, "SaveTask", ctx, task)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveTask indicates an expected call of SaveTask.
-func (mr *MockTodoRepositoryMockRecorder) SaveTask(task interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) SaveTask(ctx, task interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTask", reflect.TypeOf((*MockTodoRepository)(nil).SaveTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTask", reflect.TypeOf((*MockTodoRepository)(nil).SaveTask), ctx, task)
+}
+
+// SaveTasks mocks base method.
+func (m *MockTodoRepository) SaveTasks(ctx context.Context, tasks []*entity.Todo, batchSize int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTasks", ctx, tasks, batchSize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTasks indicates an expected call of SaveTasks.
+func (mr *MockTodoRepositoryMockRecorder) SaveTasks(ctx, tasks, batchSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTasks", reflect.TypeOf((*MockTodoRepository)(nil).SaveTasks), ctx, tasks, batchSize)
+}
+
+// SaveTransition mocks base method.
+func (m *MockTodoRepository) SaveTransition(ctx context.Context, transition *entity.TodoTransition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTransition", ctx, transition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTransition indicates an expected call of SaveTransition.
+func (mr *MockTodoRepositoryMockRecorder) SaveTransition(ctx, transition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTransition", reflect.TypeOf((*MockTodoRepository)(nil).SaveTransition), ctx, transition)
+}
+
+// StreamTasks mocks base method.
+func (m *MockTodoRepository) StreamTasks(ctx context.Context, filters []httpquery.FilterOption, fn func([]entity.Todo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamTasks", ctx, filters, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamTasks indicates an expected call of StreamTasks.
+func (mr *MockTodoRepositoryMockRecorder) StreamTasks(ctx, filters, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamTasks", reflect.TypeOf((*MockTodoRepository)(nil).StreamTasks), ctx, filters, fn)
+}
+
+// Transaction mocks base method.
+func (m *MockTodoRepository) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockTodoRepositoryMockRecorder) Transaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockTodoRepository)(nil).Transaction), ctx, fn)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTodoRepository) UpdateTask(ctx context.Context, task *entity.Todo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTodoRepositoryMockRecorder) UpdateTask(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTodoRepository)(nil).UpdateTask), ctx, task)
 }
 
 // MockTodoUseCase is a mock of TodoUseCase interface.
@@ -102,46 +465,281 @@ func (m *MockTodoUseCase) EXPECT() *MockTodoUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AddBlocker mocks base method.
+func (m *MockTodoUseCase) AddBlocker(ctx context.Context, id, blockerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlocker", ctx, id, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBlocker indicates an expected call of AddBlocker.
+func (mr *MockTodoUseCaseMockRecorder) AddBlocker(ctx, id, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlocker", reflect.TypeOf((*MockTodoUseCase)(nil).AddBlocker), ctx, id, blockerID)
+}
+
+// Batch mocks base method.
+func (m *MockTodoUseCase) Batch(ctx context.Context, operations []entity.BatchOperation, atomic bool) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", ctx, operations, atomic)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockTodoUseCaseMockRecorder) Batch(ctx, operations, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockTodoUseCase)(nil).Batch), ctx, operations, atomic)
+}
+
+// CountTasksByStatus mocks base method.
+func (m *MockTodoUseCase) CountTasksByStatus(ctx context.Context) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTasksByStatus", ctx)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTasksByStatus indicates an expected call of CountTasksByStatus.
+func (mr *MockTodoUseCaseMockRecorder) CountTasksByStatus(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTasksByStatus", reflect.TypeOf((*MockTodoUseCase)(nil).CountTasksByStatus), ctx)
+}
+
+// DeleteTask mocks base method.
+func (m *MockTodoUseCase) DeleteTask(ctx context.Context, id, version uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockTodoUseCaseMockRecorder) DeleteTask(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTodoUseCase)(nil).DeleteTask), ctx, id, version)
+}
+
+// ExportTasks mocks base method.
+func (m *MockTodoUseCase) ExportTasks(ctx context.Context, filters []httpquery.FilterOption, fn func([]entity.Todo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTasks", ctx, filters, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTasks indicates an expected call of ExportTasks.
+func (mr *MockTodoUseCaseMockRecorder) ExportTasks(ctx, filters, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTasks", reflect.TypeOf((*MockTodoUseCase)(nil).ExportTasks), ctx, filters, fn)
+}
+
+// GetBlocked mocks base method.
+func (m *MockTodoUseCase) GetBlocked(ctx context.Context, id uint) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocked", ctx, id)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocked indicates an expected call of GetBlocked.
+func (mr *MockTodoUseCaseMockRecorder) GetBlocked(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocked", reflect.TypeOf((*MockTodoUseCase)(nil).GetBlocked), ctx, id)
+}
+
+// GetBlockers mocks base method.
+func (m *MockTodoUseCase) GetBlockers(ctx context.Context, id uint) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockers", ctx, id)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockers indicates an expected call of GetBlockers.
+func (mr *MockTodoUseCaseMockRecorder) GetBlockers(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockers", reflect.TypeOf((*MockTodoUseCase)(nil).GetBlockers), ctx, id)
+}
+
+// GetChildren mocks base method.
+func (m *MockTodoUseCase) GetChildren(ctx context.Context, id uint) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", ctx, id)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockTodoUseCaseMockRecorder) GetChildren(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockTodoUseCase)(nil).GetChildren), ctx, id)
+}
+
 // GetTaskById mocks base method.
-func (m *MockTodoUseCase) GetTaskById(id uint) (*entity.Todo, error) {
+func (m *MockTodoUseCase) GetTaskById(ctx context.Context, id uint) (*entity.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskById", id)
+	ret := m.ctrl.Call(m, "GetTaskById", ctx, id)
 	ret0, _ := ret[0].(*entity.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTaskById indicates an expected call of GetTaskById.
-func (mr *MockTodoUseCaseMockRecorder) GetTaskById(id interface{}) *gomock.Call {
+func (mr *MockTodoUseCaseMockRecorder) GetTaskById(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTodoUseCase)(nil).GetTaskById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTodoUseCase)(nil).GetTaskById), ctx, id)
 }
 
 // GetTasks mocks base method.
-func (m *MockTodoUseCase) GetTasks(filters []httpquery.FilterOption, pagination httpquery.Pagination) ([]entity.Todo, error) {
+func (m *MockTodoUseCase) GetTasks(ctx context.Context, filters []httpquery.FilterOption, pagination httpquery.Pagination, projection entity.Projection) ([]entity.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", filters, pagination)
+	ret := m.ctrl.Call(m, "GetTasks", ctx, filters, pagination, projection)
 	ret0, _ := ret[0].([]entity.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockTodoUseCaseMockRecorder) GetTasks(filters, pagination interface{}) *gomock.Call {
+func (mr *MockTodoUseCaseMockRecorder) GetTasks(ctx, filters, pagination, projection interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTodoUseCase)(nil).GetTasks), ctx, filters, pagination, projection)
+}
+
+// GetTopologicalOrder mocks base method.
+func (m *MockTodoUseCase) GetTopologicalOrder(ctx context.Context, ids []uint) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopologicalOrder", ctx, ids)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopologicalOrder indicates an expected call of GetTopologicalOrder.
+func (mr *MockTodoUseCaseMockRecorder) GetTopologicalOrder(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopologicalOrder", reflect.TypeOf((*MockTodoUseCase)(nil).GetTopologicalOrder), ctx, ids)
+}
+
+// GetTransitions mocks base method.
+func (m *MockTodoUseCase) GetTransitions(ctx context.Context, id uint) ([]entity.TodoTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransitions", ctx, id)
+	ret0, _ := ret[0].([]entity.TodoTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransitions indicates an expected call of GetTransitions.
+func (mr *MockTodoUseCaseMockRecorder) GetTransitions(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitions", reflect.TypeOf((*MockTodoUseCase)(nil).GetTransitions), ctx, id)
+}
+
+// GetTree mocks base method.
+func (m *MockTodoUseCase) GetTree(ctx context.Context, id uint) (*entity.TodoNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", ctx, id)
+	ret0, _ := ret[0].(*entity.TodoNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockTodoUseCaseMockRecorder) GetTree(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTodoUseCase)(nil).GetTasks), filters, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockTodoUseCase)(nil).GetTree), ctx, id)
+}
+
+// ImportTasks mocks base method.
+func (m *MockTodoUseCase) ImportTasks(ctx context.Context, tasks []*entity.Todo, dryRun bool) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTasks", ctx, tasks, dryRun)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTasks indicates an expected call of ImportTasks.
+func (mr *MockTodoUseCaseMockRecorder) ImportTasks(ctx, tasks, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTasks", reflect.TypeOf((*MockTodoUseCase)(nil).ImportTasks), ctx, tasks, dryRun)
+}
+
+// PurgeTask mocks base method.
+func (m *MockTodoUseCase) PurgeTask(ctx context.Context, id, version uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTask", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeTask indicates an expected call of PurgeTask.
+func (mr *MockTodoUseCaseMockRecorder) PurgeTask(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTask", reflect.TypeOf((*MockTodoUseCase)(nil).PurgeTask), ctx, id, version)
+}
+
+// RemoveBlocker mocks base method.
+func (m *MockTodoUseCase) RemoveBlocker(ctx context.Context, id, blockerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBlocker", ctx, id, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBlocker indicates an expected call of RemoveBlocker.
+func (mr *MockTodoUseCaseMockRecorder) RemoveBlocker(ctx, id, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBlocker", reflect.TypeOf((*MockTodoUseCase)(nil).RemoveBlocker), ctx, id, blockerID)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTodoUseCase) RestoreTask(ctx context.Context, id uint) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, id)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTodoUseCaseMockRecorder) RestoreTask(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTodoUseCase)(nil).RestoreTask), ctx, id)
 }
 
 // SaveTask mocks base method.
-func (m *MockTodoUseCase) SaveTask(task *entity.Todo) error {
+func (m *MockTodoUseCase) SaveTask(ctx context.Context, task *entity.Todo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveTask", task)
+	ret := m.ctrl.Call(m, "SaveTask", ctx, task)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveTask indicates an expected call of SaveTask.
-func (mr *MockTodoUseCaseMockRecorder) SaveTask(task interface{}) *gomock.Call {
+func (mr *MockTodoUseCaseMockRecorder) SaveTask(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTask", reflect.TypeOf((*MockTodoUseCase)(nil).SaveTask), ctx, task)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTodoUseCase) UpdateTask(ctx context.Context, id, version uint, patch entity.TodoPatch) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, id, version, patch)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTodoUseCaseMockRecorder) UpdateTask(ctx, id, version, patch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTask", reflect.TypeOf((*MockTodoUseCase)(nil).SaveTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTodoUseCase)(nil).UpdateTask), ctx, id, version, patch)
 }
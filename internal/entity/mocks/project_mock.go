@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: project.go
+
+// Package mock_entity is a generated GoMock package.
+package mock_entity
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/Vaixle/crud-golang/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProjectRepository is a mock of ProjectRepository interface.
+type MockProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectRepositoryMockRecorder
+}
+
+// MockProjectRepositoryMockRecorder is the mock recorder for MockProjectRepository.
+type MockProjectRepositoryMockRecorder struct {
+	mock *MockProjectRepository
+}
+
+// NewMockProjectRepository creates a new mock instance.
+func NewMockProjectRepository(ctrl *gomock.Controller) *MockProjectRepository {
+	mock := &MockProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectRepository) EXPECT() *MockProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetProjectById mocks base method.
+func (m *MockProjectRepository) GetProjectById(ctx context.Context, id uint) (*entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectById", ctx, id)
+	ret0, _ := ret[0].(*entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectById indicates an expected call of GetProjectById.
+func (mr *MockProjectRepositoryMockRecorder) GetProjectById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectById", reflect.TypeOf((*MockProjectRepository)(nil).GetProjectById), ctx, id)
+}
+
+// GetProjects mocks base method.
+func (m *MockProjectRepository) GetProjects(ctx context.Context, includeArchived bool) ([]entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", ctx, includeArchived)
+	ret0, _ := ret[0].([]entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockProjectRepositoryMockRecorder) GetProjects(ctx, includeArchived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockProjectRepository)(nil).GetProjects), ctx, includeArchived)
+}
+
+// SaveProject mocks base method.
+func (m *MockProjectRepository) SaveProject(ctx context.Context, project *entity.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProject", ctx, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProject indicates an expected call of SaveProject.
+func (mr *MockProjectRepositoryMockRecorder) SaveProject(ctx, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProject", reflect.TypeOf((*MockProjectRepository)(nil).SaveProject), ctx, project)
+}
+
+// UpdateProject mocks base method.
+func (m *MockProjectRepository) UpdateProject(ctx context.Context, project *entity.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockProjectRepositoryMockRecorder) UpdateProject(ctx, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProjectRepository)(nil).UpdateProject), ctx, project)
+}
+
+// MockProjectUseCase is a mock of ProjectUseCase interface.
+type MockProjectUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectUseCaseMockRecorder
+}
+
+// MockProjectUseCaseMockRecorder is the mock recorder for MockProjectUseCase.
+type MockProjectUseCaseMockRecorder struct {
+	mock *MockProjectUseCase
+}
+
+// NewMockProjectUseCase creates a new mock instance.
+func NewMockProjectUseCase(ctrl *gomock.Controller) *MockProjectUseCase {
+	mock := &MockProjectUseCase{ctrl: ctrl}
+	mock.recorder = &MockProjectUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectUseCase) EXPECT() *MockProjectUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetProjectById mocks base method.
+func (m *MockProjectUseCase) GetProjectById(ctx context.Context, id uint) (*entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectById", ctx, id)
+	ret0, _ := ret[0].(*entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectById indicates an expected call of GetProjectById.
+func (mr *MockProjectUseCaseMockRecorder) GetProjectById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectById", reflect.TypeOf((*MockProjectUseCase)(nil).GetProjectById), ctx, id)
+}
+
+// GetProjects mocks base method.
+func (m *MockProjectUseCase) GetProjects(ctx context.Context, includeArchived bool) ([]entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", ctx, includeArchived)
+	ret0, _ := ret[0].([]entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockProjectUseCaseMockRecorder) GetProjects(ctx, includeArchived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockProjectUseCase)(nil).GetProjects), ctx, includeArchived)
+}
+
+// SaveProject mocks base method.
+func (m *MockProjectUseCase) SaveProject(ctx context.Context, project *entity.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProject", ctx, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProject indicates an expected call of SaveProject.
+func (mr *MockProjectUseCaseMockRecorder) SaveProject(ctx, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProject", reflect.TypeOf((*MockProjectUseCase)(nil).SaveProject), ctx, project)
+}
+
+// UpdateProject mocks base method.
+func (m *MockProjectUseCase) UpdateProject(ctx context.Context, id uint, patch entity.ProjectPatch) (*entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, id, patch)
+	ret0, _ := ret[0].(*entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockProjectUseCaseMockRecorder) UpdateProject(ctx, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProjectUseCase)(nil).UpdateProject), ctx, id, patch)
+}
@@ -0,0 +1,218 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tag.go
+
+// Package mock_entity is a generated GoMock package.
+package mock_entity
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/Vaixle/crud-golang/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTagRepository is a mock of TagRepository interface.
+type MockTagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryMockRecorder
+}
+
+// MockTagRepositoryMockRecorder is the mock recorder for MockTagRepository.
+type MockTagRepositoryMockRecorder struct {
+	mock *MockTagRepository
+}
+
+// NewMockTagRepository creates a new mock instance.
+func NewMockTagRepository(ctrl *gomock.Controller) *MockTagRepository {
+	mock := &MockTagRepository{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepository) EXPECT() *MockTagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteTag mocks base method.
+func (m *MockTagRepository) DeleteTag(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockTagRepositoryMockRecorder) DeleteTag(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTagRepository)(nil).DeleteTag), ctx, id)
+}
+
+// GetTagById mocks base method.
+func (m *MockTagRepository) GetTagById(ctx context.Context, id uint) (*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagById", ctx, id)
+	ret0, _ := ret[0].(*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagById indicates an expected call of GetTagById.
+func (mr *MockTagRepositoryMockRecorder) GetTagById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagById", reflect.TypeOf((*MockTagRepository)(nil).GetTagById), ctx, id)
+}
+
+// GetTags mocks base method.
+func (m *MockTagRepository) GetTags(ctx context.Context) ([]entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx)
+	ret0, _ := ret[0].([]entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTagRepositoryMockRecorder) GetTags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTagRepository)(nil).GetTags), ctx)
+}
+
+// GetTagsByIds mocks base method.
+func (m *MockTagRepository) GetTagsByIds(ctx context.Context, ids []uint) ([]entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagsByIds", ctx, ids)
+	ret0, _ := ret[0].([]entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagsByIds indicates an expected call of GetTagsByIds.
+func (mr *MockTagRepositoryMockRecorder) GetTagsByIds(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsByIds", reflect.TypeOf((*MockTagRepository)(nil).GetTagsByIds), ctx, ids)
+}
+
+// SaveTag mocks base method.
+func (m *MockTagRepository) SaveTag(ctx context.Context, tag *entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTag", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTag indicates an expected call of SaveTag.
+func (mr *MockTagRepositoryMockRecorder) SaveTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTag", reflect.TypeOf((*MockTagRepository)(nil).SaveTag), ctx, tag)
+}
+
+// UpdateTag mocks base method.
+func (m *MockTagRepository) UpdateTag(ctx context.Context, tag *entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockTagRepositoryMockRecorder) UpdateTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockTagRepository)(nil).UpdateTag), ctx, tag)
+}
+
+// MockTagUseCase is a mock of TagUseCase interface.
+type MockTagUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagUseCaseMockRecorder
+}
+
+// MockTagUseCaseMockRecorder is the mock recorder for MockTagUseCase.
+type MockTagUseCaseMockRecorder struct {
+	mock *MockTagUseCase
+}
+
+// NewMockTagUseCase creates a new mock instance.
+func NewMockTagUseCase(ctrl *gomock.Controller) *MockTagUseCase {
+	mock := &MockTagUseCase{ctrl: ctrl}
+	mock.recorder = &MockTagUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagUseCase) EXPECT() *MockTagUseCaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteTag mocks base method.
+func (m *MockTagUseCase) DeleteTag(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockTagUseCaseMockRecorder) DeleteTag(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTagUseCase)(nil).DeleteTag), ctx, id)
+}
+
+// GetTagById mocks base method.
+func (m *MockTagUseCase) GetTagById(ctx context.Context, id uint) (*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagById", ctx, id)
+	ret0, _ := ret[0].(*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagById indicates an expected call of GetTagById.
+func (mr *MockTagUseCaseMockRecorder) GetTagById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagById", reflect.TypeOf((*MockTagUseCase)(nil).GetTagById), ctx, id)
+}
+
+// GetTags mocks base method.
+func (m *MockTagUseCase) GetTags(ctx context.Context) ([]entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx)
+	ret0, _ := ret[0].([]entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTagUseCaseMockRecorder) GetTags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTagUseCase)(nil).GetTags), ctx)
+}
+
+// SaveTag mocks base method.
+func (m *MockTagUseCase) SaveTag(ctx context.Context, tag *entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTag", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTag indicates an expected call of SaveTag.
+func (mr *MockTagUseCaseMockRecorder) SaveTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTag", reflect.TypeOf((*MockTagUseCase)(nil).SaveTag), ctx, tag)
+}
+
+// UpdateTag mocks base method.
+func (m *MockTagUseCase) UpdateTag(ctx context.Context, tag *entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockTagUseCaseMockRecorder) UpdateTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockTagUseCase)(nil).UpdateTag), ctx, tag)
+}
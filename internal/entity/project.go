@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=project.go -destination=./mocks/project_mock.go
+
+// Project groups tasks; new tasks in it start with its default status and priority.
+type Project struct {
+	gorm.Model
+	Name        string `json:"name" gorm:"type:varchar(128);not null" binding:"required,max=128" example:"backend"`
+	Description string `json:"description" example:"API and workers"`
+	// Archived hides the tasks of the project from the default task list.
+	Archived        bool   `json:"archived" gorm:"not null;default:false" example:"false"`
+	DefaultStatus   string `json:"default_status,omitempty" gorm:"type:varchar(64)" example:"open"`
+	DefaultPriority string `json:"default_priority,omitempty" gorm:"type:varchar(16)" binding:"omitempty,oneof=low normal high urgent" example:"normal"`
+}
+
+// ProjectPatch is a partial update of a project; nil fields are left unchanged.
+type ProjectPatch struct {
+	Name            *string `json:"name" binding:"omitempty,min=1,max=128" example:"backend"`
+	Description     *string `json:"description" example:"API and workers"`
+	Archived        *bool   `json:"archived" example:"true"`
+	DefaultStatus   *string `json:"default_status" example:"open"`
+	DefaultPriority *string `json:"default_priority" binding:"omitempty,oneof=low normal high urgent" example:"high"`
+}
+
+// Apply -.
+func (p ProjectPatch) Apply(project *Project) {
+	if p.Name != nil {
+		project.Name = *p.Name
+	}
+	if p.Description != nil {
+		project.Description = *p.Description
+	}
+	if p.Archived != nil {
+		project.Archived = *p.Archived
+	}
+	if p.DefaultStatus != nil {
+		project.DefaultStatus = *p.DefaultStatus
+	}
+	if p.DefaultPriority != nil {
+		project.DefaultPriority = *p.DefaultPriority
+	}
+}
+
+type ProjectRepository interface {
+	GetProjects(ctx context.Context, includeArchived bool) ([]Project, error)
+	GetProjectById(ctx context.Context, id uint) (*Project, error)
+	SaveProject(ctx context.Context, project *Project) error
+	UpdateProject(ctx context.Context, project *Project) error
+}
+
+type ProjectUseCase interface {
+	GetProjects(ctx context.Context, includeArchived bool) ([]Project, error)
+	GetProjectById(ctx context.Context, id uint) (*Project, error)
+	SaveProject(ctx context.Context, project *Project) error
+	UpdateProject(ctx context.Context, id uint, patch ProjectPatch) (*Project, error)
+}
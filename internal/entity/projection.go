@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskFields are the fields of a task a read may be narrowed to, by their name in the fields param.
+var TaskFields = []string{
+	"id", "description", "status", "priority", "due_at", "parent_id", "project_id", "progress", "blocked",
+	"recurrence", "timezone", "occurrence", "series_id", "created_by", "updated_by", "version", "created_at", "updated_at",
+}
+
+// TaskIncludes are the resources related to a task a read may expand.
+var TaskIncludes = []string{"tags", "assignees", "comment_count"}
+
+// Projection is a sparse fieldset of a read of tasks. The zero Projection reads whole tasks with every related
+// resource; once either list is given, only the fields in Fields, or all of them when it is empty, and the
+// resources in Include are read.
+type Projection struct {
+	Fields  []string
+	Include []string
+}
+
+// ParseProjection reads the fields and include params, comma separated names of TaskFields and TaskIncludes.
+func ParseProjection(fields, include string) (Projection, error) {
+	var projection Projection
+	var err error
+	if projection.Fields, err = parseNames(fields, TaskFields); err != nil {
+		return Projection{}, err
+	}
+	if projection.Include, err = parseNames(include, TaskIncludes); err != nil {
+		return Projection{}, err
+	}
+	return projection, nil
+}
+
+func parseNames(value string, allowed []string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if !contains(allowed, name) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownField, name)
+		}
+		if !contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// Sparse reports whether the read is narrowed at all.
+func (p Projection) Sparse() bool {
+	return len(p.Fields) > 0 || len(p.Include) > 0
+}
+
+// Selects reports whether the field is read.
+func (p Projection) Selects(field string) bool {
+	return len(p.Fields) == 0 || contains(p.Fields, field)
+}
+
+// Includes reports whether the related resource is read.
+func (p Projection) Includes(resource string) bool {
+	return !p.Sparse() || contains(p.Include, resource)
+}
@@ -0,0 +1,70 @@
+package entity
+
+// Subtasks limits how tasks nest under one another.
+type Subtasks struct {
+	// MaxDepth is the deepest level a subtask may sit at, top-level tasks being level 0; 0 means no limit.
+	MaxDepth int `json:"max_depth" mapstructure:"max_depth"`
+	// CloseRequiresClosedChildren keeps a parent out of closed statuses while any of its children is open.
+	CloseRequiresClosedChildren bool `json:"close_requires_closed_children" mapstructure:"close_requires_closed_children"`
+}
+
+// TodoNode is a task with its subtasks nested below it.
+type TodoNode struct {
+	Todo
+	Children []TodoNode `json:"children"`
+}
+
+// NewTodoTree nests tasks under the one with rootID by their ParentID and fills in progress from the closed
+// statuses. It returns nil when rootID is not among the tasks.
+func NewTodoTree(tasks []Todo, rootID uint, closed []string) *TodoNode {
+	children := make(map[uint][]Todo, len(tasks))
+	var root *Todo
+	for i := range tasks {
+		if tasks[i].ID == rootID {
+			root = &tasks[i]
+			continue
+		}
+		if tasks[i].ParentID != nil {
+			children[*tasks[i].ParentID] = append(children[*tasks[i].ParentID], tasks[i])
+		}
+	}
+	if root == nil {
+		return nil
+	}
+
+	node, _, _ := buildNode(*root, children, closed)
+	return &node
+}
+
+// buildNode returns the node and the number of its descendants, in total and closed.
+func buildNode(task Todo, children map[uint][]Todo, closed []string) (TodoNode, int, int) {
+	node := TodoNode{Todo: task, Children: []TodoNode{}}
+	var total, done int
+	for _, child := range children[task.ID] {
+		childNode, childTotal, childDone := buildNode(child, children, closed)
+		node.Children = append(node.Children, childNode)
+
+		total += childTotal + 1
+		done += childDone
+		if contains(closed, child.Status) {
+			done++
+		}
+	}
+
+	if total > 0 {
+		progress := done * 100 / total
+		node.Progress = &progress
+	}
+	return node, total, done
+}
+
+// Height returns how many levels of subtasks sit below the node.
+func (n TodoNode) Height() int {
+	height := 0
+	for _, child := range n.Children {
+		if h := child.Height() + 1; h > height {
+			height = h
+		}
+	}
+	return height
+}
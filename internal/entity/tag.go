@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=tag.go -destination=./mocks/tag_mock.go
+
+// Tag labels tasks; names are unique among tags that are not deleted.
+type Tag struct {
+	gorm.Model
+	Name string `json:"name" gorm:"type:varchar(64);not null;uniqueIndex:idx_tags_name,where:deleted_at IS NULL" binding:"required,max=64" example:"bug"`
+}
+
+type TagRepository interface {
+	GetTags(ctx context.Context) ([]Tag, error)
+	GetTagById(ctx context.Context, id uint) (*Tag, error)
+	GetTagsByIds(ctx context.Context, ids []uint) ([]Tag, error)
+	SaveTag(ctx context.Context, tag *Tag) error
+	UpdateTag(ctx context.Context, tag *Tag) error
+	DeleteTag(ctx context.Context, id uint) error
+}
+
+type TagUseCase interface {
+	GetTags(ctx context.Context) ([]Tag, error)
+	GetTagById(ctx context.Context, id uint) (*Tag, error)
+	SaveTag(ctx context.Context, tag *Tag) error
+	UpdateTag(ctx context.Context, tag *Tag) error
+	DeleteTag(ctx context.Context, id uint) error
+}
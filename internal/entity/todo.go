@@ -1,26 +1,192 @@
 package entity
 
 import (
+	"context"
 	"github.com/Vaixle/crud-golang/pkg/httpquery"
 	"gorm.io/gorm"
+	"time"
 )
 
 //go:generate mockgen -source=todo.go -destination=./mocks/mock.go
 
+const (
+	StatusOpen  = "open"
+	StatusClose = "close"
+)
+
+const (
+	PriorityLow    = "low"
+	PriorityNormal = "normal"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// Priorities are ordered from most to least urgent.
+var Priorities = []string{PriorityUrgent, PriorityHigh, PriorityNormal, PriorityLow}
+
 type Todo struct {
 	gorm.Model
-	Description string `json:"description" binding:"required" example:"some text"`
-	Status      string `json:"status" binding:"required,oneof=open close" example:"open/close"`
+	Description string     `json:"description" binding:"required" example:"some text"`
+	Status      string     `json:"status" gorm:"type:varchar(64)" example:"open"`
+	Priority    string     `json:"priority" gorm:"type:varchar(16);not null;default:'normal';index" binding:"omitempty,oneof=low normal high urgent" example:"normal"`
+	DueAt       *time.Time `json:"due_at" gorm:"index" example:"2024-01-01T12:00:00Z"`
+	ParentID    *uint      `json:"parent_id" gorm:"index" example:"1"`
+	ProjectID   *uint      `json:"project_id" gorm:"index" example:"1"`
+	// Progress is the percentage of closed subtasks at every level; nil for a task without subtasks.
+	Progress *int `json:"progress,omitempty" gorm:"-" example:"50"`
+	// Blocked is set while any of the tasks blocking this one is not closed.
+	Blocked bool `json:"blocked" gorm:"-" example:"false"`
+	// CommentCount is the number of comments and replies on the task.
+	CommentCount int `json:"comment_count" gorm:"-" example:"3"`
+	// Recurrence is an RRULE subset counted from DueAt; closing the task creates its next occurrence.
+	Recurrence string `json:"recurrence,omitempty" gorm:"type:varchar(255)" example:"FREQ=MONTHLY;BYMONTHDAY=1"`
+	// Timezone is the IANA zone the recurrence is evaluated in, UTC when empty.
+	Timezone string `json:"timezone,omitempty" gorm:"type:varchar(64)" example:"Europe/Berlin"`
+	// Occurrence is the position of the task in its series, starting at 1.
+	Occurrence int `json:"occurrence,omitempty" example:"1"`
+	// SeriesID is the first task of the series; unset on that task itself.
+	SeriesID *uint `json:"series_id,omitempty" gorm:"index" example:"1"`
+	// CreatedBy and UpdatedBy are the users who created and last changed the task; nil for anonymous changes.
+	CreatedBy *uint `json:"created_by,omitempty" gorm:"index" example:"1"`
+	UpdatedBy *uint `json:"updated_by,omitempty" example:"1"`
+	// Version starts at 1 and is incremented on every write of the task; it is the ETag of the task.
+	Version   uint   `json:"version" gorm:"not null;default:1" example:"1"`
+	Assignees []User `json:"assignees,omitempty" gorm:"many2many:todo_assignees;constraint:OnDelete:CASCADE"`
+	// AssigneeIDs assigns existing users on create; the assigned users are returned in Assignees.
+	AssigneeIDs []uint `json:"assignee_ids,omitempty" gorm:"-" example:"1,2"`
+	Tags        []Tag  `json:"tags,omitempty" gorm:"many2many:todo_tags;constraint:OnDelete:CASCADE"`
+	// TagIDs assigns existing tags on create; the assigned tags are returned in Tags.
+	TagIDs []uint `json:"tag_ids,omitempty" gorm:"-" example:"1,2"`
+}
+
+// AnyVersion stands for whichever version a task is at, for writes that are not conditional.
+const AnyVersion uint = 0
+
+// TodoPatch is a partial update of a task; nil fields are left unchanged.
+type TodoPatch struct {
+	Description *string    `json:"description" binding:"omitempty,min=1" example:"some text"`
+	Status      *string    `json:"status" example:"in_progress"`
+	Priority    *string    `json:"priority" binding:"omitempty,oneof=low normal high urgent" example:"high"`
+	DueAt       *time.Time `json:"due_at" example:"2024-01-01T12:00:00Z"`
+	// ParentID moves the task under another one; 0 makes it a top-level task.
+	ParentID *uint `json:"parent_id" example:"1"`
+	// ProjectID moves the task to another project; 0 takes it out of any project.
+	ProjectID *uint `json:"project_id" example:"1"`
+	// Recurrence replaces the recurrence rule; an empty string stops the task from recurring.
+	Recurrence *string `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO"`
+	Timezone   *string `json:"timezone" example:"Europe/Berlin"`
+	// TagIDs replaces the task tags; an empty list removes them all.
+	TagIDs *[]uint `json:"tag_ids" example:"1,2"`
+	// AssigneeIDs replaces the task assignees; an empty list unassigns everyone.
+	AssigneeIDs *[]uint `json:"assignee_ids" example:"1,2"`
+}
+
+// Apply -.
+func (p TodoPatch) Apply(task *Todo) {
+	if p.Description != nil {
+		task.Description = *p.Description
+	}
+	if p.Status != nil {
+		task.Status = *p.Status
+	}
+	if p.Priority != nil {
+		task.Priority = *p.Priority
+	}
+	if p.DueAt != nil {
+		task.DueAt = p.DueAt
+	}
+	if p.ParentID != nil {
+		task.ParentID = p.ParentID
+		if *p.ParentID == 0 {
+			task.ParentID = nil
+		}
+	}
+	if p.ProjectID != nil {
+		task.ProjectID = p.ProjectID
+		if *p.ProjectID == 0 {
+			task.ProjectID = nil
+		}
+	}
+	if p.Recurrence != nil {
+		task.Recurrence = *p.Recurrence
+	}
+	if p.Timezone != nil {
+		task.Timezone = *p.Timezone
+	}
 }
 
 type TodoRepository interface {
-	GetTaskById(id uint) (*Todo, error)
-	GetTasks(filters []httpquery.FilterOption, pagination httpquery.Pagination) ([]Todo, error)
-	SaveTask(task *Todo) error
+	// Transaction runs fn in a database transaction; repository calls made with the ctx passed to fn join it.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	GetTaskById(ctx context.Context, id uint) (*Todo, error)
+	// GetTasks reads a page of tasks narrowed to the projection.
+	GetTasks(ctx context.Context, filters []httpquery.FilterOption, pagination httpquery.Pagination, projection Projection) ([]Todo, error)
+	// StreamTasks passes every task matching the filters to fn, one batch at a time.
+	StreamTasks(ctx context.Context, filters []httpquery.FilterOption, fn func(tasks []Todo) error) error
+	SaveTask(ctx context.Context, task *Todo) error
+	// SaveTasks inserts the tasks batchSize rows at a time.
+	SaveTasks(ctx context.Context, tasks []*Todo, batchSize int) error
+	// UpdateTask writes the task only if it is still at the version it was read at, and moves it to the next
+	// version; otherwise it fails with ErrVersionMismatch.
+	UpdateTask(ctx context.Context, task *Todo) error
+	// DeleteTask soft-deletes the task if it is at the version; RestoreTask brings a soft-deleted task back.
+	DeleteTask(ctx context.Context, id, version uint) error
+	RestoreTask(ctx context.Context, id uint) error
+	// PurgeTask deletes the task for good if it is at the version, deleted or not, with its comments,
+	// transitions, dependencies and attachments; subtasks left behind become top-level tasks.
+	PurgeTask(ctx context.Context, id, version uint) error
+	ReplaceTags(ctx context.Context, task *Todo, tags []Tag) error
+	ReplaceAssignees(ctx context.Context, task *Todo, users []User) error
+	GetChildren(ctx context.Context, id uint) ([]Todo, error)
+	// GetSubtree returns the task and all of its descendants ordered by id.
+	GetSubtree(ctx context.Context, id uint) ([]Todo, error)
+	// GetAncestorIds returns the id of the task followed by those of its parent, grandparent and so on.
+	GetAncestorIds(ctx context.Context, id uint) ([]uint, error)
+	CountChildren(ctx context.Context, id uint) (int64, error)
+	CountOpenChildren(ctx context.Context, id uint) (int64, error)
+	GetTasksByIds(ctx context.Context, ids []uint) ([]Todo, error)
+	// LockDependencies serializes changes to the dependency graph until the transaction in ctx ends, so that
+	// a cycle check stays true until the dependency it allowed is saved.
+	LockDependencies(ctx context.Context) error
+	SaveDependency(ctx context.Context, dependency *TodoDependency) error
+	DeleteDependency(ctx context.Context, todoID, blockerID uint) error
+	// IsBlockedBy reports whether the task depends on the blocker directly or through other tasks.
+	IsBlockedBy(ctx context.Context, id, blockerID uint) (bool, error)
+	GetBlockers(ctx context.Context, id uint) ([]Todo, error)
+	GetBlocked(ctx context.Context, id uint) ([]Todo, error)
+	// GetDependencies returns the dependencies between the given tasks.
+	GetDependencies(ctx context.Context, ids []uint) ([]TodoDependency, error)
+	SaveTransition(ctx context.Context, transition *TodoTransition) error
+	GetTransitions(ctx context.Context, todoID uint) ([]TodoTransition, error)
+	CountTasksByStatus(ctx context.Context) (map[string]int64, error)
 }
 
 type TodoUseCase interface {
-	GetTaskById(id uint) (*Todo, error)
-	GetTasks(filters []httpquery.FilterOption, pagination httpquery.Pagination) ([]Todo, error)
-	SaveTask(task *Todo) error
+	GetTaskById(ctx context.Context, id uint) (*Todo, error)
+	GetTasks(ctx context.Context, filters []httpquery.FilterOption, pagination httpquery.Pagination, projection Projection) ([]Todo, error)
+	SaveTask(ctx context.Context, task *Todo) error
+	// UpdateTask, DeleteTask and PurgeTask fail with ErrVersionMismatch unless the task is at the version,
+	// which may be AnyVersion.
+	UpdateTask(ctx context.Context, id, version uint, patch TodoPatch) (*Todo, error)
+	DeleteTask(ctx context.Context, id, version uint) error
+	RestoreTask(ctx context.Context, id uint) (*Todo, error)
+	// PurgeTask deletes the task for good, along with its attachments and their content.
+	PurgeTask(ctx context.Context, id, version uint) error
+	// Batch applies the operations in one transaction when atomic, rolling them all back on the first failure,
+	// and one by one otherwise. The results are in the order of the operations.
+	Batch(ctx context.Context, operations []BatchOperation, atomic bool) ([]BatchResult, error)
+	// ExportTasks passes every task matching the filters to fn, one batch at a time, whatever the pagination.
+	ExportTasks(ctx context.Context, filters []httpquery.FilterOption, fn func(tasks []Todo) error) error
+	// ImportTasks creates the tasks, each on its own; with dryRun the tasks are only checked. The results are
+	// in the order of the tasks.
+	ImportTasks(ctx context.Context, tasks []*Todo, dryRun bool) ([]BatchResult, error)
+	GetTransitions(ctx context.Context, id uint) ([]TodoTransition, error)
+	GetChildren(ctx context.Context, id uint) ([]Todo, error)
+	GetTree(ctx context.Context, id uint) (*TodoNode, error)
+	AddBlocker(ctx context.Context, id, blockerID uint) error
+	RemoveBlocker(ctx context.Context, id, blockerID uint) error
+	GetBlockers(ctx context.Context, id uint) ([]Todo, error)
+	GetBlocked(ctx context.Context, id uint) ([]Todo, error)
+	GetTopologicalOrder(ctx context.Context, ids []uint) ([]Todo, error)
+	CountTasksByStatus(ctx context.Context) (map[string]int64, error)
 }
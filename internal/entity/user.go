@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=user.go -destination=./mocks/user_mock.go
+
+// User is an authenticated principal; it is created the first time the auth middleware lets it in.
+type User struct {
+	gorm.Model
+	Username string `json:"username" gorm:"type:varchar(255);not null;uniqueIndex:idx_users_username,where:deleted_at IS NULL" example:"admin"`
+}
+
+type UserRepository interface {
+	GetUsers(ctx context.Context) ([]User, error)
+	GetUserById(ctx context.Context, id uint) (*User, error)
+	GetUsersByIds(ctx context.Context, ids []uint) ([]User, error)
+	// GetOrCreateUser returns the user with the username, creating it when there is none.
+	GetOrCreateUser(ctx context.Context, username string) (*User, error)
+}
+
+type UserUseCase interface {
+	GetUsers(ctx context.Context) ([]User, error)
+	GetUserById(ctx context.Context, id uint) (*User, error)
+	// ResolveUser returns the user behind an authenticated username.
+	ResolveUser(ctx context.Context, username string) (*User, error)
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Workflow lists the task statuses and the transitions allowed between them. Renamed maps statuses of an
+// earlier workflow to the ones that replace them; they are still accepted on input and stored tasks are
+// migrated to the new name.
+type Workflow struct {
+	Initial     string              `json:"initial" mapstructure:"initial"`
+	Statuses    []string            `json:"statuses" mapstructure:"statuses"`
+	Closed      []string            `json:"closed" mapstructure:"closed"`
+	Transitions map[string][]string `json:"transitions" mapstructure:"transitions"`
+	Renamed     map[string]string   `json:"renamed,omitempty" mapstructure:"renamed"`
+}
+
+// DefaultWorkflow is the original open/close pair, used when no workflow is configured.
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		Initial:  StatusOpen,
+		Statuses: []string{StatusOpen, StatusClose},
+		Closed:   []string{StatusClose},
+		Transitions: map[string][]string{
+			StatusOpen:  {StatusClose},
+			StatusClose: {StatusOpen},
+		},
+	}
+}
+
+// Validate checks that every status referenced by the workflow is declared.
+func (w Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("workflow: no statuses")
+	}
+	if !w.IsStatus(w.Initial) {
+		return fmt.Errorf("workflow: initial %w %q", ErrUnknownStatus, w.Initial)
+	}
+	for _, status := range w.Closed {
+		if !w.IsStatus(status) {
+			return fmt.Errorf("workflow: closed %w %q", ErrUnknownStatus, status)
+		}
+	}
+	for from, targets := range w.Transitions {
+		if !w.IsStatus(from) {
+			return fmt.Errorf("workflow: transition from %w %q", ErrUnknownStatus, from)
+		}
+		for _, to := range targets {
+			if !w.IsStatus(to) {
+				return fmt.Errorf("workflow: transition to %w %q", ErrUnknownStatus, to)
+			}
+		}
+	}
+	for from, to := range w.Renamed {
+		if w.IsStatus(from) {
+			return fmt.Errorf("workflow: renamed status %q is still declared", from)
+		}
+		if !w.IsStatus(to) {
+			return fmt.Errorf("workflow: renamed to %w %q", ErrUnknownStatus, to)
+		}
+	}
+	return nil
+}
+
+// IsStatus -.
+func (w Workflow) IsStatus(status string) bool {
+	return contains(w.Statuses, status)
+}
+
+// Canonical returns the status that replaces a renamed one, or the status itself.
+func (w Workflow) Canonical(status string) string {
+	if to, ok := w.Renamed[status]; ok {
+		return to
+	}
+	return status
+}
+
+// IsClosed -.
+func (w Workflow) IsClosed(status string) bool {
+	return contains(w.Closed, status)
+}
+
+// CanTransition reports whether a task may move from one status to another. Staying put is always allowed.
+func (w Workflow) CanTransition(from, to string) bool {
+	if from == to {
+		return w.IsStatus(to)
+	}
+	return contains(w.Transitions[from], to)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// TodoTransition records a status change of a task.
+type TodoTransition struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	TodoID    uint      `json:"todo_id" gorm:"not null;index"`
+	From      string    `json:"from" gorm:"type:varchar(64);not null"`
+	To        string    `json:"to" gorm:"type:varchar(64);not null"`
+	Actor     string    `json:"actor" gorm:"type:varchar(255);not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"gorm.io/gorm"
+)
+
+var _ entity.AttachmentRepository = (*AttachmentRepository)(nil)
+
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) entity.AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+func (a *AttachmentRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, a.db, fn)
+}
+
+func (a *AttachmentRepository) LockDigest(ctx context.Context, digest string) error {
+	return advisoryLock(ctx, a.db, "blob:"+digest)
+}
+
+func (a *AttachmentRepository) GetAttachments(ctx context.Context, todoID uint) ([]entity.Attachment, error) {
+	var attachments []entity.Attachment
+	if err := conn(ctx, a.db).Where("todo_id = ?", todoID).Order("id").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (a *AttachmentRepository) GetAttachmentById(ctx context.Context, id uint) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+	if err := conn(ctx, a.db).First(&attachment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", entity.ErrAttachmentNotFound, id)
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (a *AttachmentRepository) SaveAttachment(ctx context.Context, attachment *entity.Attachment) error {
+	if err := conn(ctx, a.db).Create(attachment).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *AttachmentRepository) DeleteAttachment(ctx context.Context, id uint) error {
+	result := conn(ctx, a.db).Delete(&entity.Attachment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", entity.ErrAttachmentNotFound, id)
+	}
+	return nil
+}
+
+func (a *AttachmentRepository) IsReferenced(ctx context.Context, digest string) (bool, error) {
+	var count int64
+	if err := conn(ctx, a.db).Model(&entity.Attachment{}).Where("sha256 = ?", digest).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAttachmentRepository_DeleteAttachment(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewAttachmentRepository(db)
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "attachments" WHERE "attachments"."id" = (.+)`).
+					WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "NOT FOUND",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "attachments" (.+)`).
+					WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: entity.ErrAttachmentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			err := repo.DeleteAttachment(context.Background(), 5)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAttachmentRepository_IsReferenced(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewAttachmentRepository(db)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "attachments" WHERE sha256 = (.+)`).
+		WithArgs("abc").WillReturnRows(mock.NewRows([]string{"count"}).AddRow(2))
+
+	referenced, err := repo.IsReferenced(context.Background(), "abc")
+	assert.Nil(t, mock.ExpectationsWereMet())
+
+	assert.NoError(t, err)
+	assert.True(t, referenced)
+}
+
+func TestAttachmentRepository_LockDigest(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewAttachmentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\((.+)\)\)`).
+		WithArgs("blob:abc").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.Transaction(context.Background(), func(ctx context.Context) error {
+		return repo.LockDigest(ctx, "abc")
+	})
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
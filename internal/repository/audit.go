@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"gorm.io/gorm"
+)
+
+var _ entity.AuditRepository = (*AuditRepository)(nil)
+
+// AuditRepository only ever inserts entries; nothing updates or deletes them.
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) entity.AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (a *AuditRepository) SaveAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	if err := conn(ctx, a.db).Create(entry).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *AuditRepository) SaveAuditEntries(ctx context.Context, entries []entity.AuditEntry, batchSize int) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := conn(ctx, a.db).CreateInBatches(entries, batchSize).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *AuditRepository) GetTaskHistory(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]entity.AuditEntry, error) {
+	var entries []entity.AuditEntry
+	err := conn(ctx, a.db).
+		Where("todo_id = ?", todoID).
+		Order("id").
+		Offset(pagination.GetOffset()).Limit(pagination.Limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (a *AuditRepository) GetAuditEntries(ctx context.Context, filter entity.AuditFilter, pagination httpquery.Pagination) ([]entity.AuditEntry, error) {
+	query := conn(ctx, a.db).Model(&entity.AuditEntry{})
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var entries []entity.AuditEntry
+	if err := query.Order("id DESC").Offset(pagination.GetOffset()).Limit(pagination.Limit).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
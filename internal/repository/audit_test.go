@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAuditRepository_GetAuditEntries(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewAuditRepository(db)
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		filter       entity.AuditFilter
+		mockBehavior func()
+	}{
+		{
+			name:   "NO FILTER",
+			filter: entity.AuditFilter{},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT \* FROM "audit_entries" ORDER BY id DESC LIMIT 10`).
+					WillReturnRows(mock.NewRows([]string{"id", "todo_id", "operation", "actor", "changes"}).
+						AddRow(2, 1, "update", "admin", `[{"field":"status","old":"open","new":"done"}]`).
+						AddRow(1, 1, "create", "admin", `[]`))
+			},
+		},
+		{
+			name:   "ACTOR AND TIME RANGE",
+			filter: entity.AuditFilter{Actor: "admin", From: &from, To: &to},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT \* FROM "audit_entries" WHERE actor = \$1 AND created_at >= \$2 AND created_at < \$3 ORDER BY id DESC LIMIT 10`).
+					WithArgs("admin", from, to).
+					WillReturnRows(mock.NewRows([]string{"id", "todo_id", "operation", "actor", "changes"}).
+						AddRow(2, 1, "update", "admin", `[{"field":"status","old":"open","new":"done"}]`).
+						AddRow(1, 1, "create", "admin", `[]`))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			entries, err := repo.GetAuditEntries(context.Background(), testCase.filter, httpquery.Pagination{Limit: 10})
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			assert.NoError(t, err)
+			assert.Len(t, entries, 2)
+			assert.Equal(t, entity.FieldChanges{{Field: "status", Old: []byte(`"open"`), New: []byte(`"done"`)}}, entries[0].Changes)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"gorm.io/gorm"
+)
+
+var _ entity.CommentRepository = (*CommentRepository)(nil)
+
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) entity.CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+func (c *CommentRepository) GetComments(ctx context.Context, todoID uint, pagination httpquery.Pagination) ([]entity.Comment, error) {
+	var comments []entity.Comment
+	err := conn(ctx, c.db).
+		Preload("Author").
+		Preload("Replies", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Replies.Author").
+		Where("todo_id = ? AND parent_id IS NULL", todoID).
+		Order("id").
+		Offset(pagination.GetOffset()).Limit(pagination.Limit).
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (c *CommentRepository) GetCommentById(ctx context.Context, id uint) (*entity.Comment, error) {
+	var comment entity.Comment
+	if err := conn(ctx, c.db).Preload("Author").First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", entity.ErrCommentNotFound, id)
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (c *CommentRepository) SaveComment(ctx context.Context, comment *entity.Comment) error {
+	// The author already exists, only the comment is written.
+	if err := conn(ctx, c.db).Omit("Author", "Replies").Create(comment).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CommentRepository) UpdateComment(ctx context.Context, comment *entity.Comment) error {
+	result := conn(ctx, c.db).Model(comment).Update("body", comment.Body)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", entity.ErrCommentNotFound, comment.ID)
+	}
+	return nil
+}
+
+func (c *CommentRepository) DeleteComment(ctx context.Context, id uint) error {
+	result := conn(ctx, c.db).Where("id = ? OR parent_id = ?", id, id).Delete(&entity.Comment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", entity.ErrCommentNotFound, id)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestCommentRepository_GetComments(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewCommentRepository(db)
+
+	mock.ExpectQuery(`SELECT (.+) FROM "comments" WHERE \(todo_id = (.+) AND parent_id IS NULL\) AND "comments"."deleted_at" IS NULL ORDER BY id LIMIT (.+) OFFSET (.+)`).
+		WithArgs(1).WillReturnRows(mock.NewRows([]string{"id", "todo_id", "author_id", "body"}).AddRow(10, 1, 7, "Looks good"))
+	mock.ExpectQuery(`SELECT (.+) FROM "users" WHERE "users"."id" = (.+) AND "users"."deleted_at" IS NULL`).
+		WithArgs(7).WillReturnRows(mock.NewRows([]string{"id", "username"}).AddRow(7, "admin"))
+	mock.ExpectQuery(`SELECT (.+) FROM "comments" WHERE "comments"."parent_id" = (.+) AND "comments"."deleted_at" IS NULL ORDER BY id`).
+		WithArgs(10).WillReturnRows(mock.NewRows([]string{"id", "todo_id", "author_id", "body", "parent_id"}).AddRow(11, 1, 8, "Agreed", 10))
+	mock.ExpectQuery(`SELECT (.+) FROM "users" WHERE "users"."id" = (.+) AND "users"."deleted_at" IS NULL`).
+		WithArgs(8).WillReturnRows(mock.NewRows([]string{"id", "username"}).AddRow(8, "bob"))
+
+	comments, err := repo.GetComments(context.Background(), 1, httpquery.Pagination{Limit: 20, Page: 1})
+	assert.Nil(t, mock.ExpectationsWereMet())
+
+	parentID := uint(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Comment{{
+		Model:    gorm.Model{ID: 10},
+		TodoID:   1,
+		AuthorID: 7,
+		Author:   &entity.User{Model: gorm.Model{ID: 7}, Username: "admin"},
+		Body:     "Looks good",
+		Replies: []entity.Comment{{
+			Model:    gorm.Model{ID: 11},
+			TodoID:   1,
+			AuthorID: 8,
+			Author:   &entity.User{Model: gorm.Model{ID: 8}, Username: "bob"},
+			Body:     "Agreed",
+			ParentID: &parentID,
+		}},
+	}}, comments)
+}
+
+func TestCommentRepository_DeleteComment(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewCommentRepository(db)
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "WITH REPLIES",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "comments" SET "deleted_at"=(.+) WHERE \(id = (.+) OR parent_id = (.+)\) AND "comments"."deleted_at" IS NULL`).
+					WithArgs(sqlmock.AnyArg(), 10, 10).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "NOT FOUND",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "comments" SET (.+)`).
+					WithArgs(sqlmock.AnyArg(), 10, 10).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: entity.ErrCommentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			err := repo.DeleteComment(context.Background(), 10)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
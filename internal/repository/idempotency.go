@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var _ entity.IdempotencyRepository = (*IdempotencyRepository)(nil)
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) entity.IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// SaveIdempotencyKey leaves it to the unique index on actor and key to pick one of concurrent requests.
+func (i *IdempotencyRepository) SaveIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	result := conn(ctx, i.db).Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (i *IdempotencyRepository) GetIdempotencyKey(ctx context.Context, actor, key string) (*entity.IdempotencyKey, error) {
+	var stored entity.IdempotencyKey
+	err := conn(ctx, i.db).Where("actor = ? AND key = ?", actor, key).First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %q", entity.ErrIdempotencyKeyNotFound, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+func (i *IdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) error {
+	err := conn(ctx, i.db).Model(key).Select("status_code", "headers", "response").Updates(key).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *IdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, id uint) error {
+	if err := conn(ctx, i.db).Delete(&entity.IdempotencyKey{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// TakeOverIdempotencyKey checks the key again as it deletes it, so that a key completed or taken over since it
+// was read is kept.
+func (i *IdempotencyRepository) TakeOverIdempotencyKey(ctx context.Context, id uint, now time.Time) (bool, error) {
+	result := conn(ctx, i.db).
+		Where("id = ? AND (expires_at <= ? OR (status_code = 0 AND (locked_until IS NULL OR locked_until <= ?)))", id, now, now).
+		Delete(&entity.IdempotencyKey{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (i *IdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	result := conn(ctx, i.db).Where("expires_at <= ?", now).Delete(&entity.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
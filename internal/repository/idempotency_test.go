@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyRepository_SaveIdempotencyKey(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewIdempotencyRepository(db)
+
+	now := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(time.Minute)
+
+	testTable := []struct {
+		name            string
+		mockBehavior    func()
+		expectedCreated bool
+	}{
+		{
+			name: "NEW KEY",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "idempotency_keys" (.+) ON CONFLICT DO NOTHING RETURNING "id"`).
+					WithArgs("admin", "a1", "abc", 0, nil, []byte(nil), now, now.Add(time.Hour), now.Add(time.Minute)).
+					WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			expectedCreated: true,
+		},
+		{
+			name: "TAKEN KEY",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "idempotency_keys" (.+) ON CONFLICT DO NOTHING RETURNING "id"`).
+					WithArgs("admin", "a1", "abc", 0, nil, []byte(nil), now, now.Add(time.Hour), now.Add(time.Minute)).
+					WillReturnRows(mock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			created, err := repo.SaveIdempotencyKey(context.Background(), &entity.IdempotencyKey{
+				Actor: "admin", Key: "a1", RequestHash: "abc", CreatedAt: now, ExpiresAt: now.Add(time.Hour), LockedUntil: &lockedUntil,
+			})
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedCreated, created)
+		})
+	}
+}
+
+func TestIdempotencyRepository_TakeOverIdempotencyKey(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewIdempotencyRepository(db)
+
+	now := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name          string
+		rowsAffected  int64
+		expectedTaken bool
+	}{
+		{
+			name:          "TAKEN",
+			rowsAffected:  1,
+			expectedTaken: true,
+		},
+		{
+			// The key was completed or taken over by another retry since it was read.
+			name:         "KEPT",
+			rowsAffected: 0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			mock.ExpectExec(`DELETE FROM "idempotency_keys" WHERE id = \$1 AND \(expires_at <= \$2 OR \(status_code = 0 AND \(locked_until IS NULL OR locked_until <= \$3\)\)\)`).
+				WithArgs(1, now, now).WillReturnResult(sqlmock.NewResult(0, testCase.rowsAffected))
+			mock.ExpectCommit()
+
+			taken, err := repo.TakeOverIdempotencyKey(context.Background(), 1, now)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedTaken, taken)
+		})
+	}
+}
+
+func TestIdempotencyRepository_DeleteExpiredIdempotencyKeys(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewIdempotencyRepository(db)
+
+	now := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "idempotency_keys" WHERE expires_at <= (.+)`).
+		WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	purged, err := repo.DeleteExpiredIdempotencyKeys(context.Background(), now)
+	assert.Nil(t, mock.ExpectationsWereMet())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+}
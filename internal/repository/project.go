@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"gorm.io/gorm"
+)
+
+var _ entity.ProjectRepository = (*ProjectRepository)(nil)
+
+type ProjectRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) entity.ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+func (p *ProjectRepository) GetProjects(ctx context.Context, includeArchived bool) ([]entity.Project, error) {
+	query := conn(ctx, p.db).Order("name")
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+
+	var projects []entity.Project
+	if err := query.Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (p *ProjectRepository) GetProjectById(ctx context.Context, id uint) (*entity.Project, error) {
+	var project entity.Project
+	if err := conn(ctx, p.db).First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", entity.ErrProjectNotFound, id)
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (p *ProjectRepository) SaveProject(ctx context.Context, project *entity.Project) error {
+	if err := conn(ctx, p.db).Create(project).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *ProjectRepository) UpdateProject(ctx context.Context, project *entity.Project) error {
+	// Select writes zero values too, so a project can be unarchived or lose its defaults.
+	result := conn(ctx, p.db).Model(project).
+		Select("name", "description", "archived", "default_status", "default_priority").
+		Updates(project)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", entity.ErrProjectNotFound, project.ID)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestProjectRepository_GetProjects(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewProjectRepository(db)
+
+	testTable := []struct {
+		name            string
+		includeArchived bool
+		mockBehavior    func()
+		expected        []entity.Project
+	}{
+		{
+			name: "ACTIVE",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "projects" WHERE archived = (.+) AND "projects"."deleted_at" IS NULL ORDER BY name`).
+					WithArgs(false).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, "backend"))
+			},
+			expected: []entity.Project{{Model: gorm.Model{ID: 1}, Name: "backend"}},
+		},
+		{
+			name:            "INCLUDE ARCHIVED",
+			includeArchived: true,
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "projects" WHERE "projects"."deleted_at" IS NULL ORDER BY name`).
+					WillReturnRows(mock.NewRows([]string{"id", "name", "archived"}).AddRow(1, "backend", false).AddRow(2, "legacy", true))
+			},
+			expected: []entity.Project{{Model: gorm.Model{ID: 1}, Name: "backend"}, {Model: gorm.Model{ID: 2}, Name: "legacy", Archived: true}},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			projects, err := repo.GetProjects(context.Background(), testCase.includeArchived)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, projects)
+		})
+	}
+}
+
+func TestProjectRepository_UpdateProject(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewProjectRepository(db)
+
+	testTable := []struct {
+		name         string
+		inputEntity  *entity.Project
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name:        "UNARCHIVE",
+			inputEntity: &entity.Project{Model: gorm.Model{ID: 1}, Name: "backend"},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "projects" SET "updated_at"=(.+),"name"=(.+),"description"=(.+),"archived"=(.+),"default_status"=(.+),"default_priority"=(.+) WHERE "projects"."deleted_at" IS NULL AND "id" = (.+)`).
+					WithArgs(sqlmock.AnyArg(), "backend", "", false, "", "", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "NOT FOUND",
+			inputEntity: &entity.Project{Model: gorm.Model{ID: 2}, Name: "backend"},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "projects" SET (.+)`).
+					WithArgs(sqlmock.AnyArg(), "backend", "", false, "", "", 2).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: entity.ErrProjectNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			err := repo.UpdateProject(context.Background(), testCase.inputEntity)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"gorm.io/gorm"
+)
+
+var _ entity.TagRepository = (*TagRepository)(nil)
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) entity.TagRepository {
+	return &TagRepository{db: db}
+}
+
+func (t *TagRepository) GetTags(ctx context.Context) ([]entity.Tag, error) {
+	var tags []entity.Tag
+	if err := conn(ctx, t.db).Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (t *TagRepository) GetTagById(ctx context.Context, id uint) (*entity.Tag, error) {
+	var tag entity.Tag
+	if err := conn(ctx, t.db).First(&tag, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", entity.ErrTagNotFound, id)
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (t *TagRepository) GetTagsByIds(ctx context.Context, ids []uint) ([]entity.Tag, error) {
+	var tags []entity.Tag
+	if err := conn(ctx, t.db).Where("id IN ?", ids).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (t *TagRepository) SaveTag(ctx context.Context, tag *entity.Tag) error {
+	if err := conn(ctx, t.db).Create(tag).Error; err != nil {
+		return tagError(err, tag.Name)
+	}
+	return nil
+}
+
+func (t *TagRepository) UpdateTag(ctx context.Context, tag *entity.Tag) error {
+	result := conn(ctx, t.db).Model(tag).Update("name", tag.Name)
+	if result.Error != nil {
+		return tagError(result.Error, tag.Name)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", entity.ErrTagNotFound, tag.ID)
+	}
+	return nil
+}
+
+// DeleteTag soft deletes the tag and takes it off every task in the same transaction, so that it no longer
+// counts toward tag filters. The tasks it is taken off get a new version.
+func (t *TagRepository) DeleteTag(ctx context.Context, id uint) error {
+	return transaction(ctx, t.db, func(ctx context.Context) error {
+		db := conn(ctx, t.db).Session(&gorm.Session{})
+
+		result := db.Delete(&entity.Tag{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: %d", entity.ErrTagNotFound, id)
+		}
+
+		err := db.Model(&entity.Todo{}).Where("id IN (SELECT todo_id FROM todo_tags WHERE tag_id = ?)", id).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error
+		if err != nil {
+			return err
+		}
+		return db.Exec("DELETE FROM todo_tags WHERE tag_id = ?", id).Error
+	})
+}
+
+// tagError reports a unique name violation as entity.ErrTagExists.
+func tagError(err error, name string) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%w: %q", entity.ErrTagExists, name)
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestTagRepository_UpdateTag(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTagRepository(db)
+
+	testTable := []struct {
+		name         string
+		inputEntity  *entity.Tag
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name:        "OK",
+			inputEntity: &entity.Tag{Model: gorm.Model{ID: 1}, Name: "bug"},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "tags" SET "name"=(.+),"updated_at"=(.+) WHERE "tags"."deleted_at" IS NULL AND "id" = (.+)`).
+					WithArgs("bug", sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "NOT FOUND",
+			inputEntity: &entity.Tag{Model: gorm.Model{ID: 2}, Name: "bug"},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "tags" SET (.+)`).
+					WithArgs("bug", sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: entity.ErrTagNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			err := repo.UpdateTag(context.Background(), testCase.inputEntity)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTagRepository_DeleteTag(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTagRepository(db)
+
+	testTable := []struct {
+		name         string
+		inputId      uint
+		mockBehavior func(id uint)
+		wantErr      error
+	}{
+		{
+			name:    "OK",
+			inputId: 1,
+			mockBehavior: func(id uint) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "tags" SET "deleted_at"=(.+) WHERE "tags"."id" = (.+) AND "tags"."deleted_at" IS NULL`).
+					WithArgs(sqlmock.AnyArg(), id).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE "todos" SET "version"=version \+ 1 WHERE id IN \(SELECT todo_id FROM todo_tags WHERE tag_id = (.+)\)`).
+					WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`DELETE FROM todo_tags WHERE tag_id = (.+)`).
+					WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "NOT FOUND",
+			inputId: 2,
+			mockBehavior: func(id uint) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "tags" SET "deleted_at"=(.+)`).
+					WithArgs(sqlmock.AnyArg(), id).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrTagNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.inputId)
+
+			err := repo.DeleteTag(context.Background(), testCase.inputId)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,36 +1,124 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/Vaixle/crud-golang/internal/entity"
 	"github.com/Vaixle/crud-golang/pkg/httpquery"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var _ entity.TodoRepository = (*TodoRepository)(nil)
 
+// _streamBatchSize is the number of tasks StreamTasks reads per query.
+const _streamBatchSize = 500
+
 type TodoRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	workflow entity.Workflow
+}
+
+func NewTodoRepository(db *gorm.DB, workflow entity.Workflow) entity.TodoRepository {
+	return &TodoRepository{db: db, workflow: workflow}
 }
 
-func NewTodoRepository(db *gorm.DB) entity.TodoRepository {
-	return &TodoRepository{db: db}
+func (t *TodoRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, t.db, fn)
 }
 
-func (t *TodoRepository) GetTaskById(id uint) (*entity.Todo, error) {
+func (t *TodoRepository) GetTaskById(ctx context.Context, id uint) (*entity.Todo, error) {
 	var todoTask entity.Todo
-	if err := t.db.First(&todoTask, id).Error; err != nil {
+	if err := preload(conn(ctx, t.db)).First(&todoTask, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", entity.ErrTaskNotFound, id)
+		}
+		return nil, err
+	}
+
+	tasks := []entity.Todo{todoTask}
+	if err := t.setComputed(ctx, tasks); err != nil {
 		return nil, err
 	}
-	return &todoTask, nil
+	return &tasks[0], nil
 }
 
-func (t *TodoRepository) GetTasks(filters []httpquery.FilterOption, pagination httpquery.Pagination) ([]entity.Todo, error) {
+// GetTasks reads a page of tasks, selecting only the columns and associations the projection reads.
+func (t *TodoRepository) GetTasks(ctx context.Context, filters []httpquery.FilterOption, pagination httpquery.Pagination, projection entity.Projection) ([]entity.Todo, error) {
 	var todoTasks []entity.Todo
 
-	query := t.db.Model(&entity.Todo{})
+	// Associations of the whole page are loaded with one query each rather than one per task.
+	query, err := t.filtered(projected(conn(ctx, t.db).Model(&entity.Todo{}), projection), filters)
+	if err != nil {
+		return nil, err
+	}
+
+	query.Offset(pagination.GetOffset()).Limit(pagination.Limit)
+
+	if err := query.Find(&todoTasks).Error; err != nil {
+		return nil, err
+	}
+
+	if err := t.setProjected(ctx, todoTasks, projection); err != nil {
+		return nil, err
+	}
+
+	return todoTasks, nil
+}
+
+// StreamTasks walks the whole tasks in id order, _streamBatchSize at a time, so that only one batch is held in
+// memory; sort and order_by filters are ignored.
+func (t *TodoRepository) StreamTasks(ctx context.Context, filters []httpquery.FilterOption, fn func(tasks []entity.Todo) error) error {
+	unordered := make([]httpquery.FilterOption, 0, len(filters))
+	for _, filter := range filters {
+		if filter.Field != "sort" && filter.Operator != "order_by" {
+			unordered = append(unordered, filter)
+		}
+	}
+
+	query, err := t.filtered(preload(conn(ctx, t.db).Model(&entity.Todo{})), unordered)
+	if err != nil {
+		return err
+	}
+
+	var tasks []entity.Todo
+	return query.FindInBatches(&tasks, _streamBatchSize, func(*gorm.DB, int) error {
+		if err := t.setComputed(ctx, tasks); err != nil {
+			return err
+		}
+		return fn(tasks)
+	}).Error
+}
 
+// filtered narrows a query of tasks down to the list filters.
+func (t *TodoRepository) filtered(query *gorm.DB, filters []httpquery.FilterOption) (*gorm.DB, error) {
+	includeArchived := false
 	for _, filter := range filters {
+		if filter.Field == "include_archived" {
+			var err error
+			if includeArchived, err = strconv.ParseBool(filter.Value); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if apply, ok := t.queryParam(filter.Field); ok {
+			var err error
+			if query, err = apply(query, filter.Value); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Fields and sort directions are written into the SQL, so only known ones get there.
+		if _, ok := _projectedColumns[filter.Field]; !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", entity.ErrInvalidFilter, filter.Field)
+		}
 		switch filter.Operator {
 		case "gt":
 			query = query.Where(fmt.Sprintf("%s > ?", filter.Field), filter.Value)
@@ -42,27 +130,494 @@ func (t *TodoRepository) GetTasks(filters []httpquery.FilterOption, pagination h
 			query = query.Where(fmt.Sprintf("%s <= ?", filter.Field), filter.Value)
 		case "eq":
 			query = query.Where(fmt.Sprintf("%s = ?", filter.Field), filter.Value)
+		case "ne":
+			query = query.Where(fmt.Sprintf("%s <> ?", filter.Field), filter.Value)
 		case "like":
 			query = query.Where(fmt.Sprintf("%s LIKE ?", filter.Field), "%"+filter.Value+"%")
 		case "order_by":
-			query = query.Order(fmt.Sprintf("%s %s", filter.Field, filter.Value))
+			direction := strings.ToUpper(filter.Value)
+			if direction != "ASC" && direction != "DESC" {
+				return nil, fmt.Errorf("%w: order_by must be asc or desc, not %q", entity.ErrInvalidFilter, filter.Value)
+			}
+			query = query.Order(filter.Field + " " + direction)
 		default:
 			query = query.Where(fmt.Sprintf("%s = ?", filter.Field), filter.Value)
 		}
 	}
 
-	query.Offset(pagination.GetOffset()).Limit(pagination.Limit)
+	// Tasks of archived projects stay out of the list unless asked for.
+	if !includeArchived {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM projects WHERE projects.id = todos.project_id AND projects.archived)")
+	}
+	return query, nil
+}
 
-	if err := query.Find(&todoTasks).Error; err != nil {
+func (t *TodoRepository) SaveTask(ctx context.Context, task *entity.Todo) error {
+	task.Version = 1
+	// Tags and assignees already exist, only the join rows are written.
+	if err := conn(ctx, t.db).Omit("Tags.*", "Assignees.*").Create(task).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TodoRepository) SaveTasks(ctx context.Context, tasks []*entity.Todo, batchSize int) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	for _, task := range tasks {
+		task.Version = 1
+	}
+	if err := conn(ctx, t.db).Omit("Tags.*", "Assignees.*").CreateInBatches(tasks, batchSize).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TodoRepository) UpdateTask(ctx context.Context, task *entity.Todo) error {
+	version := task.Version
+	task.Version++
+	result := conn(ctx, t.db).Model(task).Where("version = ?", version).
+		Select("*").Omit("ID", "CreatedAt", "DeletedAt", "Tags", "Assignees").
+		Updates(task)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = missing(conn(ctx, t.db), task.ID)
+	}
+	if result.Error != nil {
+		task.Version = version
+		return result.Error
+	}
+	return nil
+}
+
+func (t *TodoRepository) DeleteTask(ctx context.Context, id, version uint) error {
+	query := conn(ctx, t.db).Model(&entity.Todo{}).Where("id = ?", id)
+	if version != entity.AnyVersion {
+		query = query.Where("version = ?", version)
+	}
+	result := query.UpdateColumns(map[string]interface{}{
+		"deleted_at": time.Now(),
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return missing(conn(ctx, t.db), id)
+	}
+	return nil
+}
+
+func (t *TodoRepository) RestoreTask(ctx context.Context, id uint) error {
+	result := conn(ctx, t.db).Unscoped().Model(&entity.Todo{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		UpdateColumns(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", entity.ErrTaskNotFound, id)
+	}
+	return nil
+}
+
+// missing tells why a conditional write of the task matched no row: the task is gone, or it is at another version.
+func missing(db *gorm.DB, id uint) error {
+	var count int64
+	if err := db.Model(&entity.Todo{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %d", entity.ErrTaskNotFound, id)
+	}
+	return fmt.Errorf("%w: %d", entity.ErrVersionMismatch, id)
+}
+
+func (t *TodoRepository) PurgeTask(ctx context.Context, id, version uint) error {
+	return transaction(ctx, t.db, func(ctx context.Context) error {
+		// A new session keeps the conditions of each statement from leaking into the next.
+		db := conn(ctx, t.db).Unscoped().Session(&gorm.Session{})
+
+		// Tag and assignee rows go with the task through their foreign keys.
+		query := db
+		if version != entity.AnyVersion {
+			query = db.Where("version = ?", version)
+		}
+		result := query.Delete(&entity.Todo{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return missing(db, id)
+		}
+
+		if err := db.Where("todo_id = ?", id).Delete(&entity.Comment{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("todo_id = ?", id).Delete(&entity.TodoTransition{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("todo_id = ? OR blocker_id = ?", id, id).Delete(&entity.TodoDependency{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("todo_id = ?", id).Delete(&entity.Attachment{}).Error; err != nil {
+			return err
+		}
+		return db.Model(&entity.Todo{}).Where("parent_id = ?", id).UpdateColumns(map[string]interface{}{
+			"parent_id": nil,
+			"version":   gorm.Expr("version + 1"),
+		}).Error
+	})
+}
+
+func (t *TodoRepository) ReplaceTags(ctx context.Context, task *entity.Todo, tags []entity.Tag) error {
+	if err := conn(ctx, t.db).Model(task).Omit("Tags.*").Association("Tags").Replace(tags); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TodoRepository) ReplaceAssignees(ctx context.Context, task *entity.Todo, users []entity.User) error {
+	if err := conn(ctx, t.db).Model(task).Omit("Assignees.*").Association("Assignees").Replace(users); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TodoRepository) GetChildren(ctx context.Context, id uint) ([]entity.Todo, error) {
+	var children []entity.Todo
+	if err := preload(conn(ctx, t.db)).Where("parent_id = ?", id).Order("id").Find(&children).Error; err != nil {
 		return nil, err
 	}
 
-	return todoTasks, nil
+	if err := t.setComputed(ctx, children); err != nil {
+		return nil, err
+	}
+	return children, nil
+}
+
+func (t *TodoRepository) GetSubtree(ctx context.Context, id uint) ([]entity.Todo, error) {
+	var tasks []entity.Todo
+	err := preload(conn(ctx, t.db)).
+		Where("id IN (?)", gorm.Expr(`WITH RECURSIVE subtree AS (
+			SELECT id FROM todos WHERE id = ? AND deleted_at IS NULL
+			UNION
+			SELECT todos.id FROM todos JOIN subtree ON todos.parent_id = subtree.id WHERE todos.deleted_at IS NULL
+		) SELECT id FROM subtree`, id)).
+		Order("id").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (t *TodoRepository) GetAncestorIds(ctx context.Context, id uint) ([]uint, error) {
+	var rows []struct {
+		ID    uint
+		Depth int
+	}
+	err := conn(ctx, t.db).Raw(`WITH RECURSIVE chain AS (
+			SELECT id, parent_id, 0 AS depth FROM todos WHERE id = ? AND deleted_at IS NULL
+			UNION
+			SELECT todos.id, todos.parent_id, chain.depth + 1 FROM todos JOIN chain ON todos.id = chain.parent_id WHERE todos.deleted_at IS NULL
+		) SELECT id, depth FROM chain ORDER BY depth`, id).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids, nil
+}
+
+func (t *TodoRepository) CountChildren(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	if err := conn(ctx, t.db).Model(&entity.Todo{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
 }
 
-func (t *TodoRepository) SaveTask(task *entity.Todo) error {
-	if err := t.db.Create(task).Error; err != nil {
+func (t *TodoRepository) CountOpenChildren(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := conn(ctx, t.db).Model(&entity.Todo{}).
+		Where("parent_id = ? AND status NOT IN ?", id, t.workflow.Closed).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (t *TodoRepository) GetTasksByIds(ctx context.Context, ids []uint) ([]entity.Todo, error) {
+	var tasks []entity.Todo
+	if err := preload(conn(ctx, t.db)).Where("id IN ?", ids).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	if err := t.setComputed(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (t *TodoRepository) LockDependencies(ctx context.Context) error {
+	return advisoryLock(ctx, t.db, "todo_dependencies")
+}
+
+func (t *TodoRepository) SaveDependency(ctx context.Context, dependency *entity.TodoDependency) error {
+	if err := conn(ctx, t.db).Clauses(clause.OnConflict{DoNothing: true}).Create(dependency).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TodoRepository) DeleteDependency(ctx context.Context, todoID, blockerID uint) error {
+	result := conn(ctx, t.db).Where("todo_id = ? AND blocker_id = ?", todoID, blockerID).Delete(&entity.TodoDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: task %d is not blocked by task %d", entity.ErrNoDependency, todoID, blockerID)
+	}
+	return nil
+}
+
+func (t *TodoRepository) IsBlockedBy(ctx context.Context, id, blockerID uint) (bool, error) {
+	var blocked bool
+	err := conn(ctx, t.db).Raw(`WITH RECURSIVE blockers AS (
+			SELECT blocker_id FROM todo_dependencies WHERE todo_id = ?
+			UNION
+			SELECT todo_dependencies.blocker_id FROM todo_dependencies JOIN blockers ON todo_dependencies.todo_id = blockers.blocker_id
+		) SELECT EXISTS (SELECT 1 FROM blockers WHERE blocker_id = ?)`, id, blockerID).
+		Scan(&blocked).Error
+	if err != nil {
+		return false, err
+	}
+	return blocked, nil
+}
+
+func (t *TodoRepository) GetBlockers(ctx context.Context, id uint) ([]entity.Todo, error) {
+	return t.getDependent(ctx, "id IN (SELECT blocker_id FROM todo_dependencies WHERE todo_id = ?)", id)
+}
+
+func (t *TodoRepository) GetBlocked(ctx context.Context, id uint) ([]entity.Todo, error) {
+	return t.getDependent(ctx, "id IN (SELECT todo_id FROM todo_dependencies WHERE blocker_id = ?)", id)
+}
+
+func (t *TodoRepository) getDependent(ctx context.Context, query string, id uint) ([]entity.Todo, error) {
+	var tasks []entity.Todo
+	if err := preload(conn(ctx, t.db)).Where(query, id).Order("id").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	if err := t.setComputed(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (t *TodoRepository) GetDependencies(ctx context.Context, ids []uint) ([]entity.TodoDependency, error) {
+	var dependencies []entity.TodoDependency
+	err := conn(ctx, t.db).Where("todo_id IN ? AND blocker_id IN ?", ids, ids).Find(&dependencies).Error
+	if err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// setComputed fills in the fields derived from other tasks and from comments.
+func (t *TodoRepository) setComputed(ctx context.Context, tasks []entity.Todo) error {
+	return t.setProjected(ctx, tasks, entity.Projection{})
+}
+
+// setProjected fills in the derived fields and the comment count only when the projection reads them.
+func (t *TodoRepository) setProjected(ctx context.Context, tasks []entity.Todo, projection entity.Projection) error {
+	if projection.Selects("progress") {
+		if err := t.setProgress(ctx, tasks); err != nil {
+			return err
+		}
+	}
+	if projection.Selects("blocked") {
+		if err := t.setBlocked(ctx, tasks); err != nil {
+			return err
+		}
+	}
+	if projection.Includes("comment_count") {
+		return t.setCommentCount(ctx, tasks)
+	}
+	return nil
+}
+
+// setCommentCount fills in CommentCount, with one query for all the tasks.
+func (t *TodoRepository) setCommentCount(ctx context.Context, tasks []entity.Todo) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	var rows []struct {
+		TodoID uint
+		Count  int
+	}
+	err := conn(ctx, t.db).Model(&entity.Comment{}).
+		Select("todo_id, COUNT(*) AS count").
+		Where("todo_id IN ?", taskIds(tasks)).
+		Group("todo_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.TodoID] = row.Count
+	}
+	for i := range tasks {
+		tasks[i].CommentCount = counts[tasks[i].ID]
+	}
+	return nil
+}
+
+// setBlocked marks the tasks with a blocker that is not closed, with one query for all of them.
+func (t *TodoRepository) setBlocked(ctx context.Context, tasks []entity.Todo) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	var blocked []uint
+	err := conn(ctx, t.db).Table("todo_dependencies").
+		Select("DISTINCT todo_dependencies.todo_id").
+		Joins("JOIN todos blockers ON blockers.id = todo_dependencies.blocker_id AND blockers.deleted_at IS NULL").
+		Where("todo_dependencies.todo_id IN ? AND blockers.status NOT IN ?", taskIds(tasks), t.workflow.Closed).
+		Scan(&blocked).Error
+	if err != nil {
+		return err
+	}
+
+	set := make(map[uint]struct{}, len(blocked))
+	for _, id := range blocked {
+		set[id] = struct{}{}
+	}
+	for i := range tasks {
+		_, tasks[i].Blocked = set[tasks[i].ID]
+	}
+	return nil
+}
+
+func taskIds(tasks []entity.Todo) []uint {
+	ids := make([]uint, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+// setProgress fills in Progress for the tasks that have subtasks, with one query for all of them.
+func (t *TodoRepository) setProgress(ctx context.Context, tasks []entity.Todo) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	var rows []struct {
+		Root   uint
+		Total  int
+		Closed int
+	}
+	err := conn(ctx, t.db).Raw(`WITH RECURSIVE descendants AS (
+			SELECT parent_id AS root, id, status FROM todos WHERE parent_id IN ? AND deleted_at IS NULL
+			UNION
+			SELECT descendants.root, todos.id, todos.status FROM todos JOIN descendants ON todos.parent_id = descendants.id WHERE todos.deleted_at IS NULL
+		) SELECT root, COUNT(*) AS total, COUNT(*) FILTER (WHERE status IN ?) AS closed FROM descendants GROUP BY root`,
+		taskIds(tasks), t.workflow.Closed).
+		Scan(&rows).Error
+	if err != nil {
 		return err
 	}
+
+	progress := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		progress[row.Root] = row.Closed * 100 / row.Total
+	}
+	for i := range tasks {
+		if p, ok := progress[tasks[i].ID]; ok {
+			tasks[i].Progress = &p
+		}
+	}
 	return nil
 }
+
+func (t *TodoRepository) SaveTransition(ctx context.Context, transition *entity.TodoTransition) error {
+	if err := conn(ctx, t.db).Create(transition).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TodoRepository) GetTransitions(ctx context.Context, todoID uint) ([]entity.TodoTransition, error) {
+	var transitions []entity.TodoTransition
+	if err := conn(ctx, t.db).Where("todo_id = ?", todoID).Order("id").Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+func (t *TodoRepository) CountTasksByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+
+	if err := conn(ctx, t.db).Model(&entity.Todo{}).Select("status, count(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// preload loads the assignees and tags of the tasks found.
+func preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Assignees").Preload("Tags")
+}
+
+// _projectedColumns are the columns of the fields a projection may select and a list may be filtered or ordered
+// by; progress and blocked are derived.
+var _projectedColumns = map[string]string{
+	"id": "todos.id", "description": "todos.description", "status": "todos.status", "priority": "todos.priority",
+	"due_at": "todos.due_at", "parent_id": "todos.parent_id", "project_id": "todos.project_id",
+	"recurrence": "todos.recurrence", "timezone": "todos.timezone", "occurrence": "todos.occurrence",
+	"series_id": "todos.series_id", "created_by": "todos.created_by", "updated_by": "todos.updated_by",
+	"version": "todos.version", "created_at": "todos.created_at", "updated_at": "todos.updated_at",
+}
+
+// projected selects only the columns of the fields of a projection, along with the id, version and time of
+// the last change that associations and entity tags need, and preloads the associations it includes.
+func projected(db *gorm.DB, projection entity.Projection) *gorm.DB {
+	if projection.Includes("assignees") {
+		db = db.Preload("Assignees")
+	}
+	if projection.Includes("tags") {
+		db = db.Preload("Tags")
+	}
+	if len(projection.Fields) == 0 {
+		return db
+	}
+
+	columns := []string{"todos.id", "todos.version", "todos.updated_at"}
+	for _, field := range projection.Fields {
+		if column, ok := _projectedColumns[field]; ok && !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	return db.Select(columns)
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"gorm.io/gorm"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errUnknownSort     = errors.New("unknown sort")
+	errUnknownTagMatch = errors.New("unknown tag match")
+)
+
+type queryParamFunc func(query *gorm.DB, value string) (*gorm.DB, error)
+
+// queryParam returns the handler of a convenience list parameter that does not map onto a single column.
+func (t *TodoRepository) queryParam(field string) (queryParamFunc, bool) {
+	switch field {
+	case "overdue":
+		return t.overdue, true
+	case "due_within":
+		return dueWithin, true
+	case "sort":
+		return sortTasks, true
+	case "tags":
+		return tagged, true
+	case "ready":
+		return t.ready, true
+	case "assignee":
+		return assignee, true
+	case "unassigned":
+		return unassigned, true
+	default:
+		return nil, false
+	}
+}
+
+func (t *TodoRepository) overdue(query *gorm.DB, value string) (*gorm.DB, error) {
+	overdue, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	if !overdue {
+		return query.Where("(due_at IS NULL OR due_at >= ? OR status IN ?)", time.Now(), t.workflow.Closed), nil
+	}
+	return query.Where("due_at < ? AND status NOT IN ?", time.Now(), t.workflow.Closed), nil
+}
+
+// ready keeps open tasks whose blockers are all closed.
+func (t *TodoRepository) ready(query *gorm.DB, value string) (*gorm.DB, error) {
+	ready, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+
+	openBlockers := `EXISTS (SELECT 1 FROM todo_dependencies
+		JOIN todos blockers ON blockers.id = todo_dependencies.blocker_id AND blockers.deleted_at IS NULL
+		WHERE todo_dependencies.todo_id = todos.id AND blockers.status NOT IN ?)`
+	if !ready {
+		return query.Where("(todos.status IN ? OR "+openBlockers+")", t.workflow.Closed, t.workflow.Closed), nil
+	}
+	return query.Where("todos.status NOT IN ? AND NOT "+openBlockers, t.workflow.Closed, t.workflow.Closed), nil
+}
+
+// assignee keeps tasks assigned to the user with the given id.
+func assignee(query *gorm.DB, value string) (*gorm.DB, error) {
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		return nil, err
+	}
+	return query.Where("todos.id IN (SELECT todo_id FROM todo_assignees WHERE user_id = ?)", id), nil
+}
+
+func unassigned(query *gorm.DB, value string) (*gorm.DB, error) {
+	unassigned, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := "EXISTS (SELECT 1 FROM todo_assignees WHERE todo_assignees.todo_id = todos.id)"
+	if unassigned {
+		return query.Where("NOT " + assigned), nil
+	}
+	return query.Where(assigned), nil
+}
+
+func dueWithin(query *gorm.DB, value string) (*gorm.DB, error) {
+	within, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return query.Where("due_at >= ? AND due_at < ?", now, now.Add(within)), nil
+}
+
+func sortTasks(query *gorm.DB, value string) (*gorm.DB, error) {
+	switch value {
+	case "priority":
+		return query.Order(priorityOrder()).Order("due_at ASC NULLS LAST"), nil
+	case "due_at":
+		return query.Order("due_at ASC NULLS LAST").Order(priorityOrder()), nil
+	default:
+		return nil, fmt.Errorf("%w %q", errUnknownSort, value)
+	}
+}
+
+// priorityOrder sorts by urgency rather than alphabetically.
+func priorityOrder() string {
+	var b strings.Builder
+	b.WriteString("CASE priority")
+	for i, priority := range entity.Priorities {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", priority, i)
+	}
+	fmt.Fprintf(&b, " ELSE %d END", len(entity.Priorities))
+	return b.String()
+}
+
+// tagged filters by tag names: any:bug,urgent matches tasks with either tag, all:bug,urgent tasks with both.
+func tagged(query *gorm.DB, value string) (*gorm.DB, error) {
+	match, list, ok := strings.Cut(value, ":")
+	if !ok {
+		match, list = "any", value
+	}
+	// all: compares the count of distinct matching tags, so a name given twice must count once.
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	tasks := query.Session(&gorm.Session{NewDB: true}).
+		Table("todo_tags").
+		Select("todo_tags.todo_id").
+		Joins("JOIN tags ON tags.id = todo_tags.tag_id AND tags.deleted_at IS NULL").
+		Where("tags.name IN ?", names)
+
+	switch match {
+	case "any":
+	case "all":
+		tasks = tasks.Group("todo_tags.todo_id").Having("COUNT(DISTINCT tags.id) = ?", len(names))
+	default:
+		return nil, fmt.Errorf("%w %q", errUnknownTagMatch, match)
+	}
+	return query.Where("todos.id IN (?)", tasks), nil
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Vaixle/crud-golang/internal/entity"
@@ -36,7 +38,7 @@ func Test_GetTaskById(t *testing.T) {
 	sqlDB, db, mock := DbMock(t)
 	defer sqlDB.Close()
 
-	repo := NewTodoRepository(db)
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
 
 	type args struct {
 		id uint
@@ -63,6 +65,16 @@ func Test_GetTaskById(t *testing.T) {
 				)
 				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE "todos"."id" = (.+)`).
 					WithArgs(args.id).WillReturnRows(rows)
+				mock.ExpectQuery(`SELECT (.+) FROM "todo_assignees" WHERE "todo_assignees"."todo_id" = (.+)`).
+					WithArgs(args.id).WillReturnRows(mock.NewRows([]string{"todo_id", "user_id"}))
+				mock.ExpectQuery(`SELECT (.+) FROM "todo_tags" WHERE "todo_tags"."todo_id" = (.+)`).
+					WithArgs(args.id).WillReturnRows(mock.NewRows([]string{"todo_id", "tag_id"}))
+				mock.ExpectQuery(`WITH RECURSIVE descendants AS (.+) SELECT root, (.+) FROM descendants GROUP BY root`).
+					WithArgs(args.id, entity.StatusClose).WillReturnRows(mock.NewRows([]string{"root", "total", "closed"}))
+				mock.ExpectQuery(`SELECT DISTINCT todo_dependencies.todo_id FROM "todo_dependencies" (.+)`).
+					WithArgs(args.id, entity.StatusClose).WillReturnRows(mock.NewRows([]string{"todo_id"}))
+				mock.ExpectQuery(`SELECT todo_id, COUNT\(\*\) AS count FROM "comments" WHERE todo_id IN \((.+)\) AND "comments"."deleted_at" IS NULL GROUP BY "todo_id"`).
+					WithArgs(args.id).WillReturnRows(mock.NewRows([]string{"todo_id", "count"}).AddRow(args.id, 2))
 			},
 			expectedEntity: &entity.Todo{
 				Model: gorm.Model{
@@ -70,7 +82,10 @@ func Test_GetTaskById(t *testing.T) {
 					UpdatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
 					CreatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
 				},
-				Description: "New Task1",
+				Description:  "New Task1",
+				CommentCount: 2,
+				Assignees:    []entity.User{},
+				Tags:         []entity.Tag{},
 			},
 			wantErr: false,
 		},
@@ -99,7 +114,7 @@ func Test_GetTaskById(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.args)
 
-			task, err := repo.GetTaskById(testCase.args.id)
+			task, err := repo.GetTaskById(context.Background(), testCase.args.id)
 			assert.Nil(t, mock.ExpectationsWereMet())
 
 			if testCase.wantErr {
@@ -114,7 +129,8 @@ func Test_GetTaskById(t *testing.T) {
 func TestTodoRepository_GetTasks(t *testing.T) {
 	sqlDB, db, mock := DbMock(t)
 	defer sqlDB.Close()
-	repo := NewTodoRepository(db)
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+	progress := 25
 
 	type args struct {
 		id uint
@@ -125,6 +141,7 @@ func TestTodoRepository_GetTasks(t *testing.T) {
 		args                  args
 		inputFilterOptions    []httpquery.FilterOption
 		inputFilterPagination httpquery.Pagination
+		inputProjection       entity.Projection
 		mockBehavior          func(args args)
 		expectedEntity        []entity.Todo
 		wantErr               bool
@@ -145,6 +162,16 @@ func TestTodoRepository_GetTasks(t *testing.T) {
 				)
 				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE id > (.+)`).
 					WithArgs(strconv.Itoa(int(args.id))).WillReturnRows(rows)
+				mock.ExpectQuery(`SELECT (.+) FROM "todo_assignees" WHERE "todo_assignees"."todo_id" = (.+)`).
+					WithArgs(1).WillReturnRows(mock.NewRows([]string{"todo_id", "user_id"}))
+				mock.ExpectQuery(`SELECT (.+) FROM "todo_tags" WHERE "todo_tags"."todo_id" = (.+)`).
+					WithArgs(1).WillReturnRows(mock.NewRows([]string{"todo_id", "tag_id"}))
+				mock.ExpectQuery(`WITH RECURSIVE descendants AS (.+) SELECT root, (.+) FROM descendants GROUP BY root`).
+					WithArgs(1, entity.StatusClose).WillReturnRows(mock.NewRows([]string{"root", "total", "closed"}))
+				mock.ExpectQuery(`SELECT DISTINCT todo_dependencies.todo_id FROM "todo_dependencies" (.+)`).
+					WithArgs(1, entity.StatusClose).WillReturnRows(mock.NewRows([]string{"todo_id"}))
+				mock.ExpectQuery(`SELECT todo_id, COUNT\(\*\) AS count FROM "comments" (.+) GROUP BY "todo_id"`).
+					WithArgs(1).WillReturnRows(mock.NewRows([]string{"todo_id", "count"}))
 			},
 			expectedEntity: []entity.Todo{{
 				Model: gorm.Model{
@@ -153,6 +180,122 @@ func TestTodoRepository_GetTasks(t *testing.T) {
 					CreatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
 				},
 				Description: "New Task1",
+				Assignees:   []entity.User{},
+				Tags:        []entity.Tag{},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "OK WITH TAGS",
+			args: args{
+				id: 1,
+			},
+			inputFilterOptions:    []httpquery.FilterOption{{Operator: "gt", Field: "id", Value: "1"}},
+			inputFilterPagination: httpquery.Pagination{Limit: 100, Page: 0},
+			mockBehavior: func(args args) {
+				rows := mock.NewRows([]string{"id", "updated_at", "created_at", "deleted_at", "description"}).AddRow(
+					2, time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+					time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+					nil,
+					"New Task2",
+				).AddRow(
+					3, time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+					time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+					nil,
+					"New Task3",
+				)
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE id > (.+)`).
+					WithArgs(strconv.Itoa(int(args.id))).WillReturnRows(rows)
+				mock.ExpectQuery(`SELECT (.+) FROM "todo_assignees" WHERE "todo_assignees"."todo_id" IN \((.+),(.+)\)`).
+					WithArgs(2, 3).WillReturnRows(mock.NewRows([]string{"todo_id", "user_id"}).AddRow(2, 1))
+				mock.ExpectQuery(`SELECT (.+) FROM "users" WHERE "users"."id" = (.+) AND "users"."deleted_at" IS NULL`).
+					WithArgs(1).WillReturnRows(mock.NewRows([]string{"id", "username"}).AddRow(1, "admin"))
+				// One query for the join rows and one for the tags of the whole page.
+				mock.ExpectQuery(`SELECT (.+) FROM "todo_tags" WHERE "todo_tags"."todo_id" IN \((.+),(.+)\)`).
+					WithArgs(2, 3).WillReturnRows(mock.NewRows([]string{"todo_id", "tag_id"}).AddRow(2, 1).AddRow(3, 1).AddRow(3, 2))
+				mock.ExpectQuery(`SELECT (.+) FROM "tags" WHERE "tags"."id" IN \((.+),(.+)\)`).
+					WithArgs(1, 2).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, "bug").AddRow(2, "urgent"))
+				// Progress of the whole page in one query as well.
+				mock.ExpectQuery(`WITH RECURSIVE descendants AS (.+) SELECT root, (.+) FROM descendants GROUP BY root`).
+					WithArgs(2, 3, entity.StatusClose).WillReturnRows(mock.NewRows([]string{"root", "total", "closed"}).AddRow(2, 4, 1))
+				mock.ExpectQuery(`SELECT DISTINCT todo_dependencies.todo_id FROM "todo_dependencies" JOIN todos blockers (.+) WHERE todo_dependencies.todo_id IN \((.+),(.+)\) AND blockers.status NOT IN \((.+)\)`).
+					WithArgs(2, 3, entity.StatusClose).WillReturnRows(mock.NewRows([]string{"todo_id"}).AddRow(3))
+				mock.ExpectQuery(`SELECT todo_id, COUNT\(\*\) AS count FROM "comments" WHERE todo_id IN \((.+),(.+)\) (.+) GROUP BY "todo_id"`).
+					WithArgs(2, 3).WillReturnRows(mock.NewRows([]string{"todo_id", "count"}).AddRow(3, 5))
+			},
+			expectedEntity: []entity.Todo{{
+				Model: gorm.Model{
+					ID:        2,
+					UpdatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+					CreatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+				},
+				Description: "New Task2",
+				Progress:    &progress,
+				Assignees:   []entity.User{{Model: gorm.Model{ID: 1}, Username: "admin"}},
+				Tags:        []entity.Tag{{Model: gorm.Model{ID: 1}, Name: "bug"}},
+			}, {
+				Model: gorm.Model{
+					ID:        3,
+					UpdatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+					CreatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC),
+				},
+				Description:  "New Task3",
+				Blocked:      true,
+				CommentCount: 5,
+				Assignees:    []entity.User{},
+				Tags:         []entity.Tag{{Model: gorm.Model{ID: 1}, Name: "bug"}, {Model: gorm.Model{ID: 2}, Name: "urgent"}},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "SPARSE FIELDSET",
+			args: args{
+				id: 1,
+			},
+			inputFilterOptions:    []httpquery.FilterOption{{Operator: "gt", Field: "id", Value: "1"}},
+			inputFilterPagination: httpquery.Pagination{Limit: 100, Page: 0},
+			inputProjection:       entity.Projection{Fields: []string{"id", "description", "status"}},
+			mockBehavior: func(args args) {
+				// Only the columns asked for, and nothing derived or related.
+				mock.ExpectQuery(`SELECT todos.id,todos.version,todos.updated_at,todos.description,todos.status FROM "todos" WHERE id > (.+)`).
+					WithArgs(strconv.Itoa(int(args.id))).
+					WillReturnRows(mock.NewRows([]string{"id", "version", "updated_at", "description", "status"}).
+						AddRow(2, 3, time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC), "New Task2", "open"))
+			},
+			expectedEntity: []entity.Todo{{
+				Model:       gorm.Model{ID: 2, UpdatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)},
+				Description: "New Task2",
+				Status:      "open",
+				Version:     3,
+			}},
+			wantErr: false,
+		},
+		{
+			name: "INCLUDE",
+			args: args{
+				id: 1,
+			},
+			inputFilterOptions:    []httpquery.FilterOption{{Operator: "gt", Field: "id", Value: "1"}},
+			inputFilterPagination: httpquery.Pagination{Limit: 100, Page: 0},
+			inputProjection:       entity.Projection{Fields: []string{"description"}, Include: []string{"tags", "comment_count"}},
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(`SELECT todos.id,todos.version,todos.updated_at,todos.description FROM "todos" WHERE id > (.+)`).
+					WithArgs(strconv.Itoa(int(args.id))).
+					WillReturnRows(mock.NewRows([]string{"id", "version", "updated_at", "description"}).
+						AddRow(2, 3, time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC), "New Task2"))
+				mock.ExpectQuery(`SELECT (.+) FROM "todo_tags" WHERE "todo_tags"."todo_id" = (.+)`).
+					WithArgs(2).WillReturnRows(mock.NewRows([]string{"todo_id", "tag_id"}).AddRow(2, 1))
+				mock.ExpectQuery(`SELECT (.+) FROM "tags" WHERE "tags"."id" = (.+)`).
+					WithArgs(1).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, "bug"))
+				mock.ExpectQuery(`SELECT todo_id, COUNT\(\*\) AS count FROM "comments" WHERE todo_id IN \((.+)\) (.+) GROUP BY "todo_id"`).
+					WithArgs(2).WillReturnRows(mock.NewRows([]string{"todo_id", "count"}).AddRow(2, 4))
+			},
+			expectedEntity: []entity.Todo{{
+				Model:        gorm.Model{ID: 2, UpdatedAt: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)},
+				Description:  "New Task2",
+				Version:      3,
+				CommentCount: 4,
+				Tags:         []entity.Tag{{Model: gorm.Model{ID: 1}, Name: "bug"}},
 			}},
 			wantErr: false,
 		},
@@ -176,7 +319,7 @@ func TestTodoRepository_GetTasks(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.args)
 
-			task, err := repo.GetTasks(testCase.inputFilterOptions, testCase.inputFilterPagination)
+			task, err := repo.GetTasks(context.Background(), testCase.inputFilterOptions, testCase.inputFilterPagination, testCase.inputProjection)
 			assert.Nil(t, mock.ExpectationsWereMet())
 
 			if testCase.wantErr {
@@ -191,7 +334,7 @@ func TestTodoRepository_GetTasks(t *testing.T) {
 func TestTodoRepository_SaveTask(t *testing.T) {
 	sqlDB, db, mock := DbMock(t)
 	defer sqlDB.Close()
-	repo := NewTodoRepository(db)
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
 
 	type args struct {
 		id uint
@@ -224,7 +367,8 @@ func TestTodoRepository_SaveTask(t *testing.T) {
 				mock.ExpectBegin()
 				mock.ExpectQuery(expectedSQL).WillReturnRows(rows).
 					WithArgs(inputEntity.CreatedAt, inputEntity.UpdatedAt, inputEntity.DeletedAt, inputEntity.Description,
-						inputEntity.Status)
+						inputEntity.Status, entity.PriorityNormal, inputEntity.DueAt, inputEntity.ParentID, inputEntity.ProjectID,
+						inputEntity.Recurrence, inputEntity.Timezone, inputEntity.Occurrence, inputEntity.SeriesID, inputEntity.CreatedBy, inputEntity.UpdatedBy, 1)
 				mock.ExpectCommit()
 
 			},
@@ -236,6 +380,8 @@ func TestTodoRepository_SaveTask(t *testing.T) {
 				},
 				Description: "New Task1",
 				Status:      "open",
+				Priority:    entity.PriorityNormal,
+				Version:     1,
 			},
 			wantErr: false,
 		},
@@ -248,12 +394,15 @@ func TestTodoRepository_SaveTask(t *testing.T) {
 				},
 				Description: "New Task1",
 				Status:      "open",
+				Priority:    entity.PriorityHigh,
 			},
 			mockBehavior: func(inputEntity *entity.Todo) {
 				expectedSQL := `INSERT INTO "todos" (.+) VALUES (.+)`
 				mock.ExpectBegin()
 				mock.ExpectQuery(expectedSQL).
-					WithArgs(inputEntity.CreatedAt, inputEntity.UpdatedAt, inputEntity.DeletedAt, inputEntity.Description, inputEntity.Status).
+					WithArgs(inputEntity.CreatedAt, inputEntity.UpdatedAt, inputEntity.DeletedAt, inputEntity.Description, inputEntity.Status,
+						inputEntity.Priority, inputEntity.DueAt, inputEntity.ParentID, inputEntity.ProjectID,
+						inputEntity.Recurrence, inputEntity.Timezone, inputEntity.Occurrence, inputEntity.SeriesID, inputEntity.CreatedBy, inputEntity.UpdatedBy, 1).
 					WillReturnError(errors.New("some error"))
 				mock.ExpectRollback()
 
@@ -267,7 +416,7 @@ func TestTodoRepository_SaveTask(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.inputEntity)
 
-			err := repo.SaveTask(testCase.inputEntity)
+			err := repo.SaveTask(context.Background(), testCase.inputEntity)
 			assert.Nil(t, mock.ExpectationsWereMet())
 
 			if testCase.wantErr {
@@ -278,3 +427,539 @@ func TestTodoRepository_SaveTask(t *testing.T) {
 		})
 	}
 }
+
+// insertArgs are the arguments of a multi-row INSERT of new tasks with only a description.
+func insertArgs(descriptions ...string) []driver.Value {
+	var args []driver.Value
+	for _, description := range descriptions {
+		args = append(args, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, description, "", entity.PriorityNormal,
+			nil, nil, nil, "", "", 0, nil, nil, nil, 1)
+	}
+	return args
+}
+
+func TestTodoRepository_SaveTasks(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		expectedIDs  []uint
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "todos" (.+) VALUES \((.+)\),\((.+)\) RETURNING "id"`).
+					WithArgs(insertArgs("a", "b")...).
+					WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectQuery(`INSERT INTO "todos" (.+) VALUES \(([^)]+)\) RETURNING "id"`).
+					WithArgs(insertArgs("c")...).
+					WillReturnRows(mock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectCommit()
+			},
+			expectedIDs: []uint{1, 2, 3},
+			wantErr:     false,
+		},
+		{
+			name: "ERROR",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "todos" (.+) VALUES \((.+)\),\((.+)\)`).
+					WithArgs(insertArgs("a", "b")...).
+					WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectQuery(`INSERT INTO "todos"`).
+					WithArgs(insertArgs("c")...).
+					WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			tasks := []*entity.Todo{{Description: "a"}, {Description: "b"}, {Description: "c"}}
+			err := repo.SaveTasks(context.Background(), tasks, 2)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			for i, task := range tasks {
+				assert.Equal(t, testCase.expectedIDs[i], task.ID)
+				assert.Equal(t, uint(1), task.Version)
+			}
+		})
+	}
+}
+
+func TestTodoRepository_CountTasksByStatus(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+
+	testTable := []struct {
+		name           string
+		mockBehavior   func()
+		expectedCounts map[string]int64
+		wantErr        bool
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				rows := mock.NewRows([]string{"status", "count"}).AddRow("open", 3).AddRow("close", 2)
+				mock.ExpectQuery(`SELECT status, count\(\*\) AS count FROM "todos" WHERE "todos"."deleted_at" IS NULL GROUP BY "status"`).
+					WillReturnRows(rows)
+			},
+			expectedCounts: map[string]int64{"open": 3, "close": 2},
+			wantErr:        false,
+		},
+		{
+			name: "ERROR",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT status, count\(\*\) AS count FROM "todos"`).
+					WillReturnError(errors.New("some error"))
+			},
+			expectedCounts: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			counts, err := repo.CountTasksByStatus(context.Background())
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.Equal(t, testCase.expectedCounts, counts)
+			}
+		})
+	}
+}
+
+func TestTodoRepository_StreamTasks(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		expectedIDs  []uint
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				// Sorting is dropped: batches are read in id order.
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE status = (.+) AND \(NOT EXISTS (.+)\) (.+) ORDER BY "todos"."id" LIMIT ` + strconv.Itoa(_streamBatchSize)).
+					WithArgs("open").
+					WillReturnRows(mock.NewRows([]string{"id", "description"}).AddRow(2, "a").AddRow(3, "b"))
+				mock.ExpectQuery(`SELECT (.+) FROM "todo_assignees"`).WithArgs(2, 3).WillReturnRows(mock.NewRows([]string{"todo_id", "user_id"}))
+				mock.ExpectQuery(`SELECT (.+) FROM "todo_tags"`).WithArgs(2, 3).WillReturnRows(mock.NewRows([]string{"todo_id", "tag_id"}))
+				mock.ExpectQuery(`WITH RECURSIVE descendants`).WithArgs(2, 3, entity.StatusClose).WillReturnRows(mock.NewRows([]string{"root", "total", "closed"}))
+				mock.ExpectQuery(`SELECT DISTINCT todo_dependencies.todo_id`).WithArgs(2, 3, entity.StatusClose).WillReturnRows(mock.NewRows([]string{"todo_id"}))
+				mock.ExpectQuery(`SELECT todo_id, COUNT\(\*\) AS count FROM "comments"`).WithArgs(2, 3).WillReturnRows(mock.NewRows([]string{"todo_id", "count"}))
+			},
+			expectedIDs: []uint{2, 3},
+			wantErr:     false,
+		},
+		{
+			name: "ERROR",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos"`).
+					WithArgs("open").
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			var ids []uint
+			filters := []httpquery.FilterOption{{Operator: "eq", Field: "status", Value: "open"}, {Field: "sort", Value: "priority"}, {Operator: "order_by", Field: "id", Value: "desc"}}
+			err := repo.StreamTasks(context.Background(), filters, func(tasks []entity.Todo) error {
+				for _, task := range tasks {
+					ids = append(ids, task.ID)
+				}
+				return nil
+			})
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestTodoRepository_GetAncestorIds(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+
+	// Soft-deleted ancestors end the chain.
+	mock.ExpectQuery(`WITH RECURSIVE chain AS \((.+) FROM todos WHERE id = (.+) AND deleted_at IS NULL UNION (.+) FROM todos JOIN chain ON todos.id = chain.parent_id WHERE todos.deleted_at IS NULL \) SELECT id, depth FROM chain ORDER BY depth`).
+		WithArgs(3).
+		WillReturnRows(mock.NewRows([]string{"id", "depth"}).AddRow(3, 0).AddRow(2, 1))
+
+	ids, err := repo.GetAncestorIds(context.Background(), 3)
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{3, 2}, ids)
+}
+
+func TestTodoRepository_LockDependencies(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\((.+)\)\)`).
+		WithArgs("todo_dependencies").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.Transaction(context.Background(), func(ctx context.Context) error {
+		return repo.LockDependencies(ctx)
+	})
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestTodoRepository_GetTasksQueryParams(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+
+	testTable := []struct {
+		name               string
+		inputFilterOptions []httpquery.FilterOption
+		mockBehavior       func()
+		wantErr            bool
+		wantErrIs          error
+	}{
+		{
+			name:               "OVERDUE",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "overdue", Value: "true"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE \(due_at < (.+) AND status NOT IN \((.+)\)\)`).
+					WithArgs(sqlmock.AnyArg(), entity.StatusClose).WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "DUE WITHIN",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "due_within", Value: "24h"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE \(due_at >= (.+) AND due_at < (.+)\)`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name: "DUE AT RANGE",
+			inputFilterOptions: []httpquery.FilterOption{
+				{Operator: "ge", Field: "due_at", Value: "2024-01-01T00:00:00Z"},
+				{Operator: "lt", Field: "due_at", Value: "2024-01-01T12:00:00Z"},
+			},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE due_at >= (.+) AND due_at < (.+)`).
+					WithArgs("2024-01-01T00:00:00Z", "2024-01-01T12:00:00Z").WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "SORT BY PRIORITY",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "sort", Value: "priority"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" (.+) ORDER BY CASE priority WHEN 'urgent' THEN 0 WHEN 'high' THEN 1 WHEN 'normal' THEN 2 WHEN 'low' THEN 3 ELSE 4 END,due_at ASC NULLS LAST`).
+					WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "TAGS ALL",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "tags", Value: "all:bug,urgent"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE todos.id IN \(SELECT todo_tags.todo_id FROM "todo_tags" JOIN tags (.+) WHERE tags.name IN \((.+),(.+)\) GROUP BY "todo_tags"."todo_id" HAVING COUNT\(DISTINCT tags.id\) = (.+)\)`).
+					WithArgs("bug", "urgent", 2).WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "TAGS ALL REPEATED",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "tags", Value: "all:bug,bug"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE todos.id IN \(SELECT todo_tags.todo_id FROM "todo_tags" JOIN tags (.+) WHERE tags.name IN \((.+)\) GROUP BY "todo_tags"."todo_id" HAVING COUNT\(DISTINCT tags.id\) = (.+)\)`).
+					WithArgs("bug", 1).WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "TAGS ANY",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "tags", Value: "any:bug"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE todos.id IN \(SELECT todo_tags.todo_id FROM "todo_tags" JOIN tags (.+) WHERE tags.name IN \((.+)\)\)`).
+					WithArgs("bug").WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "READY",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "ready", Value: "true"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE \(todos.status NOT IN \((.+)\) AND NOT EXISTS \(SELECT 1 FROM todo_dependencies (.+) AND blockers.status NOT IN \((.+)\)\)\)`).
+					WithArgs(entity.StatusClose, entity.StatusClose).WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "ASSIGNEE",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "assignee", Value: "3"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE todos.id IN \(SELECT todo_id FROM todo_assignees WHERE user_id = (.+)\)`).
+					WithArgs(3).WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "UNASSIGNED",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "unassigned", Value: "true"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE NOT EXISTS \(SELECT 1 FROM todo_assignees WHERE todo_assignees.todo_id = todos.id\)`).
+					WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "ARCHIVED PROJECTS HIDDEN",
+			inputFilterOptions: []httpquery.FilterOption{{Operator: "eq", Field: "status", Value: "open"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE status = (.+) AND \(NOT EXISTS \(SELECT 1 FROM projects WHERE projects.id = todos.project_id AND projects.archived\)\)`).
+					WithArgs("open").WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "INCLUDE ARCHIVED",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "include_archived", Value: "true"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE "todos"."deleted_at" IS NULL`).
+					WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "INVALID DURATION",
+			inputFilterOptions: []httpquery.FilterOption{{Field: "due_within", Value: "tomorrow"}},
+			mockBehavior:       func() {},
+			wantErr:            true,
+		},
+		{
+			name:               "ORDER BY",
+			inputFilterOptions: []httpquery.FilterOption{{Operator: "order_by", Field: "due_at", Value: "desc"}},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "todos" WHERE (.+) ORDER BY due_at DESC`).
+					WillReturnRows(mock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:               "UNKNOWN FIELD",
+			inputFilterOptions: []httpquery.FilterOption{{Operator: "eq", Field: "1=1 OR id", Value: "1"}},
+			mockBehavior:       func() {},
+			wantErr:            true,
+			wantErrIs:          entity.ErrInvalidFilter,
+		},
+		{
+			name:               "INJECTED ORDER BY",
+			inputFilterOptions: []httpquery.FilterOption{{Operator: "order_by", Field: "id", Value: "asc; DROP TABLE todos"}},
+			mockBehavior:       func() {},
+			wantErr:            true,
+			wantErrIs:          entity.ErrInvalidFilter,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			_, err := repo.GetTasks(context.Background(), testCase.inputFilterOptions, httpquery.Pagination{Limit: 100}, entity.Projection{})
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr {
+				assert.Error(t, err)
+				if testCase.wantErrIs != nil {
+					assert.ErrorIs(t, err, testCase.wantErrIs)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTodoRepository_UpdateTask(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+
+	testTable := []struct {
+		name            string
+		mockBehavior    func()
+		expectedVersion uint
+		wantErr         error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "todos" SET (.+),"version"=(.+) WHERE version = (.+) AND "todos"."deleted_at" IS NULL AND "id" = (.+)`).
+					WithArgs(sqlmock.AnyArg(), "New Task1", "open", "", nil, nil, nil, "", "", 0, nil, nil, nil, 3, 2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedVersion: 3,
+		},
+		{
+			name: "VERSION MISMATCH",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "todos" (.+) WHERE version = (.+)`).
+					WithArgs(sqlmock.AnyArg(), "New Task1", "open", "", nil, nil, nil, "", "", 0, nil, nil, nil, 3, 2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "todos" WHERE id = (.+)`).
+					WithArgs(1).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(1))
+			},
+			expectedVersion: 2,
+			wantErr:         entity.ErrVersionMismatch,
+		},
+		{
+			name: "NOT FOUND",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "todos" (.+) WHERE version = (.+)`).
+					WithArgs(sqlmock.AnyArg(), "New Task1", "open", "", nil, nil, nil, "", "", 0, nil, nil, nil, 3, 2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "todos" WHERE id = (.+)`).
+					WithArgs(1).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+			},
+			expectedVersion: 2,
+			wantErr:         entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			task := &entity.Todo{Model: gorm.Model{ID: 1}, Description: "New Task1", Status: "open", Version: 2}
+			err := repo.UpdateTask(context.Background(), task)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedVersion, task.Version)
+		})
+	}
+}
+
+func TestTodoRepository_PurgeTask(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "todos" WHERE "todos"."id" = (.+)`).
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM "comments" WHERE todo_id = (.+)`).
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`DELETE FROM "todo_transitions" WHERE todo_id = (.+)`).
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM "todo_dependencies" WHERE todo_id = (.+) OR blocker_id = (.+)`).
+					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`DELETE FROM "attachments" WHERE todo_id = (.+)`).
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE "todos" SET "parent_id"=(.+),"version"=version \+ 1 WHERE parent_id = (.+)`).
+					WithArgs(nil, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "NOT FOUND",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "todos" (.+)`).
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "todos" WHERE id = (.+)`).
+					WithArgs(1).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			err := repo.PurgeTask(context.Background(), 1, entity.AnyVersion)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTodoRepository_RestoreTask(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewTodoRepository(db, entity.DefaultWorkflow())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "todos" SET "deleted_at"=(.+),"version"=version \+ 1 WHERE id = (.+) AND deleted_at IS NOT NULL`).
+		WithArgs(nil, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.RestoreTask(context.Background(), 1)
+	assert.Nil(t, mock.ExpectationsWereMet())
+
+	assert.ErrorIs(t, err, entity.ErrTaskNotFound)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// transaction runs fn in a transaction stored in the context, or joins the one already there.
+func transaction(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, if any, or db.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// advisoryLock takes a transaction-level advisory lock on the name, held until the transaction in ctx ends.
+// Names that hash alike only serialize more than they need to.
+func advisoryLock(ctx context.Context, db *gorm.DB, name string) error {
+	return conn(ctx, db).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", name).Error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"gorm.io/gorm"
+)
+
+var _ entity.UserRepository = (*UserRepository)(nil)
+
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) entity.UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (u *UserRepository) GetUsers(ctx context.Context) ([]entity.User, error) {
+	var users []entity.User
+	if err := conn(ctx, u.db).Order("username").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (u *UserRepository) GetUserById(ctx context.Context, id uint) (*entity.User, error) {
+	var user entity.User
+	if err := conn(ctx, u.db).First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", entity.ErrUserNotFound, id)
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (u *UserRepository) GetUsersByIds(ctx context.Context, ids []uint) ([]entity.User, error) {
+	var users []entity.User
+	if err := conn(ctx, u.db).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (u *UserRepository) GetOrCreateUser(ctx context.Context, username string) (*entity.User, error) {
+	user := entity.User{Username: username}
+	err := conn(ctx, u.db).Where("username = ?", username).FirstOrCreate(&user).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// Another request created the user between the lookup and the insert.
+		user = entity.User{}
+		err = conn(ctx, u.db).Where("username = ?", username).First(&user).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestUserRepository_GetOrCreateUser(t *testing.T) {
+	sqlDB, db, mock := DbMock(t)
+	defer sqlDB.Close()
+	repo := NewUserRepository(db)
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		expected     *entity.User
+	}{
+		{
+			name: "EXISTING",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "users" WHERE username = (.+) AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT 1`).
+					WithArgs("admin").WillReturnRows(mock.NewRows([]string{"id", "username"}).AddRow(1, "admin"))
+			},
+			expected: &entity.User{Model: gorm.Model{ID: 1}, Username: "admin"},
+		},
+		{
+			name: "FIRST LOGIN",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM "users" WHERE username = (.+)`).
+					WithArgs("admin").WillReturnRows(mock.NewRows([]string{"id", "username"}))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "users" (.+) VALUES (.+) RETURNING "id"`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "admin").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectCommit()
+			},
+			expected: &entity.User{Model: gorm.Model{ID: 2}, Username: "admin"},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			user, err := repo.GetOrCreateUser(context.Background(), "admin")
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected.ID, user.ID)
+			assert.Equal(t, testCase.expected.Username, user.Username)
+		})
+	}
+}
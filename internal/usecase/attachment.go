@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/blobstore"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"io"
+	"net/http"
+)
+
+// _sniffLen is how much of the content http.DetectContentType looks at.
+const _sniffLen = 512
+
+var _ entity.AttachmentUseCase = (*AttachmentUseCase)(nil)
+
+type AttachmentUseCase struct {
+	repo   entity.AttachmentRepository
+	tasks  entity.TodoRepository
+	store  blobstore.BlobStore
+	limits entity.Attachments
+	l      logger.Interface
+}
+
+func NewAttachmentUseCase(repo entity.AttachmentRepository, tasks entity.TodoRepository, store blobstore.BlobStore, limits entity.Attachments, l logger.Interface) entity.AttachmentUseCase {
+	return &AttachmentUseCase{repo: repo, tasks: tasks, store: store, limits: limits, l: l}
+}
+
+func (a AttachmentUseCase) GetAttachments(ctx context.Context, todoID uint) (_ []entity.Attachment, err error) {
+	ctx, span := startSpan(ctx, "AttachmentUseCase.GetAttachments", attribute.Int64("todo.id", int64(todoID)))
+	defer func() { endSpan(span, err) }()
+
+	if _, err = a.tasks.GetTaskById(ctx, todoID); err != nil {
+		return nil, err
+	}
+
+	return a.repo.GetAttachments(ctx, todoID)
+}
+
+func (a AttachmentUseCase) GetAttachment(ctx context.Context, todoID, id uint) (_ *entity.Attachment, err error) {
+	ctx, span := startSpan(ctx, "AttachmentUseCase.GetAttachment",
+		attribute.Int64("todo.id", int64(todoID)), attribute.Int64("attachment.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	return a.attachment(ctx, todoID, id)
+}
+
+// SaveAttachment checks the sniffed content type and the size against the limits, then stores the content
+// unless a blob with the same SHA-256 is there already and saves the attachment, both under the lock of the digest.
+func (a AttachmentUseCase) SaveAttachment(ctx context.Context, attachment *entity.Attachment, content io.ReadSeeker) (err error) {
+	ctx, span := startSpan(ctx, "AttachmentUseCase.SaveAttachment", attribute.Int64("todo.id", int64(attachment.TodoID)))
+	defer func() { endSpan(span, err) }()
+
+	if _, err = a.tasks.GetTaskById(ctx, attachment.TodoID); err != nil {
+		return err
+	}
+
+	head := make([]byte, _sniffLen)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !a.limits.Allows(contentType) {
+		return fmt.Errorf("%w: %s", entity.ErrUnsupportedContent, contentType)
+	}
+
+	hash := sha256.New()
+	hash.Write(head)
+	rest := io.Reader(content)
+	if a.limits.MaxSize > 0 {
+		// One byte past the limit is enough to tell the content is too large.
+		rest = io.LimitReader(content, a.limits.MaxSize-int64(n)+1)
+	}
+	copied, err := io.Copy(hash, rest)
+	if err != nil {
+		return err
+	}
+	size := int64(n) + copied
+	if a.limits.MaxSize > 0 && size > a.limits.MaxSize {
+		return fmt.Errorf("%w: larger than %d bytes", entity.ErrAttachmentTooLarge, a.limits.MaxSize)
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	attachment.ContentType = contentType
+	attachment.Size = size
+	attachment.SHA256 = digest
+	attachment.UploadedBy = entity.UserIDFromContext(ctx)
+
+	stored := false
+	err = a.repo.Transaction(ctx, func(ctx context.Context) error {
+		// The lock is held until the attachment is committed, so removeBlobs cannot take the blob from under it.
+		if err := a.repo.LockDigest(ctx, digest); err != nil {
+			return err
+		}
+		exists, err := a.store.Exists(ctx, digest)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if _, err = content.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			if err = a.store.Put(ctx, digest, content, size); err != nil {
+				return err
+			}
+			stored = true
+		}
+		return a.repo.SaveAttachment(ctx, attachment)
+	})
+	if err != nil {
+		if stored {
+			removeBlobs(ctx, a.repo, a.store, a.l, digest)
+		}
+		return err
+	}
+
+	a.l.WithContext(ctx).Info("success creating attachment")
+	return nil
+}
+
+func (a AttachmentUseCase) OpenAttachment(ctx context.Context, todoID, id uint) (_ *entity.Attachment, _ io.ReadSeekCloser, err error) {
+	ctx, span := startSpan(ctx, "AttachmentUseCase.OpenAttachment",
+		attribute.Int64("todo.id", int64(todoID)), attribute.Int64("attachment.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	attachment, err := a.attachment(ctx, todoID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := a.store.Open(ctx, attachment.SHA256)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (a AttachmentUseCase) DeleteAttachment(ctx context.Context, todoID, id uint) (err error) {
+	ctx, span := startSpan(ctx, "AttachmentUseCase.DeleteAttachment",
+		attribute.Int64("todo.id", int64(todoID)), attribute.Int64("attachment.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	attachment, err := a.attachment(ctx, todoID, id)
+	if err != nil {
+		return err
+	}
+
+	if err = a.repo.DeleteAttachment(ctx, id); err != nil {
+		return err
+	}
+	removeBlobs(ctx, a.repo, a.store, a.l, attachment.SHA256)
+
+	a.l.WithContext(ctx).Info("success deleting attachment")
+	return nil
+}
+
+// attachment returns the attachment when it belongs to the task; attachments of other tasks are reported as not found.
+func (a AttachmentUseCase) attachment(ctx context.Context, todoID, id uint) (*entity.Attachment, error) {
+	attachment, err := a.repo.GetAttachmentById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TodoID != todoID {
+		return nil, fmt.Errorf("%w: %d", entity.ErrAttachmentNotFound, id)
+	}
+	return attachment, nil
+}
+
+// removeBlobs deletes the blobs no attachment refers to any more, under the lock uploads of the same content
+// take. Failures are only logged: the attachments are gone already and an orphaned blob costs nothing but space.
+func removeBlobs(ctx context.Context, repo entity.AttachmentRepository, store blobstore.BlobStore, l logger.Interface, digests ...string) {
+	for _, digest := range digests {
+		err := repo.Transaction(ctx, func(ctx context.Context) error {
+			if err := repo.LockDigest(ctx, digest); err != nil {
+				return err
+			}
+			referenced, err := repo.IsReferenced(ctx, digest)
+			if err != nil || referenced {
+				return err
+			}
+			return store.Delete(ctx, digest)
+		})
+		if err != nil {
+			l.WithContext(ctx).Error(fmt.Errorf("usecase - removeBlobs: %s: %w", digest, err))
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/blobstore"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAttachmentUseCase_SaveAttachment(t *testing.T) {
+	const content = "2024-01-01 12:00:00 ERROR something broke\n"
+	sum := sha256.Sum256([]byte(content))
+	digest := hex.EncodeToString(sum[:])
+	uploader := entity.User{Model: gorm.Model{ID: 7}, Username: "admin"}
+	limits := entity.Attachments{MaxSize: 1024, ContentTypes: []string{"image/*", "text/plain"}}
+
+	testTable := []struct {
+		name          string
+		content       string
+		limits        entity.Attachments
+		stored        bool
+		mockBehaviour func(r *mock_entity.MockAttachmentRepository, tasks *mock_entity.MockTodoRepository)
+		wantErr       error
+	}{
+		{
+			name:    "OK",
+			content: content,
+			limits:  limits,
+			mockBehaviour: func(r *mock_entity.MockAttachmentRepository, tasks *mock_entity.MockTodoRepository) {
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().LockDigest(gomock.Any(), digest).Return(nil)
+				r.EXPECT().SaveAttachment(gomock.Any(), &entity.Attachment{
+					TodoID:      1,
+					Filename:    "app.log",
+					ContentType: "text/plain; charset=utf-8",
+					Size:        int64(len(content)),
+					SHA256:      digest,
+					UploadedBy:  &uploader.ID,
+				}).Return(nil)
+			},
+		},
+		{
+			name:    "SAME CONTENT STORED ONCE",
+			content: content,
+			limits:  limits,
+			stored:  true,
+			mockBehaviour: func(r *mock_entity.MockAttachmentRepository, tasks *mock_entity.MockTodoRepository) {
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().LockDigest(gomock.Any(), digest).Return(nil)
+				r.EXPECT().SaveAttachment(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "SAVE FAILS",
+			content: content,
+			limits:  limits,
+			mockBehaviour: func(r *mock_entity.MockAttachmentRepository, tasks *mock_entity.MockTodoRepository) {
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().LockDigest(gomock.Any(), digest).Return(nil).Times(2)
+				r.EXPECT().SaveAttachment(gomock.Any(), gomock.Any()).Return(entity.ErrTaskNotFound)
+				r.EXPECT().IsReferenced(gomock.Any(), digest).Return(false, nil)
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+		{
+			name:    "TOO LARGE",
+			content: strings.Repeat("x", 1025),
+			limits:  limits,
+			mockBehaviour: func(r *mock_entity.MockAttachmentRepository, tasks *mock_entity.MockTodoRepository) {
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+			},
+			wantErr: entity.ErrAttachmentTooLarge,
+		},
+		{
+			name:    "UNSUPPORTED CONTENT TYPE",
+			content: "%PDF-1.7\n",
+			limits:  limits,
+			mockBehaviour: func(r *mock_entity.MockAttachmentRepository, tasks *mock_entity.MockTodoRepository) {
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+			},
+			wantErr: entity.ErrUnsupportedContent,
+		},
+		{
+			name:    "TASK NOT FOUND",
+			content: content,
+			limits:  limits,
+			mockBehaviour: func(r *mock_entity.MockAttachmentRepository, tasks *mock_entity.MockTodoRepository) {
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(nil, entity.ErrTaskNotFound)
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockAttachmentRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+			tasks := mock_entity.NewMockTodoRepository(ctrl)
+			testCase.mockBehaviour(repo, tasks)
+
+			store, err := blobstore.NewFilesystem(t.TempDir())
+			require.NoError(t, err)
+			if testCase.stored {
+				// A marker proves the stored blob is reused rather than written again.
+				require.NoError(t, store.Put(context.Background(), digest, strings.NewReader("stored"), 6))
+			}
+
+			useCase := NewAttachmentUseCase(repo, tasks, store, testCase.limits, logger.New("info"))
+
+			ctx := entity.ContextWithUser(context.Background(), uploader)
+			attachment := &entity.Attachment{TodoID: 1, Filename: "app.log"}
+			err = useCase.SaveAttachment(ctx, attachment, strings.NewReader(testCase.content))
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				exists, err := store.Exists(context.Background(), digest)
+				require.NoError(t, err)
+				assert.False(t, exists)
+				return
+			}
+			require.NoError(t, err)
+
+			blob, err := store.Open(context.Background(), digest)
+			require.NoError(t, err)
+			defer blob.Close()
+			stored, err := io.ReadAll(blob)
+			require.NoError(t, err)
+			if testCase.stored {
+				assert.Equal(t, "stored", string(stored))
+			} else {
+				assert.Equal(t, testCase.content, string(stored))
+			}
+		})
+	}
+}
+
+func TestAttachmentUseCase_DeleteAttachment(t *testing.T) {
+	testTable := []struct {
+		name          string
+		todoID        uint
+		mockBehaviour func(r *mock_entity.MockAttachmentRepository)
+		wantBlob      bool
+		wantErr       error
+	}{
+		{
+			name:   "LAST REFERENCE",
+			todoID: 1,
+			mockBehaviour: func(r *mock_entity.MockAttachmentRepository) {
+				r.EXPECT().GetAttachmentById(gomock.Any(), uint(5)).Return(&entity.Attachment{ID: 5, TodoID: 1, SHA256: "abc"}, nil)
+				r.EXPECT().DeleteAttachment(gomock.Any(), uint(5)).Return(nil)
+				r.EXPECT().LockDigest(gomock.Any(), "abc").Return(nil)
+				r.EXPECT().IsReferenced(gomock.Any(), "abc").Return(false, nil)
+			},
+		},
+		{
+			name:   "SHARED CONTENT",
+			todoID: 1,
+			mockBehaviour: func(r *mock_entity.MockAttachmentRepository) {
+				r.EXPECT().GetAttachmentById(gomock.Any(), uint(5)).Return(&entity.Attachment{ID: 5, TodoID: 1, SHA256: "abc"}, nil)
+				r.EXPECT().DeleteAttachment(gomock.Any(), uint(5)).Return(nil)
+				r.EXPECT().LockDigest(gomock.Any(), "abc").Return(nil)
+				r.EXPECT().IsReferenced(gomock.Any(), "abc").Return(true, nil)
+			},
+			wantBlob: true,
+		},
+		{
+			name:   "OTHER TASK",
+			todoID: 2,
+			mockBehaviour: func(r *mock_entity.MockAttachmentRepository) {
+				r.EXPECT().GetAttachmentById(gomock.Any(), uint(5)).Return(&entity.Attachment{ID: 5, TodoID: 1, SHA256: "abc"}, nil)
+			},
+			wantBlob: true,
+			wantErr:  entity.ErrAttachmentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockAttachmentRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+			testCase.mockBehaviour(repo)
+
+			store, err := blobstore.NewFilesystem(t.TempDir())
+			require.NoError(t, err)
+			require.NoError(t, store.Put(context.Background(), "abc", strings.NewReader("log"), 3))
+
+			useCase := NewAttachmentUseCase(repo, nil, store, entity.Attachments{}, logger.New("info"))
+
+			err = useCase.DeleteAttachment(context.Background(), testCase.todoID, 5)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			exists, err := store.Exists(context.Background(), "abc")
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantBlob, exists)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var _ entity.AuditUseCase = (*AuditUseCase)(nil)
+
+type AuditUseCase struct {
+	repo  entity.AuditRepository
+	tasks entity.TodoRepository
+	l     logger.Interface
+}
+
+func NewAuditUseCase(repo entity.AuditRepository, tasks entity.TodoRepository, l logger.Interface) entity.AuditUseCase {
+	return &AuditUseCase{repo: repo, tasks: tasks, l: l}
+}
+
+// GetTaskHistory returns the history of deleted and purged tasks too; a task is only reported as not found
+// when it has no history and does not exist.
+func (a AuditUseCase) GetTaskHistory(ctx context.Context, todoID uint, pagination httpquery.Pagination) (_ []entity.AuditEntry, err error) {
+	ctx, span := startSpan(ctx, "AuditUseCase.GetTaskHistory", attribute.Int64("todo.id", int64(todoID)))
+	defer func() { endSpan(span, err) }()
+
+	entries, err := a.repo.GetTaskHistory(ctx, todoID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 && pagination.Page == 0 {
+		if _, err = a.tasks.GetTaskById(ctx, todoID); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func (a AuditUseCase) GetAuditEntries(ctx context.Context, filter entity.AuditFilter, pagination httpquery.Pagination) (_ []entity.AuditEntry, err error) {
+	ctx, span := startSpan(ctx, "AuditUseCase.GetAuditEntries", attribute.String("audit.actor", filter.Actor))
+	defer func() { endSpan(span, err) }()
+
+	return a.repo.GetAuditEntries(ctx, filter, pagination)
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"time"
+)
+
+// Batch applies the operations in order. Consecutive creates are inserted together, InsertSize tasks per
+// INSERT; updates and deletes go through UpdateTask and DeleteTask, which join the batch transaction when atomic.
+func (t TodoUseCase) Batch(ctx context.Context, operations []entity.BatchOperation, atomic bool) (_ []entity.BatchResult, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.Batch",
+		attribute.Int("batch.size", len(operations)), attribute.Bool("batch.atomic", atomic))
+	defer func() { endSpan(span, err) }()
+
+	if t.batch.MaxOperations > 0 && len(operations) > t.batch.MaxOperations {
+		return nil, fmt.Errorf("%w: %d, the limit is %d", entity.ErrBatchTooLarge, len(operations), t.batch.MaxOperations)
+	}
+
+	results := make([]entity.BatchResult, len(operations))
+	for i, operation := range operations {
+		results[i] = entity.BatchResult{Op: operation.Op, ID: operation.ID, Err: operation.Check()}
+	}
+
+	if !atomic {
+		t.applyBatch(ctx, operations, results, false)
+		t.l.WithContext(ctx).Info("applied batch of %d operations", len(operations))
+		return results, nil
+	}
+
+	// An operation that failed its check fails the whole batch, so nothing is written.
+	for i := range results {
+		if results[i].Err != nil {
+			err = results[i].Err
+			rollBackBatch(operations, results)
+			return results, err
+		}
+	}
+
+	err = t.repo.Transaction(ctx, func(ctx context.Context) error {
+		return t.applyBatch(ctx, operations, results, true)
+	})
+	if err != nil {
+		rollBackBatch(operations, results)
+		return results, err
+	}
+
+	t.l.WithContext(ctx).Info("applied batch of %d operations", len(operations))
+	return results, nil
+}
+
+// rollBackBatch marks the operations that did not fail themselves as rolled back.
+func rollBackBatch(operations []entity.BatchOperation, results []entity.BatchResult) {
+	for i := range results {
+		if results[i].Err == nil {
+			// Created tasks lose the ids they were given along with the rest of the transaction.
+			results[i].ID, results[i].Task = operations[i].ID, nil
+			results[i].Err = entity.ErrBatchRolledBack
+		}
+	}
+}
+
+// applyBatch records the outcome of each operation in its result. When atomic, it stops at the first failure
+// and returns it; otherwise operations that already failed their check are skipped.
+func (t TodoUseCase) applyBatch(ctx context.Context, operations []entity.BatchOperation, results []entity.BatchResult, atomic bool) error {
+	for i := 0; i < len(operations); {
+		if operations[i].Op == entity.BatchCreate {
+			j := i + 1
+			for j < len(operations) && operations[j].Op == entity.BatchCreate {
+				j++
+			}
+			if err := t.batchCreate(ctx, operations[i:j], results[i:j], atomic); err != nil {
+				return err
+			}
+			i = j
+			continue
+		}
+
+		operation, result := operations[i], &results[i]
+		if result.Err == nil {
+			switch operation.Op {
+			case entity.BatchUpdate:
+				result.Task, result.Err = t.UpdateTask(ctx, operation.ID, operation.Version, *operation.Patch)
+			case entity.BatchDelete:
+				result.Err = t.DeleteTask(ctx, operation.ID, operation.Version)
+			}
+		}
+		if atomic && result.Err != nil {
+			return result.Err
+		}
+		i++
+	}
+	return nil
+}
+
+// batchCreate inserts a run of creates together. Outside an atomic batch a failed insert is retried task by
+// task, so that only the creates at fault fail.
+func (t TodoUseCase) batchCreate(ctx context.Context, operations []entity.BatchOperation, results []entity.BatchResult, atomic bool) error {
+	tasks := make([]*entity.Todo, 0, len(operations))
+	positions := make([]int, 0, len(operations))
+	for i, operation := range operations {
+		if results[i].Err == nil {
+			results[i].Err = t.prepareTask(ctx, operation.Task)
+		}
+		if results[i].Err != nil {
+			if atomic {
+				return results[i].Err
+			}
+			continue
+		}
+		tasks = append(tasks, operation.Task)
+		positions = append(positions, i)
+	}
+
+	if err := t.insertTasks(ctx, tasks); err != nil {
+		if atomic || len(tasks) == 1 {
+			for _, i := range positions {
+				results[i].Err = err
+			}
+		} else {
+			for n, task := range tasks {
+				// Forget the ids and timestamps the failed insert gave the task.
+				task.ID, task.CreatedAt, task.UpdatedAt = 0, time.Time{}, time.Time{}
+				results[positions[n]].Err = t.insertTasks(ctx, []*entity.Todo{task})
+			}
+		}
+		if atomic {
+			return err
+		}
+	}
+
+	for n, task := range tasks {
+		if result := &results[positions[n]]; result.Err == nil {
+			result.ID, result.Task = task.ID, task
+		}
+	}
+	return nil
+}
+
+// insertTasks writes prepared tasks along with their audit entries in one transaction.
+func (t TodoUseCase) insertTasks(ctx context.Context, tasks []*entity.Todo) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	size := t.batch.InsertSize
+	if size <= 0 {
+		size = entity.DefaultInsertSize
+	}
+
+	return t.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := t.repo.SaveTasks(ctx, tasks, size); err != nil {
+			return err
+		}
+		entries := make([]entity.AuditEntry, 0, len(tasks))
+		for _, task := range tasks {
+			entries = append(entries, auditEntry(ctx, entity.AuditCreate, task.ID, entity.DiffTodos(nil, task)))
+		}
+		return t.audit.SaveAuditEntries(ctx, entries, size)
+	})
+}
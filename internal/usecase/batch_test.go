@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// saveTasks gives the inserted tasks ids from next on.
+func saveTasks(next uint) func(context.Context, []*entity.Todo, int) error {
+	return func(_ context.Context, tasks []*entity.Todo, _ int) error {
+		for _, task := range tasks {
+			task.ID = next
+			next++
+		}
+		return nil
+	}
+}
+
+func TestTodoUseCase_Batch(t *testing.T) {
+	description := "new text"
+	errDuplicate := errors.New("duplicate key")
+
+	testTable := []struct {
+		name          string
+		operations    []entity.BatchOperation
+		atomic        bool
+		mockBehaviour func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository)
+		wantErr       error
+		wantResults   []error
+		wantIDs       []uint
+	}{
+		{
+			name: "ATOMIC",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchCreate, Task: &entity.Todo{Description: "a"}},
+				{Op: entity.BatchCreate, Task: &entity.Todo{Description: "b"}},
+				{Op: entity.BatchUpdate, ID: 1, Patch: &entity.TodoPatch{Description: &description}},
+				{Op: entity.BatchDelete, ID: 2, Version: 3},
+			},
+			atomic: true,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository) {
+				r.EXPECT().SaveTasks(gomock.Any(), gomock.Len(2), 50).DoAndReturn(saveTasks(10))
+				a.EXPECT().SaveAuditEntries(gomock.Any(), gomock.Len(2), 50).Return(nil)
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Description: "a", Status: entity.StatusOpen}, nil)
+				r.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+				r.EXPECT().CountChildren(gomock.Any(), uint(2)).Return(int64(0), nil)
+				r.EXPECT().DeleteTask(gomock.Any(), uint(2), uint(3)).Return(nil)
+			},
+			wantResults: []error{nil, nil, nil, nil},
+			wantIDs:     []uint{10, 11, 1, 2},
+		},
+		{
+			name: "ATOMIC ROLLED BACK",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchUpdate, ID: 1, Patch: &entity.TodoPatch{Description: &description}},
+				{Op: entity.BatchDelete, ID: 2},
+				{Op: entity.BatchCreate, Task: &entity.Todo{Description: "a"}},
+			},
+			atomic: true,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository) {
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: entity.StatusOpen}, nil)
+				r.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+				r.EXPECT().CountChildren(gomock.Any(), uint(2)).Return(int64(0), nil)
+				r.EXPECT().DeleteTask(gomock.Any(), uint(2), entity.AnyVersion).Return(entity.ErrTaskNotFound)
+			},
+			wantErr:     entity.ErrTaskNotFound,
+			wantResults: []error{entity.ErrBatchRolledBack, entity.ErrTaskNotFound, entity.ErrBatchRolledBack},
+			wantIDs:     []uint{1, 2, 0},
+		},
+		{
+			name: "ATOMIC INVALID OPERATION",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchCreate, Task: &entity.Todo{Description: "a"}},
+				{Op: entity.BatchUpdate, ID: 1},
+			},
+			atomic:        true,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository) {},
+			wantErr:       entity.ErrInvalidOperation,
+			wantResults:   []error{entity.ErrBatchRolledBack, entity.ErrInvalidOperation},
+			wantIDs:       []uint{0, 1},
+		},
+		{
+			name: "PARTIAL",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchUpdate, ID: 1},
+				{Op: entity.BatchCreate, Task: &entity.Todo{Description: "a", Status: "unknown"}},
+				{Op: entity.BatchCreate, Task: &entity.Todo{Description: "b"}},
+				{Op: entity.BatchDelete, ID: 2},
+			},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository) {
+				r.EXPECT().SaveTasks(gomock.Any(), gomock.Len(1), 50).DoAndReturn(saveTasks(10))
+				a.EXPECT().SaveAuditEntries(gomock.Any(), gomock.Len(1), 50).Return(nil)
+				r.EXPECT().CountChildren(gomock.Any(), uint(2)).Return(int64(1), nil)
+			},
+			wantResults: []error{entity.ErrInvalidOperation, entity.ErrUnknownStatus, nil, entity.ErrHasChildren},
+			wantIDs:     []uint{1, 0, 10, 2},
+		},
+		{
+			name: "FAILED INSERT RETRIED ONE BY ONE",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchCreate, Task: &entity.Todo{Description: "a"}},
+				{Op: entity.BatchCreate, Task: &entity.Todo{Description: "b"}},
+			},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository) {
+				gomock.InOrder(
+					r.EXPECT().SaveTasks(gomock.Any(), gomock.Len(2), 50).Return(errDuplicate),
+					r.EXPECT().SaveTasks(gomock.Any(), gomock.Len(1), 50).DoAndReturn(saveTasks(10)),
+					r.EXPECT().SaveTasks(gomock.Any(), gomock.Len(1), 50).Return(errDuplicate),
+				)
+				a.EXPECT().SaveAuditEntries(gomock.Any(), gomock.Len(1), 50).Return(nil)
+			},
+			wantResults: []error{nil, errDuplicate},
+			wantIDs:     []uint{10, 0},
+		},
+		{
+			name: "FAILED INSERT",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchCreate, Task: &entity.Todo{Description: "a"}},
+				{Op: entity.BatchDelete, ID: 2},
+			},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository) {
+				r.EXPECT().SaveTasks(gomock.Any(), gomock.Len(1), 50).Return(errDuplicate)
+				r.EXPECT().CountChildren(gomock.Any(), uint(2)).Return(int64(0), nil)
+				r.EXPECT().DeleteTask(gomock.Any(), uint(2), entity.AnyVersion).Return(nil)
+			},
+			wantResults: []error{errDuplicate, nil},
+			wantIDs:     []uint{0, 2},
+		},
+		{
+			name: "TOO LARGE",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchDelete, ID: 1},
+				{Op: entity.BatchDelete, ID: 2},
+				{Op: entity.BatchDelete, ID: 3},
+				{Op: entity.BatchDelete, ID: 4},
+				{Op: entity.BatchDelete, ID: 5},
+			},
+			atomic:        true,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository) {},
+			wantErr:       entity.ErrBatchTooLarge,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).AnyTimes()
+			audit := mock_entity.NewMockAuditRepository(ctrl)
+			audit.EXPECT().SaveAuditEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			testCase.mockBehaviour(repo, audit)
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, audit, entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"),
+				Batches(entity.Batch{MaxOperations: 4, InsertSize: 50}))
+
+			results, err := useCase.Batch(context.Background(), testCase.operations, testCase.atomic)
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Len(t, results, len(testCase.wantResults))
+			for i, result := range results {
+				if testCase.wantResults[i] != nil {
+					assert.ErrorIs(t, result.Err, testCase.wantResults[i], "result %d", i)
+					assert.Nil(t, result.Task, "result %d", i)
+				} else {
+					assert.NoError(t, result.Err, "result %d", i)
+				}
+				assert.Equal(t, testCase.wantIDs[i], result.ID, "result %d", i)
+			}
+		})
+	}
+}
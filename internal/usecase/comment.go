@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var _ entity.CommentUseCase = (*CommentUseCase)(nil)
+
+type CommentUseCase struct {
+	repo  entity.CommentRepository
+	tasks entity.TodoRepository
+	l     logger.Interface
+}
+
+func NewCommentUseCase(repo entity.CommentRepository, tasks entity.TodoRepository, l logger.Interface) entity.CommentUseCase {
+	return &CommentUseCase{repo: repo, tasks: tasks, l: l}
+}
+
+func (c CommentUseCase) GetComments(ctx context.Context, todoID uint, pagination httpquery.Pagination) (_ []entity.Comment, err error) {
+	ctx, span := startSpan(ctx, "CommentUseCase.GetComments", attribute.Int64("todo.id", int64(todoID)))
+	defer func() { endSpan(span, err) }()
+
+	if _, err = c.tasks.GetTaskById(ctx, todoID); err != nil {
+		return nil, err
+	}
+
+	return c.repo.GetComments(ctx, todoID, pagination)
+}
+
+func (c CommentUseCase) GetComment(ctx context.Context, todoID, id uint) (_ *entity.Comment, err error) {
+	ctx, span := startSpan(ctx, "CommentUseCase.GetComment",
+		attribute.Int64("todo.id", int64(todoID)), attribute.Int64("comment.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	return c.comment(ctx, todoID, id)
+}
+
+// SaveComment posts the comment as the authenticated user. A reply must answer a top-level comment of the same task.
+func (c CommentUseCase) SaveComment(ctx context.Context, comment *entity.Comment) (err error) {
+	ctx, span := startSpan(ctx, "CommentUseCase.SaveComment", attribute.Int64("todo.id", int64(comment.TodoID)))
+	defer func() { endSpan(span, err) }()
+
+	author, ok := entity.UserFromContext(ctx)
+	if !ok {
+		return entity.ErrAnonymous
+	}
+
+	if _, err = c.tasks.GetTaskById(ctx, comment.TodoID); err != nil {
+		return err
+	}
+
+	if comment.ParentID != nil {
+		parent, err := c.comment(ctx, comment.TodoID, *comment.ParentID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", entity.ErrInvalidComment, err)
+		}
+		if parent.ParentID != nil {
+			return fmt.Errorf("%w: comment %d is a reply itself", entity.ErrInvalidComment, parent.ID)
+		}
+	}
+
+	comment.AuthorID = author.ID
+	comment.Author = &author
+	comment.Replies = nil
+	if err = c.repo.SaveComment(ctx, comment); err != nil {
+		return err
+	}
+
+	c.l.WithContext(ctx).Info("success creating comment")
+	return nil
+}
+
+func (c CommentUseCase) UpdateComment(ctx context.Context, todoID, id uint, body string) (_ *entity.Comment, err error) {
+	ctx, span := startSpan(ctx, "CommentUseCase.UpdateComment",
+		attribute.Int64("todo.id", int64(todoID)), attribute.Int64("comment.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	comment, err := c.authored(ctx, todoID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Body = body
+	if err = c.repo.UpdateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	c.l.WithContext(ctx).Info("success updating comment")
+	return comment, nil
+}
+
+func (c CommentUseCase) DeleteComment(ctx context.Context, todoID, id uint) (err error) {
+	ctx, span := startSpan(ctx, "CommentUseCase.DeleteComment",
+		attribute.Int64("todo.id", int64(todoID)), attribute.Int64("comment.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	if _, err = c.authored(ctx, todoID, id); err != nil {
+		return err
+	}
+
+	if err = c.repo.DeleteComment(ctx, id); err != nil {
+		return err
+	}
+
+	c.l.WithContext(ctx).Info("success deleting comment")
+	return nil
+}
+
+// comment returns the comment when it belongs to the task; comments of other tasks are reported as not found.
+func (c CommentUseCase) comment(ctx context.Context, todoID, id uint) (*entity.Comment, error) {
+	comment, err := c.repo.GetCommentById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TodoID != todoID {
+		return nil, fmt.Errorf("%w: %d", entity.ErrCommentNotFound, id)
+	}
+	return comment, nil
+}
+
+// authored returns the comment when the authenticated user wrote it.
+func (c CommentUseCase) authored(ctx context.Context, todoID, id uint) (*entity.Comment, error) {
+	user, ok := entity.UserFromContext(ctx)
+	if !ok {
+		return nil, entity.ErrAnonymous
+	}
+
+	comment, err := c.comment(ctx, todoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != user.ID {
+		return nil, fmt.Errorf("%w: comment %d", entity.ErrNotCommentAuthor, id)
+	}
+	return comment, nil
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestCommentUseCase_SaveComment(t *testing.T) {
+	author := entity.User{Model: gorm.Model{ID: 7}, Username: "admin"}
+	parentID := uint(10)
+
+	testTable := []struct {
+		name          string
+		ctx           context.Context
+		inputEntity   *entity.Comment
+		mockBehaviour func(r *mock_entity.MockCommentRepository, tasks *mock_entity.MockTodoRepository)
+		wantErr       error
+	}{
+		{
+			name:        "OK",
+			ctx:         entity.ContextWithUser(context.Background(), author),
+			inputEntity: &entity.Comment{TodoID: 1, Body: "Looks good"},
+			mockBehaviour: func(r *mock_entity.MockCommentRepository, tasks *mock_entity.MockTodoRepository) {
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().SaveComment(gomock.Any(), &entity.Comment{TodoID: 1, AuthorID: 7, Author: &author, Body: "Looks good"}).Return(nil)
+			},
+		},
+		{
+			name:        "REPLY",
+			ctx:         entity.ContextWithUser(context.Background(), author),
+			inputEntity: &entity.Comment{TodoID: 1, Body: "Agreed", ParentID: &parentID},
+			mockBehaviour: func(r *mock_entity.MockCommentRepository, tasks *mock_entity.MockTodoRepository) {
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().GetCommentById(gomock.Any(), parentID).Return(&entity.Comment{Model: gorm.Model{ID: parentID}, TodoID: 1}, nil)
+				r.EXPECT().SaveComment(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "REPLY TO REPLY",
+			ctx:         entity.ContextWithUser(context.Background(), author),
+			inputEntity: &entity.Comment{TodoID: 1, Body: "Agreed", ParentID: &parentID},
+			mockBehaviour: func(r *mock_entity.MockCommentRepository, tasks *mock_entity.MockTodoRepository) {
+				grandparent := uint(9)
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().GetCommentById(gomock.Any(), parentID).
+					Return(&entity.Comment{Model: gorm.Model{ID: parentID}, TodoID: 1, ParentID: &grandparent}, nil)
+			},
+			wantErr: entity.ErrInvalidComment,
+		},
+		{
+			name:        "REPLY TO OTHER TASK",
+			ctx:         entity.ContextWithUser(context.Background(), author),
+			inputEntity: &entity.Comment{TodoID: 1, Body: "Agreed", ParentID: &parentID},
+			mockBehaviour: func(r *mock_entity.MockCommentRepository, tasks *mock_entity.MockTodoRepository) {
+				tasks.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().GetCommentById(gomock.Any(), parentID).Return(&entity.Comment{Model: gorm.Model{ID: parentID}, TodoID: 2}, nil)
+			},
+			wantErr: entity.ErrInvalidComment,
+		},
+		{
+			name:          "ANONYMOUS",
+			ctx:           context.Background(),
+			inputEntity:   &entity.Comment{TodoID: 1, Body: "Looks good"},
+			mockBehaviour: func(r *mock_entity.MockCommentRepository, tasks *mock_entity.MockTodoRepository) {},
+			wantErr:       entity.ErrAnonymous,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockCommentRepository(ctrl)
+			tasks := mock_entity.NewMockTodoRepository(ctrl)
+			testCase.mockBehaviour(repo, tasks)
+
+			useCase := NewCommentUseCase(repo, tasks, logger.New("info"))
+
+			err := useCase.SaveComment(testCase.ctx, testCase.inputEntity)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCommentUseCase_DeleteComment(t *testing.T) {
+	author := entity.User{Model: gorm.Model{ID: 7}, Username: "admin"}
+	other := entity.User{Model: gorm.Model{ID: 8}, Username: "bob"}
+
+	testTable := []struct {
+		name          string
+		user          entity.User
+		todoID        uint
+		mockBehaviour func(r *mock_entity.MockCommentRepository)
+		wantErr       error
+	}{
+		{
+			name:   "OK",
+			user:   author,
+			todoID: 1,
+			mockBehaviour: func(r *mock_entity.MockCommentRepository) {
+				r.EXPECT().GetCommentById(gomock.Any(), uint(10)).Return(&entity.Comment{Model: gorm.Model{ID: 10}, TodoID: 1, AuthorID: 7}, nil)
+				r.EXPECT().DeleteComment(gomock.Any(), uint(10)).Return(nil)
+			},
+		},
+		{
+			name:   "NOT AUTHOR",
+			user:   other,
+			todoID: 1,
+			mockBehaviour: func(r *mock_entity.MockCommentRepository) {
+				r.EXPECT().GetCommentById(gomock.Any(), uint(10)).Return(&entity.Comment{Model: gorm.Model{ID: 10}, TodoID: 1, AuthorID: 7}, nil)
+			},
+			wantErr: entity.ErrNotCommentAuthor,
+		},
+		{
+			name:   "OTHER TASK",
+			user:   author,
+			todoID: 2,
+			mockBehaviour: func(r *mock_entity.MockCommentRepository) {
+				r.EXPECT().GetCommentById(gomock.Any(), uint(10)).Return(&entity.Comment{Model: gorm.Model{ID: 10}, TodoID: 1, AuthorID: 7}, nil)
+			},
+			wantErr: entity.ErrCommentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockCommentRepository(ctrl)
+			testCase.mockBehaviour(repo)
+
+			useCase := NewCommentUseCase(repo, nil, logger.New("info"))
+
+			err := useCase.DeleteComment(entity.ContextWithUser(context.Background(), testCase.user), testCase.todoID, 10)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (t TodoUseCase) ExportTasks(ctx context.Context, filters []httpquery.FilterOption, fn func(tasks []entity.Todo) error) (err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.ExportTasks")
+	defer func() { endSpan(span, err) }()
+
+	if filters, err = resolveMe(ctx, filters); err != nil {
+		return err
+	}
+
+	count := 0
+	err = t.repo.StreamTasks(ctx, filters, func(tasks []entity.Todo) error {
+		count += len(tasks)
+		return fn(tasks)
+	})
+	if err != nil {
+		return err
+	}
+
+	t.l.WithContext(ctx).Info("exported %d tasks", count)
+	return nil
+}
+
+// ImportTasks creates the tasks the way a non-atomic batch of creates does: they are inserted together, and
+// one by one when that fails. A dry run checks each task as SaveTask would and writes nothing.
+func (t TodoUseCase) ImportTasks(ctx context.Context, tasks []*entity.Todo, dryRun bool) (_ []entity.BatchResult, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.ImportTasks",
+		attribute.Int("import.size", len(tasks)), attribute.Bool("import.dry_run", dryRun))
+	defer func() { endSpan(span, err) }()
+
+	results := make([]entity.BatchResult, len(tasks))
+	if dryRun {
+		for i, task := range tasks {
+			results[i] = entity.BatchResult{Op: entity.BatchCreate, Task: task, Err: t.prepareTask(ctx, task)}
+			if results[i].Err != nil {
+				results[i].Task = nil
+			}
+		}
+		t.l.WithContext(ctx).Info("checked %d tasks to import", len(tasks))
+		return results, nil
+	}
+
+	operations := make([]entity.BatchOperation, len(tasks))
+	for i, task := range tasks {
+		operations[i] = entity.BatchOperation{Op: entity.BatchCreate, Task: task}
+		results[i] = entity.BatchResult{Op: entity.BatchCreate}
+	}
+	if err = t.batchCreate(ctx, operations, results, false); err != nil {
+		return nil, err
+	}
+
+	t.l.WithContext(ctx).Info("imported %d tasks", len(tasks))
+	return results, nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/httpquery"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestTodoUseCase_ExportTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mock_entity.NewMockTodoRepository(ctrl)
+	repo.EXPECT().StreamTasks(gomock.Any(), []httpquery.FilterOption{{Field: "assignee", Value: "7"}}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ []httpquery.FilterOption, fn func([]entity.Todo) error) error {
+			if err := fn([]entity.Todo{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}}}); err != nil {
+				return err
+			}
+			return fn([]entity.Todo{{Model: gorm.Model{ID: 3}}})
+		})
+
+	useCase := NewTodoUseCase(repo, nil, nil, nil, nil, entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+	ctx := entity.ContextWithUser(context.Background(), entity.User{Model: gorm.Model{ID: 7}, Username: "bob"})
+	var ids []uint
+	err := useCase.ExportTasks(ctx, []httpquery.FilterOption{{Field: "assignee", Value: "me"}}, func(tasks []entity.Todo) error {
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 2, 3}, ids)
+}
+
+func TestTodoUseCase_ImportTasks(t *testing.T) {
+	testTable := []struct {
+		name          string
+		dryRun        bool
+		mockBehaviour func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository)
+		wantIDs       []uint
+	}{
+		{
+			name:   "DRY RUN",
+			dryRun: true,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository) {
+			},
+			wantIDs: []uint{0, 0},
+		},
+		{
+			name: "IMPORT",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, a *mock_entity.MockAuditRepository) {
+				r.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				r.EXPECT().SaveTasks(gomock.Any(), gomock.Len(1), entity.DefaultInsertSize).DoAndReturn(saveTasks(10))
+				a.EXPECT().SaveAuditEntries(gomock.Any(), gomock.Len(1), entity.DefaultInsertSize).Return(nil)
+			},
+			wantIDs: []uint{10, 0},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			audit := mock_entity.NewMockAuditRepository(ctrl)
+			testCase.mockBehaviour(repo, audit)
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, audit, entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			tasks := []*entity.Todo{{Description: "a"}, {Description: "b", Status: "later"}}
+			results, err := useCase.ImportTasks(context.Background(), tasks, testCase.dryRun)
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+
+			assert.NoError(t, results[0].Err)
+			assert.Equal(t, entity.StatusOpen, results[0].Task.Status)
+			assert.ErrorIs(t, results[1].Err, entity.ErrUnknownStatus)
+			assert.Nil(t, results[1].Task)
+			for i, result := range results {
+				assert.Equal(t, testCase.wantIDs[i], result.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"time"
+)
+
+var _ entity.IdempotencyUseCase = (*IdempotencyUseCase)(nil)
+
+type IdempotencyUseCase struct {
+	repo  entity.IdempotencyRepository
+	ttl   time.Duration
+	lease time.Duration
+	l     logger.Interface
+}
+
+// NewIdempotencyUseCase keeps each key for ttl after its first request. A request in progress holds its key for
+// lease, ttl when lease is not positive; a retry after that takes the key over.
+func NewIdempotencyUseCase(repo entity.IdempotencyRepository, ttl, lease time.Duration, l logger.Interface) entity.IdempotencyUseCase {
+	if lease <= 0 || lease > ttl {
+		lease = ttl
+	}
+	return &IdempotencyUseCase{repo: repo, ttl: ttl, lease: lease, l: l}
+}
+
+func (i IdempotencyUseCase) Begin(ctx context.Context, key, requestHash string) (_ *entity.IdempotencyKey, err error) {
+	ctx, span := startSpan(ctx, "IdempotencyUseCase.Begin", attribute.String("idempotency.key", key))
+	defer func() { endSpan(span, err) }()
+
+	actor := entity.ActorFromContext(ctx)
+	now := time.Now()
+
+	// The key is reserved again when the request that held it released it, when its lease lapsed or when it
+	// expired; one more attempt is enough, as a key only goes away once per request.
+	for attempt := 0; attempt < 2; attempt++ {
+		lockedUntil := now.Add(i.lease)
+		reserved := &entity.IdempotencyKey{
+			Actor:       actor,
+			Key:         key,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(i.ttl),
+			LockedUntil: &lockedUntil,
+		}
+		created, err := i.repo.SaveIdempotencyKey(ctx, reserved)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			return reserved, nil
+		}
+
+		stored, err := i.repo.GetIdempotencyKey(ctx, actor, key)
+		if errors.Is(err, entity.ErrIdempotencyKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !stored.ExpiresAt.After(now) || !stored.Done() && !stored.Locked(now) {
+			taken, err := i.repo.TakeOverIdempotencyKey(ctx, stored.ID, now)
+			if err != nil {
+				return nil, err
+			}
+			if taken {
+				continue
+			}
+
+			// The request that held the key finished, or another retry took it over, since it was read.
+			stored, err = i.repo.GetIdempotencyKey(ctx, actor, key)
+			if errors.Is(err, entity.ErrIdempotencyKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if stored.RequestHash != requestHash {
+			return nil, fmt.Errorf("%w: %q", entity.ErrIdempotencyKeyReused, key)
+		}
+		if !stored.Done() {
+			return nil, fmt.Errorf("%w: %q", entity.ErrRequestInProgress, key)
+		}
+
+		i.l.WithContext(ctx).Info("replaying idempotent request")
+		return stored, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", entity.ErrRequestInProgress, key)
+}
+
+func (i IdempotencyUseCase) Finish(ctx context.Context, key *entity.IdempotencyKey, statusCode int, headers map[string]string, response []byte) (err error) {
+	ctx, span := startSpan(ctx, "IdempotencyUseCase.Finish", attribute.String("idempotency.key", key.Key))
+	defer func() { endSpan(span, err) }()
+
+	if statusCode >= http.StatusInternalServerError {
+		return i.repo.DeleteIdempotencyKey(ctx, key.ID)
+	}
+
+	key.StatusCode = statusCode
+	key.Headers = headers
+	key.Response = response
+	return i.repo.CompleteIdempotencyKey(ctx, key)
+}
+
+func (i IdempotencyUseCase) PurgeExpired(ctx context.Context) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "IdempotencyUseCase.PurgeExpired")
+	defer func() { endSpan(span, err) }()
+
+	purged, err := i.repo.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		i.l.WithContext(ctx).Info("purged %d expired idempotency keys", purged)
+	}
+	return purged, nil
+}
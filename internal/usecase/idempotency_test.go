@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyUseCase_Begin(t *testing.T) {
+	admin := entity.User{Model: gorm.Model{ID: 7}, Username: "admin"}
+	later := time.Now().Add(time.Hour)
+	done := &entity.IdempotencyKey{ID: 1, Actor: "admin", Key: "a1", RequestHash: "abc", StatusCode: 200, Response: []byte(`{"id":1}`), ExpiresAt: later}
+
+	testTable := []struct {
+		name          string
+		mockBehaviour func(r *mock_entity.MockIdempotencyRepository)
+		wantKey       *entity.IdempotencyKey
+		wantNew       bool
+		wantErr       error
+	}{
+		{
+			name: "NEW KEY",
+			mockBehaviour: func(r *mock_entity.MockIdempotencyRepository) {
+				r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key *entity.IdempotencyKey) (bool, error) {
+					assert.Equal(t, "admin", key.Actor)
+					assert.Equal(t, "abc", key.RequestHash)
+					assert.Equal(t, 24*time.Hour, key.ExpiresAt.Sub(key.CreatedAt))
+					assert.Equal(t, time.Minute, key.LockedUntil.Sub(key.CreatedAt))
+					key.ID = 2
+					return true, nil
+				})
+			},
+			wantNew: true,
+		},
+		{
+			name: "RETRY",
+			mockBehaviour: func(r *mock_entity.MockIdempotencyRepository) {
+				r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil)
+				r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(done, nil)
+			},
+			wantKey: done,
+		},
+		{
+			name: "DIFFERENT REQUEST",
+			mockBehaviour: func(r *mock_entity.MockIdempotencyRepository) {
+				r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil)
+				r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(&entity.IdempotencyKey{ID: 1, RequestHash: "def", StatusCode: 200, ExpiresAt: later}, nil)
+			},
+			wantErr: entity.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "IN PROGRESS",
+			mockBehaviour: func(r *mock_entity.MockIdempotencyRepository) {
+				r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil)
+				r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(&entity.IdempotencyKey{ID: 1, RequestHash: "abc", ExpiresAt: later, LockedUntil: &later}, nil)
+			},
+			wantErr: entity.ErrRequestInProgress,
+		},
+		{
+			name: "LEASE LAPSED",
+			mockBehaviour: func(r *mock_entity.MockIdempotencyRepository) {
+				lapsed := time.Now().Add(-time.Second)
+				gomock.InOrder(
+					r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil),
+					r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(&entity.IdempotencyKey{ID: 1, RequestHash: "abc", ExpiresAt: later, LockedUntil: &lapsed}, nil),
+					r.EXPECT().TakeOverIdempotencyKey(gomock.Any(), uint(1), gomock.Any()).Return(true, nil),
+					r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(true, nil),
+				)
+			},
+			wantNew: true,
+		},
+		{
+			name: "LEASE LAPSED BUT COMPLETED",
+			mockBehaviour: func(r *mock_entity.MockIdempotencyRepository) {
+				lapsed := time.Now().Add(-time.Second)
+				gomock.InOrder(
+					r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil),
+					r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(&entity.IdempotencyKey{ID: 1, RequestHash: "abc", ExpiresAt: later, LockedUntil: &lapsed}, nil),
+					r.EXPECT().TakeOverIdempotencyKey(gomock.Any(), uint(1), gomock.Any()).Return(false, nil),
+					r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(done, nil),
+				)
+			},
+			wantKey: done,
+		},
+		{
+			name: "LEASE LAPSED AND TAKEN OVER",
+			mockBehaviour: func(r *mock_entity.MockIdempotencyRepository) {
+				lapsed := time.Now().Add(-time.Second)
+				gomock.InOrder(
+					r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil),
+					r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(&entity.IdempotencyKey{ID: 1, RequestHash: "abc", ExpiresAt: later, LockedUntil: &lapsed}, nil),
+					r.EXPECT().TakeOverIdempotencyKey(gomock.Any(), uint(1), gomock.Any()).Return(false, nil),
+					r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(&entity.IdempotencyKey{ID: 2, RequestHash: "abc", ExpiresAt: later, LockedUntil: &later}, nil),
+				)
+			},
+			wantErr: entity.ErrRequestInProgress,
+		},
+		{
+			name: "EXPIRED",
+			mockBehaviour: func(r *mock_entity.MockIdempotencyRepository) {
+				gomock.InOrder(
+					r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil),
+					r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(&entity.IdempotencyKey{ID: 1, RequestHash: "def", StatusCode: 200, ExpiresAt: time.Now().Add(-time.Minute)}, nil),
+					r.EXPECT().TakeOverIdempotencyKey(gomock.Any(), uint(1), gomock.Any()).Return(true, nil),
+					r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(true, nil),
+				)
+			},
+			wantNew: true,
+		},
+		{
+			name: "RELEASED",
+			mockBehaviour: func(r *mock_entity.MockIdempotencyRepository) {
+				gomock.InOrder(
+					r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil),
+					r.EXPECT().GetIdempotencyKey(gomock.Any(), "admin", "a1").Return(nil, fmt.Errorf("%w: %q", entity.ErrIdempotencyKeyNotFound, "a1")),
+					r.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(true, nil),
+				)
+			},
+			wantNew: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockIdempotencyRepository(ctrl)
+			testCase.mockBehaviour(repo)
+
+			useCase := NewIdempotencyUseCase(repo, 24*time.Hour, time.Minute, logger.New("info"))
+
+			key, err := useCase.Begin(entity.ContextWithUser(context.Background(), admin), "a1", "abc")
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if testCase.wantNew {
+				assert.False(t, key.Done())
+				assert.Equal(t, "a1", key.Key)
+				return
+			}
+			assert.Equal(t, testCase.wantKey, key)
+		})
+	}
+}
+
+func TestIdempotencyUseCase_Finish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mock_entity.NewMockIdempotencyRepository(ctrl)
+	repo.EXPECT().CompleteIdempotencyKey(gomock.Any(), &entity.IdempotencyKey{
+		ID: 1, Key: "a1", StatusCode: 200, Headers: map[string]string{"Content-Type": "application/json"}, Response: []byte(`{"id":1}`),
+	}).Return(nil)
+	repo.EXPECT().DeleteIdempotencyKey(gomock.Any(), uint(2)).Return(nil)
+
+	useCase := NewIdempotencyUseCase(repo, time.Hour, time.Minute, logger.New("info"))
+
+	assert.NoError(t, useCase.Finish(context.Background(), &entity.IdempotencyKey{ID: 1, Key: "a1"}, 200,
+		map[string]string{"Content-Type": "application/json"}, []byte(`{"id":1}`)))
+	// A server error releases the key rather than storing the failure.
+	assert.NoError(t, useCase.Finish(context.Background(), &entity.IdempotencyKey{ID: 2, Key: "a2"}, 503, nil, nil))
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/blobstore"
+	"time"
+)
+
+// Option -.
+type Option func(*TodoUseCase)
+
+// Clock replaces time.Now, which decides when the next occurrence of a recurring task falls.
+func Clock(now func() time.Time) Option {
+	return func(t *TodoUseCase) {
+		t.now = now
+	}
+}
+
+// Attachments lets PurgeTask remove the content of the attachments of a purged task from the blob store.
+func Attachments(repo entity.AttachmentRepository, store blobstore.BlobStore) Option {
+	return func(t *TodoUseCase) {
+		t.attachments = repo
+		t.blobs = store
+	}
+}
+
+// Batches sets the limits of Batch.
+func Batches(limits entity.Batch) Option {
+	return func(t *TodoUseCase) {
+		t.batch = limits
+	}
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var _ entity.ProjectUseCase = (*ProjectUseCase)(nil)
+
+type ProjectUseCase struct {
+	repo     entity.ProjectRepository
+	workflow entity.Workflow
+	l        logger.Interface
+}
+
+func NewProjectUseCase(repo entity.ProjectRepository, workflow entity.Workflow, l logger.Interface) entity.ProjectUseCase {
+	return &ProjectUseCase{repo: repo, workflow: workflow, l: l}
+}
+
+func (p ProjectUseCase) GetProjects(ctx context.Context, includeArchived bool) (_ []entity.Project, err error) {
+	ctx, span := startSpan(ctx, "ProjectUseCase.GetProjects", attribute.Bool("project.include_archived", includeArchived))
+	defer func() { endSpan(span, err) }()
+
+	return p.repo.GetProjects(ctx, includeArchived)
+}
+
+func (p ProjectUseCase) GetProjectById(ctx context.Context, id uint) (_ *entity.Project, err error) {
+	ctx, span := startSpan(ctx, "ProjectUseCase.GetProjectById", attribute.Int64("project.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	return p.repo.GetProjectById(ctx, id)
+}
+
+func (p ProjectUseCase) SaveProject(ctx context.Context, project *entity.Project) (err error) {
+	ctx, span := startSpan(ctx, "ProjectUseCase.SaveProject")
+	defer func() { endSpan(span, err) }()
+
+	if err = p.checkDefaults(project); err != nil {
+		return err
+	}
+
+	if err = p.repo.SaveProject(ctx, project); err != nil {
+		return err
+	}
+
+	p.l.WithContext(ctx).Info("success creating project")
+	return nil
+}
+
+func (p ProjectUseCase) UpdateProject(ctx context.Context, id uint, patch entity.ProjectPatch) (_ *entity.Project, err error) {
+	ctx, span := startSpan(ctx, "ProjectUseCase.UpdateProject", attribute.Int64("project.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	project, err := p.repo.GetProjectById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patch.Apply(project)
+	if err = p.checkDefaults(project); err != nil {
+		return nil, err
+	}
+
+	if err = p.repo.UpdateProject(ctx, project); err != nil {
+		return nil, err
+	}
+
+	p.l.WithContext(ctx).Info("success updating project")
+	return project, nil
+}
+
+// checkDefaults rejects a default status the workflow does not know.
+func (p ProjectUseCase) checkDefaults(project *entity.Project) error {
+	if project.DefaultStatus != "" {
+		project.DefaultStatus = p.workflow.Canonical(project.DefaultStatus)
+	}
+	if project.DefaultStatus != "" && !p.workflow.IsStatus(project.DefaultStatus) {
+		return fmt.Errorf("%w %q", entity.ErrUnknownStatus, project.DefaultStatus)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestProjectUseCase_UpdateProject(t *testing.T) {
+	archived := true
+	unknown := "triage"
+
+	testTable := []struct {
+		name          string
+		patch         entity.ProjectPatch
+		mockBehaviour func(r *mock_entity.MockProjectRepository)
+		expected      *entity.Project
+		wantErr       error
+	}{
+		{
+			name:  "ARCHIVE",
+			patch: entity.ProjectPatch{Archived: &archived},
+			mockBehaviour: func(r *mock_entity.MockProjectRepository) {
+				r.EXPECT().GetProjectById(gomock.Any(), uint(1)).Return(&entity.Project{Model: gorm.Model{ID: 1}, Name: "backend"}, nil)
+				r.EXPECT().UpdateProject(gomock.Any(), &entity.Project{Model: gorm.Model{ID: 1}, Name: "backend", Archived: true}).Return(nil)
+			},
+			expected: &entity.Project{Model: gorm.Model{ID: 1}, Name: "backend", Archived: true},
+		},
+		{
+			name:  "UNKNOWN DEFAULT STATUS",
+			patch: entity.ProjectPatch{DefaultStatus: &unknown},
+			mockBehaviour: func(r *mock_entity.MockProjectRepository) {
+				r.EXPECT().GetProjectById(gomock.Any(), uint(1)).Return(&entity.Project{Model: gorm.Model{ID: 1}, Name: "backend"}, nil)
+			},
+			wantErr: entity.ErrUnknownStatus,
+		},
+		{
+			name:  "NOT FOUND",
+			patch: entity.ProjectPatch{Archived: &archived},
+			mockBehaviour: func(r *mock_entity.MockProjectRepository) {
+				r.EXPECT().GetProjectById(gomock.Any(), uint(1)).Return(nil, entity.ErrProjectNotFound)
+			},
+			wantErr: entity.ErrProjectNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockProjectRepository(ctrl)
+			testCase.mockBehaviour(repo)
+
+			useCase := NewProjectUseCase(repo, entity.DefaultWorkflow(), logger.New("info"))
+
+			project, err := useCase.UpdateProject(context.Background(), 1, testCase.patch)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, project)
+		})
+	}
+}
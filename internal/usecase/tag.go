@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var _ entity.TagUseCase = (*TagUseCase)(nil)
+
+type TagUseCase struct {
+	repo entity.TagRepository
+	l    logger.Interface
+}
+
+func NewTagUseCase(repo entity.TagRepository, l logger.Interface) entity.TagUseCase {
+	return &TagUseCase{repo: repo, l: l}
+}
+
+func (t TagUseCase) GetTags(ctx context.Context) (_ []entity.Tag, err error) {
+	ctx, span := startSpan(ctx, "TagUseCase.GetTags")
+	defer func() { endSpan(span, err) }()
+
+	return t.repo.GetTags(ctx)
+}
+
+func (t TagUseCase) GetTagById(ctx context.Context, id uint) (_ *entity.Tag, err error) {
+	ctx, span := startSpan(ctx, "TagUseCase.GetTagById", attribute.Int64("tag.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	return t.repo.GetTagById(ctx, id)
+}
+
+func (t TagUseCase) SaveTag(ctx context.Context, tag *entity.Tag) (err error) {
+	ctx, span := startSpan(ctx, "TagUseCase.SaveTag")
+	defer func() { endSpan(span, err) }()
+
+	if err = t.repo.SaveTag(ctx, tag); err != nil {
+		return err
+	}
+
+	t.l.WithContext(ctx).Info("success creating tag")
+	return nil
+}
+
+func (t TagUseCase) UpdateTag(ctx context.Context, tag *entity.Tag) (err error) {
+	ctx, span := startSpan(ctx, "TagUseCase.UpdateTag", attribute.Int64("tag.id", int64(tag.ID)))
+	defer func() { endSpan(span, err) }()
+
+	if err = t.repo.UpdateTag(ctx, tag); err != nil {
+		return err
+	}
+
+	t.l.WithContext(ctx).Info("success updating tag")
+	return nil
+}
+
+func (t TagUseCase) DeleteTag(ctx context.Context, id uint) (err error) {
+	ctx, span := startSpan(ctx, "TagUseCase.DeleteTag", attribute.Int64("tag.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	if err = t.repo.DeleteTag(ctx, id); err != nil {
+		return err
+	}
+
+	t.l.WithContext(ctx).Info("success deleting tag")
+	return nil
+}
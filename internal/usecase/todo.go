@@ -1,47 +1,752 @@
 package usecase
 
 import (
+	"context"
+	"fmt"
 	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/blobstore"
 	"github.com/Vaixle/crud-golang/pkg/httpquery"
 	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/Vaixle/crud-golang/pkg/rrule"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"strconv"
+	"time"
 )
 
 var _ entity.TodoUseCase = (*TodoUseCase)(nil)
 
+const _tracerName = "github.com/Vaixle/crud-golang/internal/usecase"
+
 type TodoUseCase struct {
-	repo entity.TodoRepository
-	l    logger.Interface
+	repo     entity.TodoRepository
+	tags     entity.TagRepository
+	projects entity.ProjectRepository
+	users    entity.UserRepository
+	audit    entity.AuditRepository
+	workflow entity.Workflow
+	subtasks entity.Subtasks
+	batch    entity.Batch
+	l        logger.Interface
+	now      func() time.Time
+
+	attachments entity.AttachmentRepository
+	blobs       blobstore.BlobStore
 }
 
-func NewTodoUseCase(repo entity.TodoRepository, l logger.Interface) entity.TodoUseCase {
-	return &TodoUseCase{repo: repo, l: l}
+func NewTodoUseCase(repo entity.TodoRepository, tags entity.TagRepository, projects entity.ProjectRepository, users entity.UserRepository, audit entity.AuditRepository, workflow entity.Workflow, subtasks entity.Subtasks, l logger.Interface, opts ...Option) entity.TodoUseCase {
+	t := &TodoUseCase{repo: repo, tags: tags, projects: projects, users: users, audit: audit, workflow: workflow, subtasks: subtasks, l: l, now: time.Now}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
 }
 
-func (t TodoUseCase) GetTaskById(id uint) (*entity.Todo, error) {
-	task, err := t.repo.GetTaskById(id)
+func (t TodoUseCase) GetTaskById(ctx context.Context, id uint) (_ *entity.Todo, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.GetTaskById", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	task, err := t.repo.GetTaskById(ctx, id)
 	if err != nil {
 		return task, err
 	}
 
-	t.l.Info("returning task by id")
+	t.l.WithContext(ctx).Info("returning task by id")
 	return task, nil
 }
 
-func (t TodoUseCase) GetTasks(filters []httpquery.FilterOption, pagination httpquery.Pagination) ([]entity.Todo, error) {
-	tasks, err := t.repo.GetTasks(filters, pagination)
+func (t TodoUseCase) GetTasks(ctx context.Context, filters []httpquery.FilterOption, pagination httpquery.Pagination, projection entity.Projection) (_ []entity.Todo, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.GetTasks")
+	defer func() { endSpan(span, err) }()
+
+	if filters, err = resolveMe(ctx, filters); err != nil {
+		return nil, err
+	}
+
+	tasks, err := t.repo.GetTasks(ctx, filters, pagination, projection)
 	if err != nil {
 		return tasks, err
 	}
 
-	t.l.Info("returning tasks")
+	t.l.WithContext(ctx).Info("returning tasks")
 	return tasks, nil
 }
 
-func (t TodoUseCase) SaveTask(task *entity.Todo) error {
-	if err := t.repo.SaveTask(task); err != nil {
+func (t TodoUseCase) SaveTask(ctx context.Context, task *entity.Todo) (err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.SaveTask")
+	defer func() { endSpan(span, err) }()
+
+	if task == nil {
+		return fmt.Errorf("%w: no task", entity.ErrInvalidTask)
+	}
+	if err = t.prepareTask(ctx, task); err != nil {
+		return err
+	}
+
+	err = t.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := t.repo.SaveTask(ctx, task); err != nil {
+			return err
+		}
+		return t.record(ctx, entity.AuditCreate, task.ID, entity.DiffTodos(nil, task))
+	})
+	if err != nil {
 		return err
 	}
 
-	t.l.Info("success creating task")
+	t.l.WithContext(ctx).Info("success creating task")
 	return nil
 }
+
+// prepareTask checks a task about to be created against its project, the workflow and its parent, fills in
+// the defaults and resolves its tags and assignees.
+func (t TodoUseCase) prepareTask(ctx context.Context, task *entity.Todo) (err error) {
+	if task.ProjectID != nil {
+		project, err := t.openProject(ctx, *task.ProjectID)
+		if err != nil {
+			return err
+		}
+		if task.Status == "" {
+			task.Status = project.DefaultStatus
+		}
+		if task.Priority == "" {
+			task.Priority = project.DefaultPriority
+		}
+	}
+	if task.Priority == "" {
+		task.Priority = entity.PriorityNormal
+	}
+	if task.Status == "" {
+		task.Status = t.workflow.Initial
+	}
+	task.Status = t.workflow.Canonical(task.Status)
+	if !t.workflow.IsStatus(task.Status) {
+		return fmt.Errorf("%w %q", entity.ErrUnknownStatus, task.Status)
+	}
+	if len(task.TagIDs) > 0 {
+		if task.Tags, err = t.resolveTags(ctx, task.TagIDs); err != nil {
+			return err
+		}
+	}
+	if len(task.AssigneeIDs) > 0 {
+		if task.Assignees, err = t.resolveUsers(ctx, task.AssigneeIDs); err != nil {
+			return err
+		}
+	}
+	if task.ParentID != nil {
+		if err = t.checkParent(ctx, 0, *task.ParentID); err != nil {
+			return err
+		}
+	}
+	if err = checkRecurrence(task); err != nil {
+		return err
+	}
+	if task.Recurrence != "" && task.Occurrence == 0 {
+		task.Occurrence = 1
+	}
+	task.Progress = nil
+	task.Blocked = false
+	task.CreatedBy = entity.UserIDFromContext(ctx)
+	task.UpdatedBy = task.CreatedBy
+	return nil
+}
+
+// UpdateTask applies the patch and, when the status changes, checks the transition against the workflow
+// and records who made it, all in one transaction.
+func (t TodoUseCase) UpdateTask(ctx context.Context, id, version uint, patch entity.TodoPatch) (_ *entity.Todo, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.UpdateTask", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	var task *entity.Todo
+	err = t.repo.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if task, err = t.repo.GetTaskById(ctx, id); err != nil {
+			return err
+		}
+		if err = checkVersion(task, version); err != nil {
+			return err
+		}
+
+		before := *task
+		from := task.Status
+		patch.Apply(task)
+		task.Status = t.workflow.Canonical(task.Status)
+
+		if task.Status != from {
+			if !t.workflow.IsStatus(task.Status) {
+				return fmt.Errorf("%w %q", entity.ErrUnknownStatus, task.Status)
+			}
+			if !t.workflow.CanTransition(from, task.Status) {
+				return fmt.Errorf("%w from %q to %q", entity.ErrIllegalTransition, from, task.Status)
+			}
+			if err = t.checkChildrenClosed(ctx, task); err != nil {
+				return err
+			}
+		}
+
+		if patch.ParentID != nil && task.ParentID != nil {
+			if err = t.checkParent(ctx, task.ID, *task.ParentID); err != nil {
+				return err
+			}
+		}
+
+		if patch.ProjectID != nil && task.ProjectID != nil {
+			if _, err = t.openProject(ctx, *task.ProjectID); err != nil {
+				return err
+			}
+		}
+
+		if patch.Recurrence != nil || patch.Timezone != nil || patch.DueAt != nil {
+			if err = checkRecurrence(task); err != nil {
+				return err
+			}
+		}
+
+		task.UpdatedBy = entity.UserIDFromContext(ctx)
+		if err = t.repo.UpdateTask(ctx, task); err != nil {
+			return err
+		}
+
+		if patch.TagIDs != nil {
+			tags, err := t.resolveTags(ctx, *patch.TagIDs)
+			if err != nil {
+				return err
+			}
+			if err = t.repo.ReplaceTags(ctx, task, tags); err != nil {
+				return err
+			}
+			task.Tags = tags
+		}
+
+		if patch.AssigneeIDs != nil {
+			users, err := t.resolveUsers(ctx, *patch.AssigneeIDs)
+			if err != nil {
+				return err
+			}
+			if err = t.repo.ReplaceAssignees(ctx, task, users); err != nil {
+				return err
+			}
+			task.Assignees = users
+		}
+
+		if err = t.record(ctx, entity.AuditUpdate, task.ID, entity.DiffTodos(&before, task)); err != nil {
+			return err
+		}
+
+		if task.Status == from {
+			return nil
+		}
+		err = t.repo.SaveTransition(ctx, &entity.TodoTransition{
+			TodoID: task.ID,
+			From:   from,
+			To:     task.Status,
+			Actor:  entity.ActorFromContext(ctx),
+		})
+		if err != nil {
+			return err
+		}
+
+		if task.Recurrence != "" && t.workflow.IsClosed(task.Status) && !t.workflow.IsClosed(from) {
+			return t.nextOccurrence(ctx, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.l.WithContext(ctx).Info("success updating task")
+	return task, nil
+}
+
+// DeleteTask soft-deletes the task; a task with subtasks is kept until they are deleted.
+func (t TodoUseCase) DeleteTask(ctx context.Context, id, version uint) (err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.DeleteTask", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	err = t.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := t.checkNoChildren(ctx, id); err != nil {
+			return err
+		}
+		if err := t.repo.DeleteTask(ctx, id, version); err != nil {
+			return err
+		}
+		return t.record(ctx, entity.AuditDelete, id, entity.DeletionChanges(true))
+	})
+	if err != nil {
+		return err
+	}
+
+	t.l.WithContext(ctx).Info("success deleting task")
+	return nil
+}
+
+func (t TodoUseCase) RestoreTask(ctx context.Context, id uint) (_ *entity.Todo, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.RestoreTask", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	var task *entity.Todo
+	err = t.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := t.repo.RestoreTask(ctx, id); err != nil {
+			return err
+		}
+		if err := t.record(ctx, entity.AuditRestore, id, entity.DeletionChanges(false)); err != nil {
+			return err
+		}
+
+		var err error
+		task, err = t.repo.GetTaskById(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.l.WithContext(ctx).Info("success restoring task")
+	return task, nil
+}
+
+// PurgeTask deletes the task for good, whether it was soft-deleted before or not. The content of its
+// attachments is removed from the blob store once the transaction commits, unless another attachment shares it.
+func (t TodoUseCase) PurgeTask(ctx context.Context, id, version uint) (err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.PurgeTask", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	var digests []string
+	err = t.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := t.checkNoChildren(ctx, id); err != nil {
+			return err
+		}
+
+		if t.attachments != nil {
+			attachments, err := t.attachments.GetAttachments(ctx, id)
+			if err != nil {
+				return err
+			}
+			for _, attachment := range attachments {
+				digests = append(digests, attachment.SHA256)
+			}
+		}
+
+		if err := t.repo.PurgeTask(ctx, id, version); err != nil {
+			return err
+		}
+		return t.record(ctx, entity.AuditPurge, id, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	if t.blobs != nil {
+		removeBlobs(ctx, t.attachments, t.blobs, t.l, digests...)
+	}
+
+	t.l.WithContext(ctx).Info("success purging task")
+	return nil
+}
+
+func (t TodoUseCase) GetTransitions(ctx context.Context, id uint) (_ []entity.TodoTransition, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.GetTransitions", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	if _, err = t.repo.GetTaskById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return t.repo.GetTransitions(ctx, id)
+}
+
+func (t TodoUseCase) GetChildren(ctx context.Context, id uint) (_ []entity.Todo, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.GetChildren", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	if _, err = t.repo.GetTaskById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return t.repo.GetChildren(ctx, id)
+}
+
+func (t TodoUseCase) GetTree(ctx context.Context, id uint) (_ *entity.TodoNode, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.GetTree", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	tasks, err := t.repo.GetSubtree(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := entity.NewTodoTree(tasks, id, t.workflow.Closed)
+	if tree == nil {
+		return nil, fmt.Errorf("%w: %d", entity.ErrTaskNotFound, id)
+	}
+	return tree, nil
+}
+
+// AddBlocker records that the task cannot start before the blocker is closed, refusing edges that close a loop.
+func (t TodoUseCase) AddBlocker(ctx context.Context, id, blockerID uint) (err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.AddBlocker",
+		attribute.Int64("todo.id", int64(id)), attribute.Int64("todo.blocker_id", int64(blockerID)))
+	defer func() { endSpan(span, err) }()
+
+	if id == blockerID {
+		return fmt.Errorf("%w: %d", entity.ErrSelfDependency, id)
+	}
+
+	err = t.repo.Transaction(ctx, func(ctx context.Context) error {
+		// Concurrent requests adding the two halves of a cycle would each pass the check on their own.
+		if err := t.repo.LockDependencies(ctx); err != nil {
+			return err
+		}
+		for _, taskID := range []uint{id, blockerID} {
+			if _, err := t.repo.GetTaskById(ctx, taskID); err != nil {
+				return err
+			}
+		}
+
+		cycle, err := t.repo.IsBlockedBy(ctx, blockerID, id)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return fmt.Errorf("%w: task %d already depends on task %d", entity.ErrDependencyCycle, blockerID, id)
+		}
+
+		return t.repo.SaveDependency(ctx, &entity.TodoDependency{TodoID: id, BlockerID: blockerID})
+	})
+	if err != nil {
+		return err
+	}
+
+	t.l.WithContext(ctx).Info("success adding blocker")
+	return nil
+}
+
+func (t TodoUseCase) RemoveBlocker(ctx context.Context, id, blockerID uint) (err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.RemoveBlocker",
+		attribute.Int64("todo.id", int64(id)), attribute.Int64("todo.blocker_id", int64(blockerID)))
+	defer func() { endSpan(span, err) }()
+
+	if err = t.repo.DeleteDependency(ctx, id, blockerID); err != nil {
+		return err
+	}
+
+	t.l.WithContext(ctx).Info("success removing blocker")
+	return nil
+}
+
+func (t TodoUseCase) GetBlockers(ctx context.Context, id uint) (_ []entity.Todo, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.GetBlockers", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	if _, err = t.repo.GetTaskById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return t.repo.GetBlockers(ctx, id)
+}
+
+func (t TodoUseCase) GetBlocked(ctx context.Context, id uint) (_ []entity.Todo, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.GetBlocked", attribute.Int64("todo.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	if _, err = t.repo.GetTaskById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return t.repo.GetBlocked(ctx, id)
+}
+
+// GetTopologicalOrder returns the tasks ordered so that each one comes after the tasks blocking it.
+func (t TodoUseCase) GetTopologicalOrder(ctx context.Context, ids []uint) (_ []entity.Todo, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.GetTopologicalOrder", attribute.Int("todo.count", len(ids)))
+	defer func() { endSpan(span, err) }()
+
+	tasks, err := t.repo.GetTasksByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]entity.Todo, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	for _, id := range ids {
+		if _, ok := byID[id]; !ok {
+			return nil, fmt.Errorf("%w: %d", entity.ErrTaskNotFound, id)
+		}
+	}
+
+	dependencies, err := t.repo.GetDependencies(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := entity.TopologicalOrder(ids, dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]entity.Todo, 0, len(order))
+	for _, id := range order {
+		sorted = append(sorted, byID[id])
+	}
+	return sorted, nil
+}
+
+func (t TodoUseCase) CountTasksByStatus(ctx context.Context) (_ map[string]int64, err error) {
+	ctx, span := startSpan(ctx, "TodoUseCase.CountTasksByStatus")
+	defer func() { endSpan(span, err) }()
+
+	return t.repo.CountTasksByStatus(ctx)
+}
+
+// nextOccurrence creates the task following a recurring one that has just been closed. The new due date is
+// the first occurrence after both the current one and now, so a task closed late does not spawn overdue copies.
+func (t TodoUseCase) nextOccurrence(ctx context.Context, task *entity.Todo) error {
+	rule, err := rrule.Parse(task.Recurrence)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrInvalidRecurrence, err)
+	}
+	loc, err := time.LoadLocation(task.Timezone)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrInvalidRecurrence, err)
+	}
+
+	due := task.DueAt.In(loc)
+	after := t.now().In(loc)
+	if due.After(after) {
+		after = due
+	}
+
+	next, occurrence, ok := rule.Next(due, max(task.Occurrence, 1), after)
+	if !ok {
+		t.l.WithContext(ctx).Info("recurring task series ended")
+		return nil
+	}
+
+	closedBy := entity.UserIDFromContext(ctx)
+	seriesID := task.ID
+	if task.SeriesID != nil {
+		seriesID = *task.SeriesID
+	}
+
+	nextTask := &entity.Todo{
+		Description: task.Description,
+		Status:      t.workflow.Initial,
+		Priority:    task.Priority,
+		DueAt:       &next,
+		ParentID:    task.ParentID,
+		ProjectID:   task.ProjectID,
+		Recurrence:  task.Recurrence,
+		Timezone:    task.Timezone,
+		Occurrence:  occurrence,
+		SeriesID:    &seriesID,
+		CreatedBy:   closedBy,
+		UpdatedBy:   closedBy,
+		Assignees:   task.Assignees,
+		Tags:        task.Tags,
+	}
+	if err = t.repo.SaveTask(ctx, nextTask); err != nil {
+		return err
+	}
+	return t.record(ctx, entity.AuditCreate, nextTask.ID, entity.DiffTodos(nil, nextTask))
+}
+
+// openProject returns the project a task is created in or moved to, which must not be archived.
+func (t TodoUseCase) openProject(ctx context.Context, id uint) (*entity.Project, error) {
+	project, err := t.projects.GetProjectById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.Archived {
+		return nil, fmt.Errorf("%w: %d", entity.ErrProjectArchived, id)
+	}
+	return project, nil
+}
+
+// checkVersion refuses to change a task that is no longer at the version the client last read. The repository
+// writes the task only if it is still at the version it was read at, so a concurrent change in between fails too.
+func checkVersion(task *entity.Todo, version uint) error {
+	if version != entity.AnyVersion && version != task.Version {
+		return fmt.Errorf("%w: %d is at version %d", entity.ErrVersionMismatch, task.ID, task.Version)
+	}
+	return nil
+}
+
+// checkRecurrence validates the rule and time zone of a recurring task, which also needs a due date to count from.
+func checkRecurrence(task *entity.Todo) error {
+	if task.Recurrence == "" {
+		return nil
+	}
+	if _, err := rrule.Parse(task.Recurrence); err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrInvalidRecurrence, err)
+	}
+	if _, err := time.LoadLocation(task.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", entity.ErrInvalidRecurrence, task.Timezone)
+	}
+	if task.DueAt == nil {
+		return fmt.Errorf("%w: a recurring task needs a due date", entity.ErrInvalidRecurrence)
+	}
+	return nil
+}
+
+// checkParent rejects a parent that does not exist, is the task itself or one of its subtasks, or would put
+// the task or its subtasks deeper than allowed. id is 0 for a task that is not saved yet.
+func (t TodoUseCase) checkParent(ctx context.Context, id, parentID uint) error {
+	chain, err := t.repo.GetAncestorIds(ctx, parentID)
+	if err != nil {
+		return err
+	}
+	if len(chain) == 0 {
+		return fmt.Errorf("%w: task %d not found", entity.ErrInvalidParent, parentID)
+	}
+	for _, ancestor := range chain {
+		if ancestor == id {
+			return fmt.Errorf("%w: task %d is a subtask of task %d", entity.ErrInvalidParent, parentID, id)
+		}
+	}
+
+	if t.subtasks.MaxDepth <= 0 {
+		return nil
+	}
+
+	height := 0
+	if id != 0 {
+		tasks, err := t.repo.GetSubtree(ctx, id)
+		if err != nil {
+			return err
+		}
+		if tree := entity.NewTodoTree(tasks, id, t.workflow.Closed); tree != nil {
+			height = tree.Height()
+		}
+	}
+	// The parent sits at level len(chain)-1, so the task lands at len(chain).
+	if depth := len(chain) + height; depth > t.subtasks.MaxDepth {
+		return fmt.Errorf("%w: subtasks would nest %d levels deep, the limit is %d", entity.ErrInvalidParent, depth, t.subtasks.MaxDepth)
+	}
+	return nil
+}
+
+// record writes the audit entry of a change; callers make it in the transaction of the change.
+func (t TodoUseCase) record(ctx context.Context, operation string, id uint, changes entity.FieldChanges) error {
+	entry := auditEntry(ctx, operation, id, changes)
+	return t.audit.SaveAuditEntry(ctx, &entry)
+}
+
+// auditEntry is the entry of a change made by the actor of ctx.
+func auditEntry(ctx context.Context, operation string, id uint, changes entity.FieldChanges) entity.AuditEntry {
+	return entity.AuditEntry{
+		TodoID:    id,
+		Operation: operation,
+		Actor:     entity.ActorFromContext(ctx),
+		UserID:    entity.UserIDFromContext(ctx),
+		Changes:   changes,
+	}
+}
+
+// checkNoChildren refuses to delete a task that still has subtasks.
+func (t TodoUseCase) checkNoChildren(ctx context.Context, id uint) error {
+	count, err := t.repo.CountChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d left", entity.ErrHasChildren, count)
+	}
+	return nil
+}
+
+// checkChildrenClosed enforces Subtasks.CloseRequiresClosedChildren for a task moving to a new status.
+func (t TodoUseCase) checkChildrenClosed(ctx context.Context, task *entity.Todo) error {
+	if !t.subtasks.CloseRequiresClosedChildren || !t.workflow.IsClosed(task.Status) {
+		return nil
+	}
+
+	open, err := t.repo.CountOpenChildren(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return fmt.Errorf("%w: %d", entity.ErrOpenChildren, open)
+	}
+	return nil
+}
+
+// resolveTags loads the tags with the given ids and fails on any id that does not exist.
+func (t TodoUseCase) resolveTags(ctx context.Context, ids []uint) ([]entity.Tag, error) {
+	if len(ids) == 0 {
+		return []entity.Tag{}, nil
+	}
+
+	tags, err := t.tags.GetTagsByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uint]struct{}, len(tags))
+	for _, tag := range tags {
+		found[tag.ID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			return nil, fmt.Errorf("%w: %d", entity.ErrUnknownTag, id)
+		}
+	}
+	return tags, nil
+}
+
+// resolveUsers loads the users with the given ids and fails on any id that does not exist.
+func (t TodoUseCase) resolveUsers(ctx context.Context, ids []uint) ([]entity.User, error) {
+	if len(ids) == 0 {
+		return []entity.User{}, nil
+	}
+
+	users, err := t.users.GetUsersByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uint]struct{}, len(users))
+	for _, user := range users {
+		found[user.ID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			return nil, fmt.Errorf("%w: %d", entity.ErrUnknownUser, id)
+		}
+	}
+	return users, nil
+}
+
+// resolveMe replaces assignee=me with the id of the authenticated user.
+func resolveMe(ctx context.Context, filters []httpquery.FilterOption) ([]httpquery.FilterOption, error) {
+	resolved := make([]httpquery.FilterOption, 0, len(filters))
+	for _, filter := range filters {
+		if filter.Field == "assignee" && filter.Value == "me" {
+			id := entity.UserIDFromContext(ctx)
+			if id == nil {
+				return nil, entity.ErrAnonymous
+			}
+			filter.Value = strconv.FormatUint(uint64(*id), 10)
+		}
+		resolved = append(resolved, filter)
+	}
+	return resolved, nil
+}
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(_tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -1,14 +1,19 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/Vaixle/crud-golang/internal/entity"
 	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/blobstore"
 	"github.com/Vaixle/crud-golang/pkg/httpquery"
 	"github.com/Vaixle/crud-golang/pkg/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+	"strings"
 	"testing"
 	"time"
 )
@@ -23,7 +28,7 @@ func TestTodoUseCase_GetTaskById(t *testing.T) {
 		{
 			inputId: 1,
 			mockBehaviour: func(r *mock_entity.MockTodoRepository, id uint) {
-				r.EXPECT().GetTaskById(id).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().GetTaskById(gomock.Any(), id).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
 			},
 			expectedEntity: &entity.Todo{Model: gorm.Model{ID: 1}},
 			wantErr:        false,
@@ -31,7 +36,7 @@ func TestTodoUseCase_GetTaskById(t *testing.T) {
 		{
 			inputId: 1,
 			mockBehaviour: func(r *mock_entity.MockTodoRepository, id uint) {
-				r.EXPECT().GetTaskById(id).Return(nil, errors.New("some error"))
+				r.EXPECT().GetTaskById(gomock.Any(), id).Return(nil, errors.New("some error"))
 			},
 			expectedEntity: nil,
 			wantErr:        true,
@@ -44,11 +49,11 @@ func TestTodoUseCase_GetTaskById(t *testing.T) {
 		repo := mock_entity.NewMockTodoRepository(ctrl)
 		l := logger.New("info")
 
-		useCase := NewTodoUseCase(repo, l)
+		useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, l)
 
 		testCase.mockBehaviour(repo, testCase.inputId)
 
-		task, err := useCase.GetTaskById(testCase.inputId)
+		task, err := useCase.GetTaskById(context.Background(), testCase.inputId)
 
 		if testCase.wantErr {
 			assert.Error(t, err)
@@ -60,6 +65,8 @@ func TestTodoUseCase_GetTaskById(t *testing.T) {
 }
 
 func TestTodoUseCase_GetTasks(t *testing.T) {
+	projection := entity.Projection{Fields: []string{"description"}}
+
 	testTable := []struct {
 		name                  string
 		inputFilterOptions    []httpquery.FilterOption
@@ -73,7 +80,7 @@ func TestTodoUseCase_GetTasks(t *testing.T) {
 			inputFilterOptions:    []httpquery.FilterOption{{Operator: "gt", Field: "id", Value: "0"}},
 			inputFilterPagination: httpquery.Pagination{Limit: 100, Page: 0},
 			mockBehaviour: func(r *mock_entity.MockTodoRepository, filters []httpquery.FilterOption, pagination httpquery.Pagination) {
-				r.EXPECT().GetTasks(filters, pagination).Return(
+				r.EXPECT().GetTasks(gomock.Any(), filters, pagination, projection).Return(
 					[]entity.Todo{{
 						Model: gorm.Model{
 							ID:        3,
@@ -98,7 +105,7 @@ func TestTodoUseCase_GetTasks(t *testing.T) {
 			inputFilterOptions:    []httpquery.FilterOption{{Operator: "gt", Field: "id", Value: "0"}},
 			inputFilterPagination: httpquery.Pagination{Limit: 100, Page: 0},
 			mockBehaviour: func(r *mock_entity.MockTodoRepository, filters []httpquery.FilterOption, pagination httpquery.Pagination) {
-				r.EXPECT().GetTasks(filters, pagination).Return(nil, errors.New("some error"))
+				r.EXPECT().GetTasks(gomock.Any(), filters, pagination, projection).Return(nil, errors.New("some error"))
 			},
 			expectedEntities: nil,
 			wantErr:          true,
@@ -113,11 +120,11 @@ func TestTodoUseCase_GetTasks(t *testing.T) {
 
 			l := logger.New("info")
 
-			useCase := NewTodoUseCase(repo, l)
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, l)
 
 			testCase.mockBehaviour(repo, testCase.inputFilterOptions, testCase.inputFilterPagination)
 
-			tasks, err := useCase.GetTasks(testCase.inputFilterOptions, testCase.inputFilterPagination)
+			tasks, err := useCase.GetTasks(context.Background(), testCase.inputFilterOptions, testCase.inputFilterPagination, projection)
 
 			if testCase.wantErr {
 				assert.Error(t, err)
@@ -144,19 +151,34 @@ func TestTodoUseCase_SaveTask(t *testing.T) {
 				Description: "new Task3",
 			},
 			mockBehavior: func(r *mock_entity.MockTodoRepository, task *entity.Todo) {
-				r.EXPECT().SaveTask(task).Return(nil)
+				r.EXPECT().SaveTask(gomock.Any(), task).Return(nil)
 			},
 			expectedEntity: &entity.Todo{
 				Description: "new Task3",
+				Status:      entity.StatusOpen,
+				Priority:    entity.PriorityNormal,
 			},
 			wantErr: false,
 		},
 		{
-			name:        "ERROR",
-			inputEntity: nil,
+			name: "ERROR",
+			inputEntity: &entity.Todo{
+				Description: "new Task3",
+			},
 			mockBehavior: func(r *mock_entity.MockTodoRepository, task *entity.Todo) {
-				r.EXPECT().SaveTask(nil).Return(errors.New("some error"))
+				r.EXPECT().SaveTask(gomock.Any(), task).Return(errors.New("some error"))
 			},
+			expectedEntity: &entity.Todo{
+				Description: "new Task3",
+				Status:      entity.StatusOpen,
+				Priority:    entity.PriorityNormal,
+			},
+			wantErr: true,
+		},
+		{
+			name:           "NIL TASK",
+			inputEntity:    nil,
+			mockBehavior:   func(r *mock_entity.MockTodoRepository, task *entity.Todo) {},
 			expectedEntity: nil,
 			wantErr:        true,
 		},
@@ -167,14 +189,16 @@ func TestTodoUseCase_SaveTask(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
 
 			l := logger.New("info")
 
-			useCase := NewTodoUseCase(repo, l)
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, l)
 
 			testCase.mockBehavior(repo, testCase.inputEntity)
 
-			err := useCase.SaveTask(testCase.inputEntity)
+			err := useCase.SaveTask(context.Background(), testCase.inputEntity)
 
 			if testCase.wantErr {
 				assert.Error(t, err)
@@ -186,3 +210,1086 @@ func TestTodoUseCase_SaveTask(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoUseCase_CountTasksByStatus(t *testing.T) {
+	testTable := []struct {
+		name           string
+		mockBehavior   func(r *mock_entity.MockTodoRepository)
+		expectedCounts map[string]int64
+		wantErr        bool
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().CountTasksByStatus(gomock.Any()).Return(map[string]int64{"open": 3}, nil)
+			},
+			expectedCounts: map[string]int64{"open": 3},
+			wantErr:        false,
+		},
+		{
+			name: "ERROR",
+			mockBehavior: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().CountTasksByStatus(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			expectedCounts: nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+
+			l := logger.New("info")
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, l)
+
+			testCase.mockBehavior(repo)
+
+			counts, err := useCase.CountTasksByStatus(context.Background())
+
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.expectedCounts, counts)
+		})
+	}
+}
+
+func TestTodoUseCase_UpdateTask(t *testing.T) {
+	workflow := entity.Workflow{
+		Initial:  entity.StatusOpen,
+		Statuses: []string{entity.StatusOpen, "in_progress", entity.StatusClose},
+		Closed:   []string{entity.StatusClose},
+		Transitions: map[string][]string{
+			entity.StatusOpen: {"in_progress"},
+			"in_progress":     {entity.StatusClose},
+		},
+		Renamed: map[string]string{"done": entity.StatusClose},
+	}
+	done := entity.StatusClose
+	renamed := "done"
+	unknown := "archived"
+
+	testTable := []struct {
+		name          string
+		inputPatch    entity.TodoPatch
+		mockBehaviour func(r *mock_entity.MockTodoRepository)
+		wantErr       error
+	}{
+		{
+			name:       "OK",
+			inputPatch: entity.TodoPatch{Status: &done},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: "in_progress"}, nil)
+				r.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+				r.EXPECT().SaveTransition(gomock.Any(), &entity.TodoTransition{
+					TodoID: 1, From: "in_progress", To: entity.StatusClose, Actor: "admin",
+				}).Return(nil)
+			},
+		},
+		{
+			name:       "RENAMED STATUS",
+			inputPatch: entity.TodoPatch{Status: &renamed},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: "in_progress"}, nil)
+				r.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+				r.EXPECT().SaveTransition(gomock.Any(), &entity.TodoTransition{
+					TodoID: 1, From: "in_progress", To: entity.StatusClose, Actor: "admin",
+				}).Return(nil)
+			},
+		},
+		{
+			name:       "ILLEGAL TRANSITION",
+			inputPatch: entity.TodoPatch{Status: &done},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: entity.StatusOpen}, nil)
+			},
+			wantErr: entity.ErrIllegalTransition,
+		},
+		{
+			name:       "UNKNOWN STATUS",
+			inputPatch: entity.TodoPatch{Status: &unknown},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: entity.StatusOpen}, nil)
+			},
+			wantErr: entity.ErrUnknownStatus,
+		},
+		{
+			name:       "NOT FOUND",
+			inputPatch: entity.TodoPatch{Status: &done},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(nil, entity.ErrTaskNotFound)
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), workflow, entity.Subtasks{}, logger.New("info"))
+
+			testCase.mockBehaviour(repo)
+
+			ctx := entity.ContextWithActor(context.Background(), "admin")
+			task, err := useCase.UpdateTask(ctx, 1, entity.AnyVersion, testCase.inputPatch)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, entity.StatusClose, task.Status)
+		})
+	}
+}
+
+func TestTodoUseCase_SaveTaskTags(t *testing.T) {
+	testTable := []struct {
+		name          string
+		inputEntity   *entity.Todo
+		mockBehaviour func(r *mock_entity.MockTodoRepository, tags *mock_entity.MockTagRepository)
+		expectedTags  []entity.Tag
+		wantErr       error
+	}{
+		{
+			name:        "OK",
+			inputEntity: &entity.Todo{Description: "new Task", TagIDs: []uint{1, 2}},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, tags *mock_entity.MockTagRepository) {
+				tags.EXPECT().GetTagsByIds(gomock.Any(), []uint{1, 2}).Return([]entity.Tag{
+					{Model: gorm.Model{ID: 1}, Name: "bug"},
+					{Model: gorm.Model{ID: 2}, Name: "urgent"},
+				}, nil)
+				r.EXPECT().SaveTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedTags: []entity.Tag{
+				{Model: gorm.Model{ID: 1}, Name: "bug"},
+				{Model: gorm.Model{ID: 2}, Name: "urgent"},
+			},
+		},
+		{
+			name:        "UNKNOWN TAG",
+			inputEntity: &entity.Todo{Description: "new Task", TagIDs: []uint{1, 3}},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, tags *mock_entity.MockTagRepository) {
+				tags.EXPECT().GetTagsByIds(gomock.Any(), []uint{1, 3}).Return([]entity.Tag{
+					{Model: gorm.Model{ID: 1}, Name: "bug"},
+				}, nil)
+			},
+			wantErr: entity.ErrUnknownTag,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+			tags := mock_entity.NewMockTagRepository(ctrl)
+
+			useCase := NewTodoUseCase(repo, tags, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			testCase.mockBehaviour(repo, tags)
+
+			err := useCase.SaveTask(context.Background(), testCase.inputEntity)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedTags, testCase.inputEntity.Tags)
+		})
+	}
+}
+
+func TestTodoUseCase_UpdateTaskParent(t *testing.T) {
+	parent := func(id uint) *uint { return &id }
+
+	testTable := []struct {
+		name          string
+		subtasks      entity.Subtasks
+		inputPatch    entity.TodoPatch
+		mockBehaviour func(r *mock_entity.MockTodoRepository)
+		wantErr       error
+	}{
+		{
+			name:       "OK",
+			subtasks:   entity.Subtasks{MaxDepth: 2},
+			inputPatch: entity.TodoPatch{ParentID: parent(2)},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetAncestorIds(gomock.Any(), uint(2)).Return([]uint{2}, nil)
+				r.EXPECT().GetSubtree(gomock.Any(), uint(1)).Return([]entity.Todo{
+					{Model: gorm.Model{ID: 1}},
+					{Model: gorm.Model{ID: 3}, ParentID: parent(1)},
+				}, nil)
+				r.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:       "CYCLE",
+			inputPatch: entity.TodoPatch{ParentID: parent(3)},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetAncestorIds(gomock.Any(), uint(3)).Return([]uint{3, 1}, nil)
+			},
+			wantErr: entity.ErrInvalidParent,
+		},
+		{
+			name:       "TOO DEEP",
+			subtasks:   entity.Subtasks{MaxDepth: 2},
+			inputPatch: entity.TodoPatch{ParentID: parent(4)},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetAncestorIds(gomock.Any(), uint(4)).Return([]uint{4, 2}, nil)
+				r.EXPECT().GetSubtree(gomock.Any(), uint(1)).Return([]entity.Todo{
+					{Model: gorm.Model{ID: 1}},
+					{Model: gorm.Model{ID: 3}, ParentID: parent(1)},
+				}, nil)
+			},
+			wantErr: entity.ErrInvalidParent,
+		},
+		{
+			name:       "UNKNOWN PARENT",
+			inputPatch: entity.TodoPatch{ParentID: parent(9)},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetAncestorIds(gomock.Any(), uint(9)).Return([]uint{}, nil)
+			},
+			wantErr: entity.ErrInvalidParent,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			repo.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: entity.StatusOpen}, nil)
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), testCase.subtasks, logger.New("info"))
+
+			testCase.mockBehaviour(repo)
+
+			_, err := useCase.UpdateTask(context.Background(), 1, entity.AnyVersion, testCase.inputPatch)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTodoUseCase_CloseWithOpenChildren(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mock_entity.NewMockTodoRepository(ctrl)
+	repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	repo.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: entity.StatusOpen}, nil)
+	repo.EXPECT().CountOpenChildren(gomock.Any(), uint(1)).Return(int64(2), nil)
+
+	useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{CloseRequiresClosedChildren: true}, logger.New("info"))
+
+	status := entity.StatusClose
+	_, err := useCase.UpdateTask(context.Background(), 1, entity.AnyVersion, entity.TodoPatch{Status: &status})
+
+	assert.ErrorIs(t, err, entity.ErrOpenChildren)
+}
+
+func TestTodoUseCase_UpdateStaleVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mock_entity.NewMockTodoRepository(ctrl)
+	repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	repo.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: entity.StatusOpen, Version: 5}, nil)
+
+	useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+	status := entity.StatusClose
+	_, err := useCase.UpdateTask(context.Background(), 1, 4, entity.TodoPatch{Status: &status})
+
+	assert.ErrorIs(t, err, entity.ErrVersionMismatch)
+}
+
+func TestTodoUseCase_GetTree(t *testing.T) {
+	parent := func(id uint) *uint { return &id }
+
+	ctrl := gomock.NewController(t)
+
+	repo := mock_entity.NewMockTodoRepository(ctrl)
+	repo.EXPECT().GetSubtree(gomock.Any(), uint(1)).Return([]entity.Todo{
+		{Model: gorm.Model{ID: 3}, ParentID: parent(2), Status: entity.StatusClose},
+		{Model: gorm.Model{ID: 1}, Status: entity.StatusOpen},
+		{Model: gorm.Model{ID: 2}, ParentID: parent(1), Status: entity.StatusOpen},
+		{Model: gorm.Model{ID: 4}, ParentID: parent(2), Status: entity.StatusOpen},
+		{Model: gorm.Model{ID: 5}, ParentID: parent(1), Status: entity.StatusClose},
+	}, nil)
+
+	useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+	tree, err := useCase.GetTree(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tree.Height())
+	assert.Equal(t, 50, *tree.Progress)
+	assert.Len(t, tree.Children, 2)
+	assert.Equal(t, uint(2), tree.Children[0].ID)
+	assert.Equal(t, 50, *tree.Children[0].Progress)
+	assert.Nil(t, tree.Children[1].Progress)
+}
+
+func TestTodoUseCase_AddBlocker(t *testing.T) {
+	testTable := []struct {
+		name          string
+		inputId       uint
+		inputBlocker  uint
+		mockBehaviour func(r *mock_entity.MockTodoRepository)
+		wantErr       error
+	}{
+		{
+			name:         "OK",
+			inputId:      1,
+			inputBlocker: 2,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+				r.EXPECT().LockDependencies(gomock.Any()).Return(nil)
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().GetTaskById(gomock.Any(), uint(2)).Return(&entity.Todo{Model: gorm.Model{ID: 2}}, nil)
+				r.EXPECT().IsBlockedBy(gomock.Any(), uint(2), uint(1)).Return(false, nil)
+				r.EXPECT().SaveDependency(gomock.Any(), &entity.TodoDependency{TodoID: 1, BlockerID: 2}).Return(nil)
+			},
+		},
+		{
+			name:         "CYCLE",
+			inputId:      1,
+			inputBlocker: 2,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+				r.EXPECT().LockDependencies(gomock.Any()).Return(nil)
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+				r.EXPECT().GetTaskById(gomock.Any(), uint(2)).Return(&entity.Todo{Model: gorm.Model{ID: 2}}, nil)
+				r.EXPECT().IsBlockedBy(gomock.Any(), uint(2), uint(1)).Return(true, nil)
+			},
+			wantErr: entity.ErrDependencyCycle,
+		},
+		{
+			name:          "SELF",
+			inputId:       1,
+			inputBlocker:  1,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {},
+			wantErr:       entity.ErrSelfDependency,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			testCase.mockBehaviour(repo)
+
+			err := useCase.AddBlocker(context.Background(), testCase.inputId, testCase.inputBlocker)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTodoUseCase_GetTopologicalOrder(t *testing.T) {
+	testTable := []struct {
+		name          string
+		inputIds      []uint
+		mockBehaviour func(r *mock_entity.MockTodoRepository)
+		expectedIds   []uint
+		wantErr       error
+	}{
+		{
+			name:     "OK",
+			inputIds: []uint{1, 2, 3, 4},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTasksByIds(gomock.Any(), []uint{1, 2, 3, 4}).Return([]entity.Todo{
+					{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}}, {Model: gorm.Model{ID: 3}}, {Model: gorm.Model{ID: 4}},
+				}, nil)
+				r.EXPECT().GetDependencies(gomock.Any(), []uint{1, 2, 3, 4}).Return([]entity.TodoDependency{
+					{TodoID: 1, BlockerID: 3},
+					{TodoID: 3, BlockerID: 4},
+					{TodoID: 2, BlockerID: 4},
+				}, nil)
+			},
+			expectedIds: []uint{4, 2, 3, 1},
+		},
+		{
+			name:     "CYCLE",
+			inputIds: []uint{1, 2},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTasksByIds(gomock.Any(), []uint{1, 2}).Return([]entity.Todo{
+					{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}},
+				}, nil)
+				r.EXPECT().GetDependencies(gomock.Any(), []uint{1, 2}).Return([]entity.TodoDependency{
+					{TodoID: 1, BlockerID: 2},
+					{TodoID: 2, BlockerID: 1},
+				}, nil)
+			},
+			wantErr: entity.ErrDependencyCycle,
+		},
+		{
+			name:     "NOT FOUND",
+			inputIds: []uint{1, 5},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTasksByIds(gomock.Any(), []uint{1, 5}).Return([]entity.Todo{{Model: gorm.Model{ID: 1}}}, nil)
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			testCase.mockBehaviour(repo)
+
+			tasks, err := useCase.GetTopologicalOrder(context.Background(), testCase.inputIds)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			var ids []uint
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			assert.Equal(t, testCase.expectedIds, ids)
+		})
+	}
+}
+
+func TestTodoUseCase_RecurringTask(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	due := time.Date(2024, time.March, 1, 9, 0, 0, 0, berlin)
+	seriesID := uint(1)
+
+	testTable := []struct {
+		name          string
+		task          entity.Todo
+		now           time.Time
+		expectedNext  *entity.Todo
+		mockBehaviour func(r *mock_entity.MockTodoRepository, next *entity.Todo)
+	}{
+		{
+			name: "NEXT MONTH",
+			task: entity.Todo{Model: gorm.Model{ID: 1}, Description: "rotate credentials", Status: entity.StatusOpen,
+				Priority: entity.PriorityHigh, DueAt: &due, Recurrence: "FREQ=MONTHLY;BYMONTHDAY=1", Timezone: "Europe/Berlin", Occurrence: 1},
+			now: time.Date(2024, time.February, 28, 12, 0, 0, 0, time.UTC),
+			expectedNext: &entity.Todo{Description: "rotate credentials", Status: entity.StatusOpen, Priority: entity.PriorityHigh,
+				Recurrence: "FREQ=MONTHLY;BYMONTHDAY=1", Timezone: "Europe/Berlin", Occurrence: 2, SeriesID: &seriesID},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, next *entity.Todo) {
+				r.EXPECT().SaveTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *entity.Todo) error {
+					// April 1st 09:00 in Berlin is after the switch to summer time.
+					assert.True(t, time.Date(2024, time.April, 1, 7, 0, 0, 0, time.UTC).Equal(*task.DueAt), task.DueAt.String())
+					task.DueAt = nil
+					assert.Equal(t, next, task)
+					return nil
+				})
+			},
+		},
+		{
+			name: "CLOSED LATE SKIPS MISSED OCCURRENCES",
+			task: entity.Todo{Model: gorm.Model{ID: 2}, Description: "standup notes", Status: entity.StatusOpen,
+				DueAt: &due, Recurrence: "FREQ=DAILY", Occurrence: 3, SeriesID: &seriesID},
+			now: time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC),
+			expectedNext: &entity.Todo{Description: "standup notes", Status: entity.StatusOpen,
+				Recurrence: "FREQ=DAILY", Occurrence: 7, SeriesID: &seriesID},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, next *entity.Todo) {
+				r.EXPECT().SaveTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *entity.Todo) error {
+					assert.True(t, time.Date(2024, time.March, 5, 8, 0, 0, 0, time.UTC).Equal(*task.DueAt), task.DueAt.String())
+					task.DueAt = nil
+					assert.Equal(t, next, task)
+					return nil
+				})
+			},
+		},
+		{
+			name: "SERIES ENDED",
+			task: entity.Todo{Model: gorm.Model{ID: 3}, Description: "onboarding", Status: entity.StatusOpen,
+				DueAt: &due, Recurrence: "FREQ=WEEKLY;COUNT=2", Occurrence: 2, SeriesID: &seriesID},
+			now:           time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC),
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, next *entity.Todo) {},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			task := testCase.task
+			repo.EXPECT().GetTaskById(gomock.Any(), task.ID).Return(&task, nil)
+			repo.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+			repo.EXPECT().SaveTransition(gomock.Any(), gomock.Any()).Return(nil)
+			testCase.mockBehaviour(repo, testCase.expectedNext)
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"),
+				Clock(func() time.Time { return testCase.now }))
+
+			status := entity.StatusClose
+			_, err := useCase.UpdateTask(context.Background(), task.ID, entity.AnyVersion, entity.TodoPatch{Status: &status})
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTodoUseCase_SaveTaskRecurrence(t *testing.T) {
+	due := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name    string
+		task    *entity.Todo
+		wantErr bool
+	}{
+		{name: "OK", task: &entity.Todo{Description: "chore", DueAt: &due, Recurrence: "FREQ=WEEKLY;BYDAY=MO", Timezone: "America/New_York"}},
+		{name: "NO DUE DATE", task: &entity.Todo{Description: "chore", Recurrence: "FREQ=DAILY"}, wantErr: true},
+		{name: "BAD RULE", task: &entity.Todo{Description: "chore", DueAt: &due, Recurrence: "FREQ=HOURLY"}, wantErr: true},
+		{name: "BAD TIME ZONE", task: &entity.Todo{Description: "chore", DueAt: &due, Recurrence: "FREQ=DAILY", Timezone: "Mars/Olympus"}, wantErr: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+			if !testCase.wantErr {
+				repo.EXPECT().SaveTask(gomock.Any(), testCase.task).Return(nil)
+			}
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			err := useCase.SaveTask(context.Background(), testCase.task)
+
+			if testCase.wantErr {
+				assert.ErrorIs(t, err, entity.ErrInvalidRecurrence)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, testCase.task.Occurrence)
+		})
+	}
+}
+
+func TestTodoUseCase_SaveTaskProject(t *testing.T) {
+	projectID := uint(1)
+
+	testTable := []struct {
+		name             string
+		inputEntity      *entity.Todo
+		mockBehaviour    func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository)
+		expectedStatus   string
+		expectedPriority string
+		wantErr          error
+	}{
+		{
+			name:        "PROJECT DEFAULTS",
+			inputEntity: &entity.Todo{Description: "new Task", ProjectID: &projectID},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository) {
+				projects.EXPECT().GetProjectById(gomock.Any(), projectID).Return(&entity.Project{
+					Model: gorm.Model{ID: projectID}, Name: "backend", DefaultStatus: entity.StatusClose, DefaultPriority: entity.PriorityHigh,
+				}, nil)
+				r.EXPECT().SaveTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatus:   entity.StatusClose,
+			expectedPriority: entity.PriorityHigh,
+		},
+		{
+			name:        "EXPLICIT VALUES WIN",
+			inputEntity: &entity.Todo{Description: "new Task", ProjectID: &projectID, Status: entity.StatusOpen, Priority: entity.PriorityLow},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository) {
+				projects.EXPECT().GetProjectById(gomock.Any(), projectID).Return(&entity.Project{
+					Model: gorm.Model{ID: projectID}, Name: "backend", DefaultStatus: entity.StatusClose, DefaultPriority: entity.PriorityHigh,
+				}, nil)
+				r.EXPECT().SaveTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatus:   entity.StatusOpen,
+			expectedPriority: entity.PriorityLow,
+		},
+		{
+			name:        "NO PROJECT DEFAULTS",
+			inputEntity: &entity.Todo{Description: "new Task", ProjectID: &projectID},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository) {
+				projects.EXPECT().GetProjectById(gomock.Any(), projectID).Return(&entity.Project{Model: gorm.Model{ID: projectID}}, nil)
+				r.EXPECT().SaveTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatus:   entity.StatusOpen,
+			expectedPriority: entity.PriorityNormal,
+		},
+		{
+			name:        "ARCHIVED",
+			inputEntity: &entity.Todo{Description: "new Task", ProjectID: &projectID},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository) {
+				projects.EXPECT().GetProjectById(gomock.Any(), projectID).Return(&entity.Project{Model: gorm.Model{ID: projectID}, Archived: true}, nil)
+			},
+			wantErr: entity.ErrProjectArchived,
+		},
+		{
+			name:        "NOT FOUND",
+			inputEntity: &entity.Todo{Description: "new Task", ProjectID: &projectID},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository) {
+				projects.EXPECT().GetProjectById(gomock.Any(), projectID).Return(nil, entity.ErrProjectNotFound)
+			},
+			wantErr: entity.ErrProjectNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+			projects := mock_entity.NewMockProjectRepository(ctrl)
+
+			useCase := NewTodoUseCase(repo, nil, projects, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			testCase.mockBehaviour(repo, projects)
+
+			err := useCase.SaveTask(context.Background(), testCase.inputEntity)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatus, testCase.inputEntity.Status)
+			assert.Equal(t, testCase.expectedPriority, testCase.inputEntity.Priority)
+		})
+	}
+}
+
+func TestTodoUseCase_MoveTaskToProject(t *testing.T) {
+	testTable := []struct {
+		name              string
+		projectID         uint
+		mockBehaviour     func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository)
+		expectedProjectID *uint
+		wantErr           error
+	}{
+		{
+			name:      "MOVE",
+			projectID: 2,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository) {
+				projects.EXPECT().GetProjectById(gomock.Any(), uint(2)).Return(&entity.Project{Model: gorm.Model{ID: 2}}, nil)
+				r.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedProjectID: func() *uint { id := uint(2); return &id }(),
+		},
+		{
+			name:      "REMOVE FROM PROJECT",
+			projectID: 0,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository) {
+				r.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "ARCHIVED",
+			projectID: 2,
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, projects *mock_entity.MockProjectRepository) {
+				projects.EXPECT().GetProjectById(gomock.Any(), uint(2)).Return(&entity.Project{Model: gorm.Model{ID: 2}, Archived: true}, nil)
+			},
+			wantErr: entity.ErrProjectArchived,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			projects := mock_entity.NewMockProjectRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			current := uint(1)
+			repo.EXPECT().GetTaskById(gomock.Any(), uint(1)).
+				Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: entity.StatusOpen, ProjectID: &current}, nil)
+			testCase.mockBehaviour(repo, projects)
+
+			useCase := NewTodoUseCase(repo, nil, projects, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			task, err := useCase.UpdateTask(context.Background(), 1, entity.AnyVersion, entity.TodoPatch{ProjectID: &testCase.projectID})
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedProjectID, task.ProjectID)
+		})
+	}
+}
+
+func TestTodoUseCase_SaveTaskAssignees(t *testing.T) {
+	admin := entity.User{Model: gorm.Model{ID: 7}, Username: "admin"}
+
+	testTable := []struct {
+		name              string
+		ctx               context.Context
+		inputEntity       *entity.Todo
+		mockBehaviour     func(r *mock_entity.MockTodoRepository, users *mock_entity.MockUserRepository)
+		expectedAssignees []entity.User
+		expectedCreatedBy *uint
+		wantErr           error
+	}{
+		{
+			name:        "OK",
+			ctx:         entity.ContextWithUser(context.Background(), admin),
+			inputEntity: &entity.Todo{Description: "new Task", AssigneeIDs: []uint{7, 8}},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, users *mock_entity.MockUserRepository) {
+				users.EXPECT().GetUsersByIds(gomock.Any(), []uint{7, 8}).Return([]entity.User{
+					admin, {Model: gorm.Model{ID: 8}, Username: "bob"},
+				}, nil)
+				r.EXPECT().SaveTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedAssignees: []entity.User{admin, {Model: gorm.Model{ID: 8}, Username: "bob"}},
+			expectedCreatedBy: &admin.ID,
+		},
+		{
+			name:        "ANONYMOUS",
+			ctx:         context.Background(),
+			inputEntity: &entity.Todo{Description: "new Task"},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, users *mock_entity.MockUserRepository) {
+				r.EXPECT().SaveTask(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "UNKNOWN USER",
+			ctx:         context.Background(),
+			inputEntity: &entity.Todo{Description: "new Task", AssigneeIDs: []uint{9}},
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, users *mock_entity.MockUserRepository) {
+				users.EXPECT().GetUsersByIds(gomock.Any(), []uint{9}).Return(nil, nil)
+			},
+			wantErr: entity.ErrUnknownUser,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+			users := mock_entity.NewMockUserRepository(ctrl)
+
+			useCase := NewTodoUseCase(repo, nil, nil, users, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			testCase.mockBehaviour(repo, users)
+
+			err := useCase.SaveTask(testCase.ctx, testCase.inputEntity)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedAssignees, testCase.inputEntity.Assignees)
+			assert.Equal(t, testCase.expectedCreatedBy, testCase.inputEntity.CreatedBy)
+			assert.Equal(t, testCase.expectedCreatedBy, testCase.inputEntity.UpdatedBy)
+		})
+	}
+}
+
+func TestTodoUseCase_UpdateTaskAssignees(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	admin := entity.User{Model: gorm.Model{ID: 7}, Username: "admin"}
+	creator := uint(1)
+
+	repo := mock_entity.NewMockTodoRepository(ctrl)
+	users := mock_entity.NewMockUserRepository(ctrl)
+	repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	repo.EXPECT().GetTaskById(gomock.Any(), uint(1)).
+		Return(&entity.Todo{Model: gorm.Model{ID: 1}, Status: entity.StatusOpen, CreatedBy: &creator, UpdatedBy: &creator}, nil)
+	repo.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+	users.EXPECT().GetUsersByIds(gomock.Any(), []uint{7}).Return([]entity.User{admin}, nil)
+	repo.EXPECT().ReplaceAssignees(gomock.Any(), gomock.Any(), []entity.User{admin}).Return(nil)
+
+	useCase := NewTodoUseCase(repo, nil, nil, users, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+	task, err := useCase.UpdateTask(entity.ContextWithUser(context.Background(), admin), 1, entity.AnyVersion, entity.TodoPatch{AssigneeIDs: &[]uint{7}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.User{admin}, task.Assignees)
+	assert.Equal(t, &creator, task.CreatedBy)
+	assert.Equal(t, &admin.ID, task.UpdatedBy)
+}
+
+func TestTodoUseCase_GetTasksAssignedToMe(t *testing.T) {
+	testTable := []struct {
+		name          string
+		ctx           context.Context
+		mockBehaviour func(r *mock_entity.MockTodoRepository)
+		wantErr       error
+	}{
+		{
+			name: "OK",
+			ctx:  entity.ContextWithUser(context.Background(), entity.User{Model: gorm.Model{ID: 7}, Username: "admin"}),
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTasks(gomock.Any(), []httpquery.FilterOption{{Field: "assignee", Value: "7"}}, httpquery.Pagination{Limit: 100}, entity.Projection{}).
+					Return([]entity.Todo{}, nil)
+			},
+		},
+		{
+			name:          "ANONYMOUS",
+			ctx:           context.Background(),
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {},
+			wantErr:       entity.ErrAnonymous,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			testCase.mockBehaviour(repo)
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			_, err := useCase.GetTasks(testCase.ctx, []httpquery.FilterOption{{Field: "assignee", Value: "me"}}, httpquery.Pagination{Limit: 100}, entity.Projection{})
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTodoUseCase_PurgeTask(t *testing.T) {
+	testTable := []struct {
+		name          string
+		mockBehaviour func(r *mock_entity.MockTodoRepository, attachments *mock_entity.MockAttachmentRepository)
+		wantBlob      bool
+		wantErr       error
+	}{
+		{
+			name: "OK",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, attachments *mock_entity.MockAttachmentRepository) {
+				r.EXPECT().CountChildren(gomock.Any(), uint(1)).Return(int64(0), nil)
+				attachments.EXPECT().GetAttachments(gomock.Any(), uint(1)).Return([]entity.Attachment{{ID: 5, TodoID: 1, SHA256: "abc"}}, nil)
+				r.EXPECT().PurgeTask(gomock.Any(), uint(1), entity.AnyVersion).Return(nil)
+				attachments.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+				attachments.EXPECT().LockDigest(gomock.Any(), "abc").Return(nil)
+				attachments.EXPECT().IsReferenced(gomock.Any(), "abc").Return(false, nil)
+			},
+		},
+		{
+			name: "HAS CHILDREN",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, attachments *mock_entity.MockAttachmentRepository) {
+				r.EXPECT().CountChildren(gomock.Any(), uint(1)).Return(int64(2), nil)
+			},
+			wantBlob: true,
+			wantErr:  entity.ErrHasChildren,
+		},
+		{
+			name: "NOT FOUND",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository, attachments *mock_entity.MockAttachmentRepository) {
+				r.EXPECT().CountChildren(gomock.Any(), uint(1)).Return(int64(0), nil)
+				attachments.EXPECT().GetAttachments(gomock.Any(), uint(1)).Return(nil, nil)
+				r.EXPECT().PurgeTask(gomock.Any(), uint(1), entity.AnyVersion).Return(entity.ErrTaskNotFound)
+			},
+			wantBlob: true,
+			wantErr:  entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			attachments := mock_entity.NewMockAttachmentRepository(ctrl)
+			testCase.mockBehaviour(repo, attachments)
+
+			store, err := blobstore.NewFilesystem(t.TempDir())
+			require.NoError(t, err)
+			require.NoError(t, store.Put(context.Background(), "abc", strings.NewReader("log"), 3))
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"),
+				Attachments(attachments, store))
+
+			err = useCase.PurgeTask(context.Background(), 1, entity.AnyVersion)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			exists, err := store.Exists(context.Background(), "abc")
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantBlob, exists)
+		})
+	}
+}
+
+// auditLog accepts any audit entry, for the tests that do not look at the audit log.
+func auditLog(ctrl *gomock.Controller) *mock_entity.MockAuditRepository {
+	audit := mock_entity.NewMockAuditRepository(ctrl)
+	audit.EXPECT().SaveAuditEntry(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return audit
+}
+
+func TestTodoUseCase_Audit(t *testing.T) {
+	admin := entity.User{Model: gorm.Model{ID: 7}, Username: "admin"}
+	done := "done"
+	due := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name          string
+		mockBehaviour func(r *mock_entity.MockTodoRepository)
+		call          func(useCase entity.TodoUseCase, ctx context.Context) error
+		wantEntry     *entity.AuditEntry
+	}{
+		{
+			name: "CREATE",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().SaveTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *entity.Todo) error {
+					task.ID = 1
+					return nil
+				})
+			},
+			call: func(useCase entity.TodoUseCase, ctx context.Context) error {
+				return useCase.SaveTask(ctx, &entity.Todo{Description: "write docs", DueAt: &due})
+			},
+			wantEntry: &entity.AuditEntry{TodoID: 1, Operation: entity.AuditCreate, Actor: "admin", UserID: &admin.ID, Changes: entity.FieldChanges{
+				{Field: "description", Old: []byte("null"), New: []byte(`"write docs"`)},
+				{Field: "status", Old: []byte("null"), New: []byte(`"open"`)},
+				{Field: "priority", Old: []byte("null"), New: []byte(`"normal"`)},
+				{Field: "due_at", Old: []byte("null"), New: []byte(`"2024-03-01T09:00:00Z"`)},
+			}},
+		},
+		{
+			name: "UPDATE",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}, Description: "write docs", Status: entity.StatusOpen, Priority: "normal"}, nil)
+				r.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(nil)
+				r.EXPECT().SaveTransition(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			call: func(useCase entity.TodoUseCase, ctx context.Context) error {
+				_, err := useCase.UpdateTask(ctx, 1, entity.AnyVersion, entity.TodoPatch{Status: &done})
+				return err
+			},
+			wantEntry: &entity.AuditEntry{TodoID: 1, Operation: entity.AuditUpdate, Actor: "admin", UserID: &admin.ID, Changes: entity.FieldChanges{
+				{Field: "status", Old: []byte(`"open"`), New: []byte(`"done"`)},
+			}},
+		},
+		{
+			name: "DELETE",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().CountChildren(gomock.Any(), uint(1)).Return(int64(0), nil)
+				r.EXPECT().DeleteTask(gomock.Any(), uint(1), entity.AnyVersion).Return(nil)
+			},
+			call: func(useCase entity.TodoUseCase, ctx context.Context) error {
+				return useCase.DeleteTask(ctx, 1, entity.AnyVersion)
+			},
+			wantEntry: &entity.AuditEntry{TodoID: 1, Operation: entity.AuditDelete, Actor: "admin", UserID: &admin.ID, Changes: entity.DeletionChanges(true)},
+		},
+		{
+			name: "RESTORE",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().RestoreTask(gomock.Any(), uint(1)).Return(nil)
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+			},
+			call: func(useCase entity.TodoUseCase, ctx context.Context) error {
+				_, err := useCase.RestoreTask(ctx, 1)
+				return err
+			},
+			wantEntry: &entity.AuditEntry{TodoID: 1, Operation: entity.AuditRestore, Actor: "admin", UserID: &admin.ID, Changes: entity.DeletionChanges(false)},
+		},
+		{
+			name: "NOTHING RECORDED ON FAILURE",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().CountChildren(gomock.Any(), uint(1)).Return(int64(0), nil)
+				r.EXPECT().DeleteTask(gomock.Any(), uint(1), entity.AnyVersion).Return(entity.ErrTaskNotFound)
+			},
+			call: func(useCase entity.TodoUseCase, ctx context.Context) error {
+				if err := useCase.DeleteTask(ctx, 1, entity.AnyVersion); !errors.Is(err, entity.ErrTaskNotFound) {
+					return fmt.Errorf("unexpected error: %v", err)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+			inTransaction := false
+			repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error {
+					inTransaction = true
+					defer func() { inTransaction = false }()
+					return fn(ctx)
+				})
+			testCase.mockBehaviour(repo)
+
+			audit := mock_entity.NewMockAuditRepository(ctrl)
+			if testCase.wantEntry != nil {
+				audit.EXPECT().SaveAuditEntry(gomock.Any(), testCase.wantEntry).DoAndReturn(func(context.Context, *entity.AuditEntry) error {
+					assert.True(t, inTransaction, "audit entry written outside the transaction")
+					return nil
+				})
+			}
+
+			workflow := entity.DefaultWorkflow()
+			workflow.Statuses = append(workflow.Statuses, done)
+			workflow.Transitions = map[string][]string{entity.StatusOpen: {done}}
+			useCase := NewTodoUseCase(repo, nil, nil, nil, audit, workflow, entity.Subtasks{}, logger.New("info"))
+
+			require.NoError(t, testCase.call(useCase, entity.ContextWithUser(context.Background(), admin)))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	mock_entity "github.com/Vaixle/crud-golang/internal/entity/mocks"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/Vaixle/crud-golang/pkg/tracing"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestTodoUseCase_Tracing(t *testing.T) {
+	testTable := []struct {
+		name           string
+		mockBehaviour  func(r *mock_entity.MockTodoRepository)
+		expectedStatus codes.Code
+	}{
+		{
+			name: "OK",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(&entity.Todo{Model: gorm.Model{ID: 1}}, nil)
+			},
+			expectedStatus: codes.Unset,
+		},
+		{
+			name: "ERROR",
+			mockBehaviour: func(r *mock_entity.MockTodoRepository) {
+				r.EXPECT().GetTaskById(gomock.Any(), uint(1)).Return(nil, errors.New("some error"))
+			},
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp, err := tracing.New(tracing.SpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter)))
+			require.NoError(t, err)
+			defer tp.Shutdown(context.Background())
+
+			ctrl := gomock.NewController(t)
+
+			repo := mock_entity.NewMockTodoRepository(ctrl)
+
+			useCase := NewTodoUseCase(repo, nil, nil, nil, auditLog(ctrl), entity.DefaultWorkflow(), entity.Subtasks{}, logger.New("info"))
+
+			testCase.mockBehaviour(repo)
+
+			_, _ = useCase.GetTaskById(context.Background(), 1)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "TodoUseCase.GetTaskById", spans[0].Name)
+			assert.Equal(t, testCase.expectedStatus, spans[0].Status.Code)
+		})
+	}
+}
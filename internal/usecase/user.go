@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"github.com/Vaixle/crud-golang/internal/entity"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var _ entity.UserUseCase = (*UserUseCase)(nil)
+
+type UserUseCase struct {
+	repo entity.UserRepository
+	l    logger.Interface
+}
+
+func NewUserUseCase(repo entity.UserRepository, l logger.Interface) entity.UserUseCase {
+	return &UserUseCase{repo: repo, l: l}
+}
+
+func (u UserUseCase) GetUsers(ctx context.Context) (_ []entity.User, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.GetUsers")
+	defer func() { endSpan(span, err) }()
+
+	return u.repo.GetUsers(ctx)
+}
+
+func (u UserUseCase) GetUserById(ctx context.Context, id uint) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.GetUserById", attribute.Int64("user.id", int64(id)))
+	defer func() { endSpan(span, err) }()
+
+	return u.repo.GetUserById(ctx, id)
+}
+
+// ResolveUser provisions the account of a principal the auth middleware has let in for the first time.
+func (u UserUseCase) ResolveUser(ctx context.Context, username string) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.ResolveUser")
+	defer func() { endSpan(span, err) }()
+
+	return u.repo.GetOrCreateUser(ctx, username)
+}
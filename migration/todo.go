@@ -1,13 +1,54 @@
 package migration
 
 import (
+	"context"
+	"fmt"
 	"github.com/Vaixle/crud-golang/internal/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"log"
+	"time"
 )
 
 func InitTodoTable(db *gorm.DB) {
-	if err := db.AutoMigrate(&entity.Todo{}); err != nil {
+	if err := db.AutoMigrate(&entity.User{}, &entity.Tag{}, &entity.Project{}, &entity.Todo{}, &entity.TodoTransition{}, &entity.TodoDependency{}, &entity.Comment{}, &entity.Attachment{}, &entity.AuditEntry{}, &entity.IdempotencyKey{}); err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// CheckTables reports an error when a migrated table is missing.
+func CheckTables(ctx context.Context, db *gorm.DB) error {
+	migrator := db.WithContext(ctx).Migrator()
+	for _, model := range []interface{}{&entity.User{}, &entity.Tag{}, &entity.Project{}, &entity.Todo{}, &entity.TodoTransition{}, &entity.TodoDependency{}, &entity.Comment{}, &entity.Attachment{}, &entity.AuditEntry{}, &entity.IdempotencyKey{}} {
+		if !migrator.HasTable(model) {
+			return fmt.Errorf("table for %T is not migrated", model)
+		}
+	}
+	return nil
+}
+
+// RenameStatuses moves tasks, their recorded transitions and project defaults from the statuses an earlier
+// workflow used to the ones that replace them, so that no stored task is left in a status it cannot leave.
+// Renamed tasks get a new version, as their representation changed.
+func RenameStatuses(db *gorm.DB, renamed map[string]string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for from, to := range renamed {
+			err := tx.Model(&entity.Todo{}).Unscoped().Where("status = ?", from).
+				UpdateColumns(map[string]interface{}{"status": to, "version": gorm.Expr("version + 1"), "updated_at": time.Now()}).Error
+			if err == nil {
+				err = tx.Model(&entity.TodoTransition{}).Where(clause.Eq{Column: "from", Value: from}).Update("from", to).Error
+			}
+			if err == nil {
+				err = tx.Model(&entity.TodoTransition{}).Where(clause.Eq{Column: "to", Value: from}).Update("to", to).Error
+			}
+			if err == nil {
+				err = tx.Model(&entity.Project{}).Unscoped().Where("default_status = ?", from).
+					UpdateColumn("default_status", to).Error
+			}
+			if err != nil {
+				return fmt.Errorf("rename status %q to %q: %w", from, to, err)
+			}
+		}
+		return nil
+	})
+}
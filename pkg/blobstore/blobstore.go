@@ -0,0 +1,42 @@
+// Package blobstore keeps opaque blobs addressed by key, on a local filesystem or in an S3-compatible bucket.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore -.
+type BlobStore interface {
+	// Put stores size bytes read from r under key, replacing any blob already there.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Open returns the blob for reading; seeking lets callers serve byte ranges.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	// Delete removes the blob; deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// checkKey accepts keys made of letters, digits, '-', '_' and '.' that do not start with a dot,
+// so that a key never escapes its directory or bucket nor clashes with temporary files.
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, ".") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
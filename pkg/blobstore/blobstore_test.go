@@ -0,0 +1,150 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is an in-memory stand-in for an S3-compatible server, enough for the object calls the store makes.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	auth    []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err == nil && strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+			body, err = unchunk(body)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[r.URL.Path] = body
+	case http.MethodGet, http.MethodHead:
+		object, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"1"`)
+		http.ServeContent(w, r, "", time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC), bytes.NewReader(object))
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// unchunk decodes an aws-chunked body, made of chunks of "<hex size>;chunk-signature=<signature>\r\n<data>\r\n"
+// up to an empty one.
+func unchunk(body []byte) ([]byte, error) {
+	var content []byte
+	for {
+		header, rest, ok := bytes.Cut(body, []byte("\r\n"))
+		if !ok {
+			return nil, fmt.Errorf("chunk header missing")
+		}
+		hexSize, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(hexSize), 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return content, nil
+		}
+		if int64(len(rest)) < size+2 {
+			return nil, fmt.Errorf("chunk of %d bytes cut short", size)
+		}
+		content = append(content, rest[:size]...)
+		body = rest[size+2:]
+	}
+}
+
+func TestBlobStore(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	filesystem, err := NewFilesystem(t.TempDir())
+	require.NoError(t, err)
+	s3, err := NewS3(server.URL, "attachments", Credentials("access", "secret"))
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name  string
+		store BlobStore
+	}{
+		{name: "FILESYSTEM", store: filesystem},
+		{name: "S3", store: s3},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+			content := "0123456789abcdef"
+
+			exists, err := testCase.store.Exists(ctx, "abc123")
+			require.NoError(t, err)
+			assert.False(t, exists)
+
+			_, err = testCase.store.Open(ctx, "abc123")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			require.NoError(t, testCase.store.Put(ctx, "abc123", strings.NewReader(content), int64(len(content))))
+			exists, err = testCase.store.Exists(ctx, "abc123")
+			require.NoError(t, err)
+			assert.True(t, exists)
+
+			blob, err := testCase.store.Open(ctx, "abc123")
+			require.NoError(t, err)
+			all, err := io.ReadAll(blob)
+			require.NoError(t, err)
+			assert.Equal(t, content, string(all))
+
+			// A range as http.ServeContent reads it: size from the end, then a bounded read from the start of the range.
+			size, err := blob.Seek(0, io.SeekEnd)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(content)), size)
+			_, err = blob.Seek(10, io.SeekStart)
+			require.NoError(t, err)
+			part, err := io.ReadAll(io.LimitReader(blob, 4))
+			require.NoError(t, err)
+			assert.Equal(t, "abcd", string(part))
+			require.NoError(t, blob.Close())
+
+			require.NoError(t, testCase.store.Delete(ctx, "abc123"))
+			require.NoError(t, testCase.store.Delete(ctx, "abc123"))
+			exists, err = testCase.store.Exists(ctx, "abc123")
+			require.NoError(t, err)
+			assert.False(t, exists)
+
+			for _, key := range []string{"", "../etc/passwd", "a/b", ".hidden"} {
+				assert.ErrorIs(t, testCase.store.Put(ctx, key, strings.NewReader(""), 0), ErrInvalidKey)
+			}
+		})
+	}
+
+	for _, auth := range fake.auth {
+		assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access/"), auth)
+	}
+}
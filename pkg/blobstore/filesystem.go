@@ -0,0 +1,109 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+var _ BlobStore = (*Filesystem)(nil)
+
+// Filesystem keeps every blob in a file under dir, fanned out over subdirectories named after the first two key characters.
+type Filesystem struct {
+	dir string
+}
+
+// NewFilesystem creates dir when it does not exist.
+func NewFilesystem(dir string) (*Filesystem, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("blobstore - NewFilesystem - os.MkdirAll: %w", err)
+	}
+	return &Filesystem{dir: dir}, nil
+}
+
+// Put writes to a temporary file first, so readers never see a partly written blob.
+func (f *Filesystem) Put(_ context.Context, key string, r io.Reader, size int64) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if written != size {
+		return fmt.Errorf("blobstore - Put: wrote %d bytes, expected %d", written, size)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *Filesystem) Open(_ context.Context, key string) (io.ReadSeekCloser, error) {
+	path, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+func (f *Filesystem) Exists(_ context.Context, key string) (bool, error) {
+	path, err := f.path(key)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err = os.Stat(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (f *Filesystem) Delete(_ context.Context, key string) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (f *Filesystem) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	if len(key) < 2 {
+		return filepath.Join(f.dir, key), nil
+	}
+	return filepath.Join(f.dir, key[:2], key), nil
+}
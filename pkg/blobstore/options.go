@@ -0,0 +1,39 @@
+package blobstore
+
+import "net/http"
+
+type s3Config struct {
+	region    string
+	accessKey string
+	secretKey string
+	transport http.RoundTripper
+}
+
+// Option -.
+type Option func(*s3Config)
+
+// Region the bucket lives in, us-east-1 unless set; most S3-compatible servers accept any region.
+func Region(region string) Option {
+	return func(c *s3Config) {
+		if region != "" {
+			c.region = region
+		}
+	}
+}
+
+// Credentials sign the requests; without them requests are sent anonymously.
+func Credentials(accessKey, secretKey string) Option {
+	return func(c *s3Config) {
+		c.accessKey = accessKey
+		c.secretKey = secretKey
+	}
+}
+
+// Transport replaces the default transport of the S3 client.
+func Transport(transport http.RoundTripper) Option {
+	return func(c *s3Config) {
+		if transport != nil {
+			c.transport = transport
+		}
+	}
+}
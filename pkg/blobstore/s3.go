@@ -0,0 +1,118 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var _ BlobStore = (*S3)(nil)
+
+// S3 keeps blobs as objects of a bucket, addressed path-style so that it works with MinIO and other S3-compatible servers.
+type S3 struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3 -.
+func NewS3(endpoint, bucket string, opts ...Option) (*S3, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("blobstore - NewS3 - url.Parse: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("blobstore - NewS3: endpoint %q is not an http(s) URL", endpoint)
+	}
+	if u.Path != "" && u.Path != "/" {
+		return nil, fmt.Errorf("blobstore - NewS3: endpoint %q has a path", endpoint)
+	}
+	if bucket == "" {
+		return nil, errors.New("blobstore - NewS3: bucket is empty")
+	}
+
+	cfg := s3Config{region: "us-east-1"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// Requests stay anonymous without credentials.
+	client, err := minio.New(u.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.accessKey, cfg.secretKey, ""),
+		Secure:       u.Scheme == "https",
+		Region:       cfg.region,
+		BucketLookup: minio.BucketLookupPath,
+		Transport:    cfg.transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("blobstore - NewS3 - minio.New: %w", err)
+	}
+
+	return &S3{client: client, bucket: bucket}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	return s.error(key, err)
+}
+
+// Open looks the object up once for its size; reads then fetch the rest of the object from the current offset.
+func (s *S3) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.error(key, err)
+	}
+	if _, err = object.Stat(); err != nil {
+		_ = object.Close()
+		return nil, s.error(key, err)
+	}
+	return object, nil
+}
+
+func (s *S3) Exists(ctx context.Context, key string) (bool, error) {
+	if err := checkKey(key); err != nil {
+		return false, err
+	}
+
+	if _, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{}); err != nil {
+		if err = s.error(key, err); errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	if err := s.error(key, s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})); !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// error reports a missing object as ErrNotFound.
+func (s *S3) error(key string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s/%s", ErrNotFound, s.bucket, key)
+	}
+	return fmt.Errorf("blobstore - S3 %s/%s: %w", s.bucket, key, err)
+}
@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"errors"
+	"gorm.io/gorm"
+)
+
+// registerCallbacks hooks before/after every GORM processor under the given name.
+func registerCallbacks(db *gorm.DB, name string, before, after func(operation string) func(*gorm.DB)) error {
+	cb := db.Callback()
+
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register(name+":before_create", before("create")),
+		cb.Create().After("gorm:create").Register(name+":after_create", after("create")),
+		cb.Query().Before("gorm:query").Register(name+":before_query", before("query")),
+		cb.Query().After("gorm:query").Register(name+":after_query", after("query")),
+		cb.Update().Before("gorm:update").Register(name+":before_update", before("update")),
+		cb.Update().After("gorm:update").Register(name+":after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register(name+":before_delete", before("delete")),
+		cb.Delete().After("gorm:delete").Register(name+":after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register(name+":before_row", before("row")),
+		cb.Row().After("gorm:row").Register(name+":after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register(name+":before_raw", before("raw")),
+		cb.Raw().After("gorm:raw").Register(name+":after_raw", after("raw")),
+	)
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"time"
+)
+
+const _metricsStartKey = "metrics:start"
+
+// RegisterMetrics exposes connection pool stats and GORM query durations by operation.
+func (p *Postgres) RegisterMetrics(reg prometheus.Registerer) error {
+	sqlDB, err := p.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gorm",
+		Name:      "query_duration_seconds",
+		Help:      "GORM statement latency by operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	if err = reg.Register(collectors.NewDBStatsCollector(sqlDB, viper.GetString("db.name"))); err != nil {
+		return err
+	}
+	if err = reg.Register(duration); err != nil {
+		return err
+	}
+
+	return registerCallbacks(p.DB, "metrics", func(string) func(db *gorm.DB) {
+		return func(db *gorm.DB) {
+			db.InstanceSet(_metricsStartKey, time.Now())
+		}
+	}, func(operation string) func(db *gorm.DB) {
+		observer := duration.WithLabelValues(operation)
+		return func(db *gorm.DB) {
+			if start, ok := db.InstanceGet(_metricsStartKey); ok {
+				observer.Observe(time.Since(start.(time.Time)).Seconds())
+			}
+		}
+	})
+}
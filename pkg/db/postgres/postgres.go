@@ -2,6 +2,7 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 	"github.com/spf13/viper"
 	"gorm.io/driver/postgres"
@@ -29,11 +30,28 @@ func New() (*Postgres, error) {
 	dbPass := viper.GetString("db.password")
 	dbName := viper.GetString("db.name")
 	dbDSN := fmt.Sprintf("user=%s password=%s dbname=%s host=%s port=%s sslmode=disable", dbUser, dbPass, dbName, dbHost, dbPort)
-	fmt.Println(dbDSN)
 
-	gormDB, err := gorm.Open(postgres.Open(dbDSN), &gorm.Config{Logger: logger.Default.LogMode(logger.Info)})
+	gormDB, err := gorm.Open(postgres.Open(dbDSN), &gorm.Config{Logger: logger.Default.LogMode(logger.Info), TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
 	return &Postgres{DB: gormDB}, nil
 }
+
+// Ping -.
+func (p *Postgres) Ping(ctx context.Context) error {
+	sqlDB, err := p.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Close -.
+func (p *Postgres) Close() error {
+	sqlDB, err := p.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const _tracingSpanKey = "tracing:span"
+
+// RegisterTracing starts a span for every GORM statement as a child of the statement context.
+func (p *Postgres) RegisterTracing() error {
+	tracer := otel.Tracer("github.com/Vaixle/crud-golang/pkg/db/postgres")
+
+	return registerCallbacks(p.DB, "tracing", func(operation string) func(db *gorm.DB) {
+		return func(db *gorm.DB) {
+			ctx, span := tracer.Start(db.Statement.Context, "gorm."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperation(operation)))
+			db.Statement.Context = ctx
+			db.InstanceSet(_tracingSpanKey, span)
+		}
+	}, func(operation string) func(db *gorm.DB) {
+		return func(db *gorm.DB) {
+			value, ok := db.InstanceGet(_tracingSpanKey)
+			if !ok {
+				return
+			}
+			span := value.(trace.Span)
+			defer span.End()
+
+			span.SetAttributes(
+				semconv.DBStatement(db.Statement.SQL.String()),
+				semconv.DBSQLTable(db.Statement.Table),
+				attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+			)
+			if db.Error != nil && db.Error != gorm.ErrRecordNotFound {
+				span.RecordError(db.Error)
+				span.SetStatus(codes.Error, db.Error.Error())
+			}
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// Package health implements liveness and readiness checks.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	_defaultTimeout = 2 * time.Second
+
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+var errNotReady = errors.New("not accepting traffic")
+
+// Check -.
+type Check func(ctx context.Context) error
+
+// Result -.
+type Result struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report -.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Up -.
+func (r Report) Up() bool {
+	return r.Status == StatusUp
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Health -.
+type Health struct {
+	mu      sync.RWMutex
+	checks  []namedCheck
+	ready   atomic.Bool
+	timeout time.Duration
+}
+
+// New -.
+func New(opts ...Option) *Health {
+	h := &Health{
+		timeout: _defaultTimeout,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// AddCheck registers a readiness check.
+func (h *Health) AddCheck(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// SetReady -.
+func (h *Health) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// Liveness reports whether the process is able to serve requests at all.
+func (h *Health) Liveness(_ context.Context) Report {
+	return Report{Status: StatusUp, Checks: map[string]Result{}}
+}
+
+// Readiness runs every registered check, each bounded by the configured timeout.
+func (h *Health) Readiness(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := make([]namedCheck, len(h.checks))
+	copy(checks, h.checks)
+	h.mu.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(checks))}
+
+	if !h.ready.Load() {
+		report.Status = StatusDown
+		report.Checks["lifecycle"] = Result{Status: StatusDown, Error: errNotReady.Error()}
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c namedCheck) {
+			defer wg.Done()
+
+			result := h.run(ctx, c.check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.name] = result
+			if result.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (h *Health) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	if err := check(ctx); err != nil {
+		return Result{Status: StatusDown, Error: err.Error()}
+	}
+	return Result{Status: StatusUp}
+}
@@ -0,0 +1,15 @@
+package health
+
+import "time"
+
+// Option -.
+type Option func(*Health)
+
+// Timeout -.
+func Timeout(timeout time.Duration) Option {
+	return func(h *Health) {
+		if timeout > 0 {
+			h.timeout = timeout
+		}
+	}
+}
@@ -25,25 +25,30 @@ const (
 	defaultPage  = 0
 )
 
+// paginationParams are consumed by getPagination and never become filters.
+var paginationParams = map[string]struct{}{"limit": {}, "page": {}}
+
 func ParseQueryParams(values map[string][]string) ([]FilterOption, Pagination, error) {
 	var filterOptions []FilterOption
 
 	for field, value := range values {
+		if _, ok := paginationParams[field]; ok {
+			continue
+		}
+
 		for _, v := range value {
 			if strings.Contains(v, ":") {
-				parts := strings.Split(v, ":")
-				if len(parts) == 2 {
-					operator := parts[0]
-					queryValue := parts[1]
-					switch operator {
-					case "gt", "lt", "ge", "le", "eq", "ne", "order_by", "like":
-						filterOptions = append(filterOptions, FilterOption{Operator: operator, Field: field, Value: queryValue})
-						continue
-					default:
-						//TODO err
-					}
+				// Only the first colon ends the operator; values such as RFC 3339 timestamps hold more.
+				parts := strings.SplitN(v, ":", 2)
+				operator := parts[0]
+				queryValue := parts[1]
+				switch operator {
+				case "gt", "lt", "ge", "le", "eq", "ne", "order_by", "like":
+					filterOptions = append(filterOptions, FilterOption{Operator: operator, Field: field, Value: queryValue})
+					continue
+				default:
+					//TODO err
 				}
-				//TODO err
 			}
 			filterOptions = append(filterOptions, FilterOption{Field: field, Value: v})
 		}
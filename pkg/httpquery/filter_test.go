@@ -0,0 +1,47 @@
+package httpquery
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseQueryParams(t *testing.T) {
+	testTable := []struct {
+		name                  string
+		inputValues           map[string][]string
+		expectedFilterOptions []FilterOption
+		expectedPagination    Pagination
+	}{
+		{
+			name:                  "OPERATOR",
+			inputValues:           map[string][]string{"id": {"gt:3"}, "limit": {"10"}},
+			expectedFilterOptions: []FilterOption{{Operator: "gt", Field: "id", Value: "3"}},
+			expectedPagination:    Pagination{Limit: 10, Page: 0},
+		},
+		{
+			name:        "DUE AT RANGE",
+			inputValues: map[string][]string{"due_at": {"ge:2024-01-01T00:00:00Z", "lt:2024-01-01T12:00:00Z"}},
+			expectedFilterOptions: []FilterOption{
+				{Operator: "ge", Field: "due_at", Value: "2024-01-01T00:00:00Z"},
+				{Operator: "lt", Field: "due_at", Value: "2024-01-01T12:00:00Z"},
+			},
+			expectedPagination: Pagination{Limit: 100, Page: 0},
+		},
+		{
+			name:                  "NO OPERATOR",
+			inputValues:           map[string][]string{"tags": {"all:bug,urgent"}},
+			expectedFilterOptions: []FilterOption{{Field: "tags", Value: "all:bug,urgent"}},
+			expectedPagination:    Pagination{Limit: 100, Page: 0},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			filterOptions, pagination, err := ParseQueryParams(testCase.inputValues)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedFilterOptions, filterOptions)
+			assert.Equal(t, testCase.expectedPagination, pagination)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// _listenFdsStart is SD_LISTEN_FDS_START, the first file descriptor passed by systemd.
+const _listenFdsStart = 3
+
+var errNoSystemdSocket = errors.New("httpserver - no socket passed by systemd")
+
+func listenTCP(addr string) (net.Listener, error) {
+	return net.Listen("tcp", addr)
+}
+
+// listenUnix removes a stale socket file left by a previous run before binding.
+func listenUnix(path string) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("httpserver - remove stale socket: %w", err)
+	}
+	return net.Listen("unix", path)
+}
+
+// listenSystemd picks up a socket passed through the sd_listen_fds protocol, by name when given.
+func listenSystemd(name string) (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, errNoSystemdSocket
+	}
+
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count < 1 {
+		return nil, errNoSystemdSocket
+	}
+
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+	for i := 0; i < count; i++ {
+		if name != "" && (i >= len(names) || names[i] != name) {
+			continue
+		}
+
+		fd := uintptr(_listenFdsStart + i)
+		f := os.NewFile(fd, "systemd-socket-"+strconv.Itoa(i))
+		ln, err := net.FileListener(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("httpserver - systemd fd %d: %w", fd, err)
+		}
+		return ln, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", errNoSystemdSocket, name)
+}
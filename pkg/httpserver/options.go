@@ -15,6 +15,13 @@ func Port(port string) Option {
 	}
 }
 
+// Address binds to the given host, e.g. 127.0.0.1 to stay off external interfaces.
+func Address(host, port string) Option {
+	return func(s *Server) {
+		s.server.Addr = net.JoinHostPort(host, port)
+	}
+}
+
 // ReadTimeout -.
 func ReadTimeout(timeout time.Duration) Option {
 	return func(s *Server) {
@@ -29,9 +36,80 @@ func WriteTimeout(timeout time.Duration) Option {
 	}
 }
 
+// IdleTimeout -.
+func IdleTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.server.IdleTimeout = timeout
+	}
+}
+
+// ReadHeaderTimeout -.
+func ReadHeaderTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.server.ReadHeaderTimeout = timeout
+	}
+}
+
+// MaxHeaderBytes -.
+func MaxHeaderBytes(n int) Option {
+	return func(s *Server) {
+		s.server.MaxHeaderBytes = n
+	}
+}
+
+// Listener serves on a caller-supplied listener instead of binding Addr.
+func Listener(ln net.Listener) Option {
+	return func(s *Server) {
+		s.listen = func() (net.Listener, error) { return ln, nil }
+	}
+}
+
+// UnixSocket serves on a unix domain socket at path.
+func UnixSocket(path string) Option {
+	return func(s *Server) {
+		s.listen = func() (net.Listener, error) { return listenUnix(path) }
+	}
+}
+
+// SystemdSocket serves on a socket passed by systemd socket activation; name matches FileDescriptorName=, empty takes the first.
+func SystemdSocket(name string) Option {
+	return func(s *Server) {
+		s.listen = func() (net.Listener, error) { return listenSystemd(name) }
+	}
+}
+
 // ShutdownTimeout -.
 func ShutdownTimeout(timeout time.Duration) Option {
 	return func(s *Server) {
 		s.shutdownTimeout = timeout
 	}
 }
+
+// TLS serves HTTPS (and HTTP/2) with the given PEM files, reloaded on SIGHUP or when they change.
+func TLS(certFile, keyFile string) Option {
+	return func(s *Server) {
+		s.tlsFiles.certFile = certFile
+		s.tlsFiles.keyFile = keyFile
+	}
+}
+
+// MinTLSVersion -.
+func MinTLSVersion(version uint16) Option {
+	return func(s *Server) {
+		s.tlsFiles.minVersion = version
+	}
+}
+
+// ClientCA requires clients to present a certificate signed by the given CA bundle (mTLS).
+func ClientCA(caFile string) Option {
+	return func(s *Server) {
+		s.tlsFiles.caFile = caFile
+	}
+}
+
+// H2C accepts cleartext HTTP/2, meant for internal traffic behind a proxy.
+func H2C() Option {
+	return func(s *Server) {
+		s.h2c = true
+	}
+}
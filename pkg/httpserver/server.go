@@ -3,7 +3,12 @@ package httpserver
 
 import (
 	"context"
+	"crypto/tls"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -19,6 +24,13 @@ type Server struct {
 	server          *http.Server
 	notify          chan error
 	shutdownTimeout time.Duration
+
+	listen   func() (net.Listener, error)
+	listener net.Listener
+	tlsFiles tlsFiles
+	h2c      bool
+	done     chan struct{}
+	doneOnce sync.Once
 }
 
 // New -.
@@ -34,6 +46,8 @@ func New(handler http.Handler, opts ...Option) *Server {
 		server:          httpServer,
 		notify:          make(chan error, 1),
 		shutdownTimeout: _defaultShutdownTimeout,
+		tlsFiles:        tlsFiles{minVersion: tls.VersionTLS12},
+		done:            make(chan struct{}),
 	}
 
 	// Custom options
@@ -41,16 +55,58 @@ func New(handler http.Handler, opts ...Option) *Server {
 		opt(s)
 	}
 
-	s.start()
+	if s.h2c {
+		s.server.Handler = h2c.NewHandler(s.server.Handler, &http2.Server{})
+	}
 
 	return s
 }
 
-func (s *Server) start() {
+// Start binds the listener and serves in the background; serve errors arrive on Notify.
+func (s *Server) Start() error {
+	ln, err := s.bind()
+	if err != nil {
+		return err
+	}
+
+	if s.tlsFiles.certFile != "" {
+		reloader := newCertReloader(s.tlsFiles)
+		if err = reloader.load(); err != nil {
+			_ = ln.Close()
+			return err
+		}
+		s.server.TLSConfig = reloader.config()
+
+		go reloader.watch(s.done, s.server.ErrorLog)
+	}
+
+	s.listener = ln
+
 	go func() {
-		s.notify <- s.server.ListenAndServe()
+		if s.server.TLSConfig != nil {
+			s.notify <- s.server.ServeTLS(ln, "", "")
+		} else {
+			s.notify <- s.server.Serve(ln)
+		}
 		close(s.notify)
 	}()
+
+	return nil
+}
+
+func (s *Server) bind() (net.Listener, error) {
+	if s.listen != nil {
+		return s.listen()
+	}
+	return listenTCP(s.server.Addr)
+}
+
+// Addr returns the bound address, e.g. the port picked for ":0". It is nil before Start.
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
 }
 
 // Notify -.
@@ -60,6 +116,8 @@ func (s *Server) Notify() <-chan error {
 
 // Shutdown -.
 func (s *Server) Shutdown() error {
+	s.doneOnce.Do(func() { close(s.done) })
+
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
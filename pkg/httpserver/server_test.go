@@ -0,0 +1,89 @@
+package httpserver
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestServer_Start(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	})
+
+	socket := filepath.Join(t.TempDir(), "http.sock")
+
+	testTable := []struct {
+		name   string
+		opts   func(t *testing.T) []Option
+		client func(s *Server) *http.Client
+	}{
+		{
+			name: "PORT 0",
+			opts: func(t *testing.T) []Option {
+				return []Option{Port("0")}
+			},
+			client: func(s *Server) *http.Client { return http.DefaultClient },
+		},
+		{
+			name: "LISTENER",
+			opts: func(t *testing.T) []Option {
+				ln, err := net.Listen("tcp", "127.0.0.1:0")
+				require.NoError(t, err)
+				return []Option{Listener(ln)}
+			},
+			client: func(s *Server) *http.Client { return http.DefaultClient },
+		},
+		{
+			name: "UNIX SOCKET",
+			opts: func(t *testing.T) []Option {
+				return []Option{UnixSocket(socket)}
+			},
+			client: func(s *Server) *http.Client {
+				return &http.Client{Transport: &http.Transport{
+					DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+						return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+					},
+				}}
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New(handler, testCase.opts(t)...)
+			assert.Nil(t, s.Addr())
+
+			require.NoError(t, s.Start())
+			defer s.Shutdown()
+
+			url := "http://unix/"
+			if addr, ok := s.Addr().(*net.TCPAddr); ok {
+				url = "http://" + net.JoinHostPort("127.0.0.1", strconv.Itoa(addr.Port)) + "/"
+			}
+
+			resp, err := testCase.client(s).Get(url)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "ok", string(body))
+		})
+	}
+}
+
+func TestServer_SystemdSocketMissing(t *testing.T) {
+	t.Setenv("LISTEN_PID", "")
+	t.Setenv("LISTEN_FDS", "")
+
+	s := New(http.NotFoundHandler(), SystemdSocket(""))
+
+	assert.ErrorIs(t, s.Start(), errNoSystemdSocket)
+}
@@ -0,0 +1,177 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+)
+
+var errNoCACertificates = errors.New("httpserver - no certificates found in client CA bundle")
+
+type tlsFiles struct {
+	certFile   string
+	keyFile    string
+	caFile     string
+	minVersion uint16
+}
+
+// certReloader serves the certificate and client CA pool most recently read from disk.
+// A failed reload keeps the previous material, so a half-written file never takes the listener down.
+type certReloader struct {
+	files tlsFiles
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+	pool *x509.CertPool
+}
+
+func newCertReloader(files tlsFiles) *certReloader {
+	return &certReloader{files: files}
+}
+
+func (r *certReloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.files.certFile, r.files.keyFile)
+	if err != nil {
+		return fmt.Errorf("httpserver - load key pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.files.caFile != "" {
+		pem, err := os.ReadFile(r.files.caFile)
+		if err != nil {
+			return fmt.Errorf("httpserver - read client CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errNoCACertificates
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.pool = pool
+
+	return nil
+}
+
+func (r *certReloader) config() *tls.Config {
+	cfg := r.baseConfig()
+	if r.files.caFile != "" {
+		cfg.GetConfigForClient = r.getConfigForClient
+	}
+	return cfg
+}
+
+func (r *certReloader) baseConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion:     r.files.minVersion,
+		NextProtos:     []string{"h2", "http/1.1"},
+		GetCertificate: r.getCertificate,
+	}
+	if r.files.caFile != "" {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// getConfigForClient hands out the current CA pool, which a static tls.Config could not pick up after a reload.
+func (r *certReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cfg := r.baseConfig()
+	cfg.ClientCAs = r.pool
+	return cfg, nil
+}
+
+// watch reloads on SIGHUP and on any change in the directories holding the files.
+// Directories rather than files are watched so atomic renames, as done for mounted secrets, are seen.
+func (r *certReloader) watch(done <-chan struct{}, logger *log.Logger) {
+	logf := log.Printf
+	if logger != nil {
+		logf = logger.Printf
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var events <-chan fsnotify.Event
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logf("httpserver - tls watcher: %v", err)
+	} else {
+		defer watcher.Close()
+		for _, dir := range r.dirs() {
+			if err = watcher.Add(dir); err != nil {
+				logf("httpserver - tls watch %s: %v", dir, err)
+			}
+		}
+		events = watcher.Events
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-hup:
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+		}
+
+		if err = r.load(); err != nil {
+			logf("httpserver - tls reload: %v", err)
+		}
+	}
+}
+
+func (r *certReloader) dirs() []string {
+	seen := make(map[string]struct{})
+	var dirs []string
+	for _, file := range []string{r.files.certFile, r.files.keyFile, r.files.caFile} {
+		if file == "" {
+			continue
+		}
+		dir := filepath.Dir(file)
+		if _, ok := seen[dir]; !ok {
+			seen[dir] = struct{}{}
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// ParseTLSVersion maps "1.0".."1.3" to the crypto/tls constant; empty means TLS 1.2.
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("httpserver - unknown TLS version %q", version)
+	}
+}
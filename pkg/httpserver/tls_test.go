@@ -0,0 +1,88 @@
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeKeyPair(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, r *certReloader) string {
+	t.Helper()
+
+	cert, err := r.getCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+
+	return leaf.Subject.CommonName
+}
+
+func TestCertReloader_Watch(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeKeyPair(t, dir, "first")
+
+	r := newCertReloader(tlsFiles{certFile: certFile, keyFile: keyFile})
+	require.NoError(t, r.load())
+	assert.Equal(t, "first", commonName(t, r))
+
+	done := make(chan struct{})
+	defer close(done)
+	go r.watch(done, nil)
+
+	// Give the watcher time to register before the files change.
+	time.Sleep(100 * time.Millisecond)
+	writeKeyPair(t, dir, "second")
+
+	assert.Eventually(t, func() bool {
+		return commonName(t, r) == "second"
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestCertReloader_LoadKeepsPreviousOnError(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeKeyPair(t, dir, "first")
+
+	r := newCertReloader(tlsFiles{certFile: certFile, keyFile: keyFile})
+	require.NoError(t, r.load())
+
+	require.NoError(t, os.WriteFile(keyFile, []byte("garbage"), 0o600))
+
+	assert.Error(t, r.load())
+	assert.Equal(t, "first", commonName(t, r))
+}
@@ -0,0 +1,188 @@
+// Package lifecycle implements ordered start and graceful stop of application components.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	_defaultStopTimeout = 15 * time.Second
+	// _lateStopTimeout bounds each hook that is still to stop once the stop timeout has passed.
+	_lateStopTimeout = time.Second
+)
+
+// Hook is a component with start and stop callbacks. Hooks start in ascending
+// Priority and stop in the reverse order, so what starts first stops last.
+type Hook struct {
+	Name     string
+	Priority int
+	OnStart  func(ctx context.Context) error
+	OnStop   func(ctx context.Context) error
+	// Err, when set, reports a failure of the running component and triggers shutdown.
+	Err <-chan error
+}
+
+// Manager -.
+type Manager struct {
+	l           logger.Interface
+	mu          sync.Mutex
+	hooks       []Hook
+	started     []Hook
+	stopTimeout time.Duration
+	signals     []os.Signal
+}
+
+// New -.
+func New(l logger.Interface, opts ...Option) *Manager {
+	m := &Manager{
+		l:           l,
+		stopTimeout: _defaultStopTimeout,
+		signals:     []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Append registers a hook. Hooks with equal priority keep registration order.
+func (m *Manager) Append(h Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.hooks = append(m.hooks, h)
+}
+
+// Start runs OnStart hooks in priority order. On failure the hooks already started are stopped.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	hooks := make([]Hook, len(m.hooks))
+	copy(hooks, m.hooks)
+	m.mu.Unlock()
+
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].Priority < hooks[j].Priority })
+
+	for _, h := range hooks {
+		if h.OnStart != nil {
+			if err := h.OnStart(ctx); err != nil {
+				err = fmt.Errorf("lifecycle - start %s: %w", h.Name, err)
+				m.l.Error(err)
+				_ = m.Stop(context.Background())
+				return err
+			}
+		}
+
+		m.mu.Lock()
+		m.started = append(m.started, h)
+		m.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Wait blocks until a shutdown signal arrives, ctx is done or a component reports an error.
+func (m *Manager) Wait(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, m.signals...)
+	defer stop()
+
+	m.mu.Lock()
+	failed := make(chan error, len(m.started))
+	for _, h := range m.started {
+		if h.Err == nil {
+			continue
+		}
+		go func(h Hook) {
+			select {
+			case err, ok := <-h.Err:
+				if ok {
+					failed <- fmt.Errorf("lifecycle - %s: %w", h.Name, err)
+				}
+			case <-ctx.Done():
+			}
+		}(h)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		m.l.Info("lifecycle - shutdown requested")
+		return nil
+	case err := <-failed:
+		m.l.Error(err)
+		return err
+	}
+}
+
+// Stop runs OnStop hooks of started components in reverse priority order within the stop timeout. A failing
+// hook is logged and a hanging one abandoned at the deadline; neither prevents the remaining hooks from running.
+// Hooks still to stop after the deadline get _lateStopTimeout each, so that they can release their resources.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	started := m.started
+	m.started = nil
+	m.mu.Unlock()
+
+	deadline := time.Now().Add(m.stopTimeout)
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		h := started[i]
+		if h.OnStop == nil {
+			continue
+		}
+
+		if err := m.stop(ctx, deadline, h); err != nil {
+			err = fmt.Errorf("lifecycle - stop %s: %w", h.Name, err)
+			m.l.Error(err)
+			errs = append(errs, err)
+			continue
+		}
+		m.l.Info("lifecycle - stopped " + h.Name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// stop runs the OnStop hook until the deadline or, once that has passed, for _lateStopTimeout.
+func (m *Manager) stop(ctx context.Context, deadline time.Time, h Hook) error {
+	var cancel context.CancelFunc
+	if time.Now().Before(deadline) {
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+	} else {
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), _lateStopTimeout)
+	}
+	defer cancel()
+
+	return run(ctx, h.OnStop)
+}
+
+// run returns once fn does or ctx is done, whichever comes first.
+func run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
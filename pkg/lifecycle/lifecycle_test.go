@@ -0,0 +1,129 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"github.com/Vaixle/crud-golang/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestManager_StartStop(t *testing.T) {
+	var calls []string
+	hook := func(name string, priority int, stopErr error) Hook {
+		return Hook{
+			Name:     name,
+			Priority: priority,
+			OnStart: func(context.Context) error {
+				calls = append(calls, "start "+name)
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				calls = append(calls, "stop "+name)
+				return stopErr
+			},
+		}
+	}
+
+	m := New(logger.New("error"))
+	m.Append(hook("http", 30, nil))
+	m.Append(hook("postgres", 0, errors.New("close failed")))
+	m.Append(hook("workers", 10, nil))
+
+	require.NoError(t, m.Start(context.Background()))
+	err := m.Stop(context.Background())
+
+	assert.ErrorContains(t, err, "stop postgres: close failed")
+	assert.Equal(t, []string{
+		"start postgres", "start workers", "start http",
+		"stop http", "stop workers", "stop postgres",
+	}, calls)
+}
+
+func TestManager_StartFailureStopsStarted(t *testing.T) {
+	var stopped []string
+
+	m := New(logger.New("error"))
+	m.Append(Hook{Name: "postgres", Priority: 0, OnStop: func(context.Context) error {
+		stopped = append(stopped, "postgres")
+		return nil
+	}})
+	m.Append(Hook{Name: "http", Priority: 10, OnStart: func(context.Context) error {
+		return errors.New("address in use")
+	}, OnStop: func(context.Context) error {
+		stopped = append(stopped, "http")
+		return nil
+	}})
+
+	err := m.Start(context.Background())
+
+	assert.ErrorContains(t, err, "start http: address in use")
+	assert.Equal(t, []string{"postgres"}, stopped)
+}
+
+func TestManager_StopDeadline(t *testing.T) {
+	var stopped []string
+
+	m := New(logger.New("error"), StopTimeout(50*time.Millisecond))
+	m.Append(Hook{Name: "postgres", Priority: 0, OnStop: func(ctx context.Context) error {
+		stopped = append(stopped, "postgres")
+		return nil
+	}})
+	m.Append(Hook{Name: "workers", Priority: 10, OnStop: func(ctx context.Context) error {
+		<-make(chan struct{})
+		return nil
+	}})
+
+	require.NoError(t, m.Start(context.Background()))
+
+	start := time.Now()
+	err := m.Stop(context.Background())
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "stop workers")
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, []string{"postgres"}, stopped, "hooks after the hanging one still run")
+}
+
+func TestManager_StopAfterDeadline(t *testing.T) {
+	var late []time.Duration
+
+	m := New(logger.New("error"), StopTimeout(50*time.Millisecond))
+	m.Append(Hook{Name: "postgres", Priority: 0, OnStop: func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		late = append(late, time.Until(deadline))
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	m.Append(Hook{Name: "workers", Priority: 10, OnStop: func(ctx context.Context) error {
+		<-make(chan struct{})
+		return nil
+	}})
+
+	require.NoError(t, m.Start(context.Background()))
+
+	start := time.Now()
+	err := m.Stop(context.Background())
+
+	// A hook that hangs past the deadline as well is abandoned once its own time is up.
+	assert.ErrorContains(t, err, "stop workers")
+	assert.ErrorContains(t, err, "stop postgres")
+	require.Len(t, late, 1)
+	assert.Greater(t, late[0], 500*time.Millisecond)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestManager_WaitComponentFailure(t *testing.T) {
+	failed := make(chan error, 1)
+
+	m := New(logger.New("error"))
+	m.Append(Hook{Name: "http", Err: failed})
+	require.NoError(t, m.Start(context.Background()))
+
+	failed <- errors.New("listener closed")
+
+	assert.ErrorContains(t, m.Wait(context.Background()), "http: listener closed")
+}
@@ -0,0 +1,25 @@
+package lifecycle
+
+import (
+	"os"
+	"time"
+)
+
+// Option -.
+type Option func(*Manager)
+
+// StopTimeout is the overall deadline for stopping every component.
+func StopTimeout(timeout time.Duration) Option {
+	return func(m *Manager) {
+		if timeout > 0 {
+			m.stopTimeout = timeout
+		}
+	}
+}
+
+// Signals overrides the signals that trigger shutdown, SIGINT and SIGTERM by default.
+func Signals(signals ...os.Signal) Option {
+	return func(m *Manager) {
+		m.signals = signals
+	}
+}
@@ -1,11 +1,13 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Interface -.
@@ -15,6 +17,7 @@ type Interface interface {
 	Warn(message string, args ...interface{})
 	Error(message interface{}, args ...interface{})
 	Fatal(message interface{}, args ...interface{})
+	WithContext(ctx context.Context) Interface
 }
 
 // Logger -.
@@ -26,18 +29,8 @@ var _ Interface = (*Logger)(nil)
 
 // New -.
 func New(level string) *Logger {
-	var l zerolog.Level
-
-	switch strings.ToLower(level) {
-	case "error":
-		l = zerolog.ErrorLevel
-	case "warn":
-		l = zerolog.WarnLevel
-	case "info":
-		l = zerolog.InfoLevel
-	case "debug":
-		l = zerolog.DebugLevel
-	default:
+	l, err := parseLevel(level)
+	if err != nil {
 		l = zerolog.InfoLevel
 	}
 
@@ -51,6 +44,37 @@ func New(level string) *Logger {
 	}
 }
 
+// Level returns the current global log level.
+func Level() string {
+	return zerolog.GlobalLevel().String()
+}
+
+// SetLevel changes the global log level at runtime.
+func SetLevel(level string) error {
+	l, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	zerolog.SetGlobalLevel(l)
+	return nil
+}
+
+func parseLevel(level string) (zerolog.Level, error) {
+	switch strings.ToLower(level) {
+	case "error":
+		return zerolog.ErrorLevel, nil
+	case "warn":
+		return zerolog.WarnLevel, nil
+	case "info":
+		return zerolog.InfoLevel, nil
+	case "debug":
+		return zerolog.DebugLevel, nil
+	default:
+		return zerolog.NoLevel, fmt.Errorf("unknown log level %q", level)
+	}
+}
+
 // Debug -.
 func (l *Logger) Debug(message interface{}, args ...interface{}) {
 	l.msg("debug", message, args...)
@@ -82,6 +106,20 @@ func (l *Logger) Fatal(message interface{}, args ...interface{}) {
 	os.Exit(1)
 }
 
+// WithContext returns a logger that adds the trace and span ids found in ctx to every line.
+func (l *Logger) WithContext(ctx context.Context) Interface {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+
+	logger := l.logger.With().Str("trace_id", sc.TraceID().String()).Str("span_id", sc.SpanID().String()).Logger()
+
+	return &Logger{
+		logger: &logger,
+	}
+}
+
 func (l *Logger) log(message string, args ...interface{}) {
 	if len(args) == 0 {
 		l.logger.Info().Msg(message)
@@ -0,0 +1,285 @@
+package mediatype
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Unmarshal decodes data of the media type into value as json.Unmarshal decodes the same data in JSON. XML
+// has no types of its own, so its text is read as the number, boolean, list or object value has in its place.
+func Unmarshal(mediaType string, data []byte, value interface{}) error {
+	var form interface{}
+	var err error
+	switch mediaType {
+	case JSON:
+		return json.Unmarshal(data, value)
+	case YAML:
+		if err = yaml.Unmarshal(data, &form); err == nil {
+			form, err = stringKeys(form)
+		}
+	case MsgPack:
+		err = codec.NewDecoderBytes(data, _msgpack).Decode(&form)
+	case XML:
+		var root *element
+		if root, err = parseXML(data); err == nil {
+			form, err = coerce(root, reflect.TypeOf(value))
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupported, mediaType)
+	}
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(form)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, value)
+}
+
+// stringKeys turns the maps YAML has with keys other than strings into maps JSON can encode.
+func stringKeys(form interface{}) (interface{}, error) {
+	var err error
+	switch form := form.(type) {
+	case map[string]interface{}:
+		for key, value := range form {
+			if form[key], err = stringKeys(value); err != nil {
+				return nil, err
+			}
+		}
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(form))
+		for key, value := range form {
+			if converted[fmt.Sprint(key)], err = stringKeys(value); err != nil {
+				return nil, err
+			}
+		}
+		return converted, nil
+	case []interface{}:
+		for i, value := range form {
+			if form[i], err = stringKeys(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return form, nil
+}
+
+// element is an XML element; key is the key attribute of an entry element, or else its name.
+type element struct {
+	key      string
+	text     string
+	children []*element
+}
+
+func parseXML(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root *element
+	var stack []*element
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			e := &element{key: token.Name.Local}
+			if token.Name.Local == _entry {
+				for _, attr := range token.Attr {
+					if attr.Name.Local == "key" {
+						e.key = attr.Value
+					}
+				}
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			} else if root == nil {
+				root = e
+			}
+			stack = append(stack, e)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(token)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("no XML element")
+	}
+	return root, nil
+}
+
+var (
+	_jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	_textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// coerce reads the element as the JSON form of a value of the type: the children of an element in place of a
+// list are its values and those in place of an object or a map its members. An empty element in place of
+// anything but a string, list, object or map is null.
+func coerce(e *element, t reflect.Type) (interface{}, error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() == reflect.Interface {
+		return generic(e), nil
+	}
+
+	text := strings.TrimSpace(e.text)
+	if reflect.PtrTo(t).Implements(_jsonUnmarshaler) || reflect.PtrTo(t).Implements(_textUnmarshaler) {
+		if text == "" && len(e.children) == 0 {
+			return nil, nil
+		}
+		return text, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := jsonFields(t)
+		members := make(map[string]interface{}, len(e.children))
+		for _, child := range e.children {
+			field, ok := lookupField(fields, child.key)
+			if !ok {
+				continue
+			}
+			value, err := coerce(child, field)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", child.key, err)
+			}
+			members[child.key] = value
+		}
+		return members, nil
+	case reflect.Map:
+		members := make(map[string]interface{}, len(e.children))
+		for _, child := range e.children {
+			value, err := coerce(child, t.Elem())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", child.key, err)
+			}
+			members[child.key] = value
+		}
+		return members, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return text, nil
+		}
+		values := make([]interface{}, 0, len(e.children))
+		for _, child := range e.children {
+			value, err := coerce(child, t.Elem())
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case reflect.String:
+		return e.text, nil
+	}
+
+	if text == "" {
+		return nil, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(text, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(text, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(text, 64)
+	default:
+		return nil, fmt.Errorf("%w: XML for %s", ErrUnsupported, t)
+	}
+}
+
+// generic reads an element with no type to go by: text without children, a list when the children are all
+// items and an object otherwise.
+func generic(e *element) interface{} {
+	if len(e.children) == 0 {
+		return e.text
+	}
+	list := true
+	for _, child := range e.children {
+		list = list && child.key == _item
+	}
+	if list {
+		values := make([]interface{}, 0, len(e.children))
+		for _, child := range e.children {
+			values = append(values, generic(child))
+		}
+		return values
+	}
+	members := make(map[string]interface{}, len(e.children))
+	for _, child := range e.children {
+		members[child.key] = generic(child)
+	}
+	return members
+}
+
+// jsonFields maps the JSON names of the fields of a struct to their types, with those of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, value := range jsonFields(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = value
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// lookupField finds a field the way encoding/json does: by its exact name, else case-insensitively.
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if field, ok := fields[key]; ok {
+		return field, true
+	}
+	for name, field := range fields {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return nil, false
+}
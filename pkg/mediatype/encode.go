@@ -0,0 +1,353 @@
+package mediatype
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// _root is the document element of XML, _item the element of each value of a list and _entry that of a member
+// whose key is not an XML name.
+const (
+	_root  = "response"
+	_item  = "item"
+	_entry = "entry"
+)
+
+var _msgpack = &codec.MsgpackHandle{WriteExt: true}
+
+func init() {
+	_msgpack.RawToString = true
+	_msgpack.MapType = reflect.TypeOf(map[string]interface{}(nil))
+}
+
+// object is a JSON object with its members in order, as alternating keys and values.
+type object []interface{}
+
+// MapBySlice has MessagePack encode the object as a map.
+func (object) MapBySlice() {}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i := 0; i < len(o); i += 2 {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(o[i])
+		value, err := json.Marshal(o[i+1])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Marshal encodes value as the media type. A value that is not a list of objects is a single row of CSV, or a
+// single value column when it is not an object; nested values are JSON in their cell.
+func Marshal(mediaType string, value interface{}) ([]byte, error) {
+	if mediaType == JSON {
+		return json.Marshal(value)
+	}
+	if _, ok := _contentTypes[mediaType]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, mediaType)
+	}
+
+	form, err := jsonForm(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch mediaType {
+	case YAML:
+		err = encodeYAML(&buf, form)
+	case XML:
+		err = encodeXML(&buf, form)
+	case MsgPack:
+		err = codec.NewEncoder(&buf, _msgpack).Encode(msgpackValue(form))
+	case CSV:
+		err = encodeCSV(&buf, form)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonForm is value encoded to JSON and read back as strings, json.Number, bool, nil, []interface{} and object.
+func jsonForm(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return readJSON(decoder)
+}
+
+func readJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		o := object{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := readJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			o = append(o, key, value)
+		}
+		_, err = decoder.Token()
+		return o, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := readJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	default:
+		return token, nil
+	}
+}
+
+func encodeYAML(w io.Writer, form interface{}) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNode(form)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func yamlNode(form interface{}) *yaml.Node {
+	switch form := form.(type) {
+	case object:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for i := 0; i < len(form); i += 2 {
+			node.Content = append(node.Content, yamlNode(form[i]), yamlNode(form[i+1]))
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, value := range form {
+			node.Content = append(node.Content, yamlNode(value))
+		}
+		return node
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: form}
+	case json.Number:
+		if strings.ContainsAny(string(form), ".eE") {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: string(form)}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: string(form)}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(form)}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+}
+
+// encodeXML writes the value as the document element. Members are elements named by their key, or entry
+// elements with a key attribute when the key is not an XML name; values of a list are item elements.
+func encodeXML(w io.Writer, form interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	if err := writeXML(encoder, _root, form); err != nil {
+		return err
+	}
+	return encoder.Flush()
+}
+
+func writeXML(encoder *xml.Encoder, name string, form interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if !isXMLName(name) {
+		start = xml.StartElement{Name: xml.Name{Local: _entry}, Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}}}
+	}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	var err error
+	switch form := form.(type) {
+	case object:
+		for i := 0; i < len(form) && err == nil; i += 2 {
+			err = writeXML(encoder, form[i].(string), form[i+1])
+		}
+	case []interface{}:
+		for i := 0; i < len(form) && err == nil; i++ {
+			err = writeXML(encoder, _item, form[i])
+		}
+	case nil:
+	default:
+		err = encoder.EncodeToken(xml.CharData(scalar(form)))
+	}
+	if err != nil {
+		return err
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// isXMLName reports whether the key can name an element: a letter or underscore, then letters, digits,
+// underscores, hyphens and dots, and not starting with xml.
+func isXMLName(key string) bool {
+	if key == "" || strings.HasPrefix(strings.ToLower(key), "xml") {
+		return false
+	}
+	for i, r := range key {
+		switch {
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+		case i > 0 && (r == '-' || r == '.' || (r >= '0' && r <= '9')):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// msgpackValue turns the numbers of the JSON form into integers, or floats when they are not.
+func msgpackValue(form interface{}) interface{} {
+	switch form := form.(type) {
+	case object:
+		for i := 1; i < len(form); i += 2 {
+			form[i] = msgpackValue(form[i])
+		}
+	case []interface{}:
+		for i := range form {
+			form[i] = msgpackValue(form[i])
+		}
+	case json.Number:
+		if n, err := form.Int64(); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseUint(string(form), 10, 64); err == nil {
+			return n
+		}
+		n, _ := form.Float64()
+		return n
+	}
+	return form
+}
+
+// encodeCSV writes a row per value of a list, with a column per key of its objects in the order first seen.
+func encodeCSV(w io.Writer, form interface{}) error {
+	rows, ok := form.([]interface{})
+	if !ok {
+		rows = []interface{}{form}
+	}
+
+	var columns []string
+	index := make(map[string]int)
+	addColumn := func(name string) {
+		if _, ok := index[name]; !ok {
+			index[name] = len(columns)
+			columns = append(columns, name)
+		}
+	}
+	for _, row := range rows {
+		if o, ok := row.(object); ok {
+			for i := 0; i < len(o); i += 2 {
+				addColumn(o[i].(string))
+			}
+		} else {
+			addColumn("value")
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		o, ok := row.(object)
+		if !ok {
+			o = object{"value", row}
+		}
+		for i := 0; i < len(o); i += 2 {
+			cell, err := csvCell(o[i+1])
+			if err != nil {
+				return err
+			}
+			record[index[o[i].(string)]] = cell
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvCell(form interface{}) (string, error) {
+	switch form := form.(type) {
+	case object, []interface{}:
+		data, err := json.Marshal(form)
+		return string(data), err
+	case string:
+		return EscapeCSVCell(form), nil
+	default:
+		return scalar(form), nil
+	}
+}
+
+// _formulaPrefixes start cells that spreadsheets evaluate as formulas.
+const _formulaPrefixes = "=+-@\t\r"
+
+// EscapeCSVCell puts an apostrophe before a cell that a spreadsheet would run as a formula, so that it is shown
+// as text instead.
+func EscapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune(_formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// UnescapeCSVCell drops the apostrophe EscapeCSVCell puts; other leading apostrophes are kept.
+func UnescapeCSVCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(_formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// scalar is the text of a string, number or boolean of the JSON form, and empty for null.
+func scalar(form interface{}) string {
+	switch form := form.(type) {
+	case string:
+		return form
+	case json.Number:
+		return string(form)
+	case bool:
+		return strconv.FormatBool(form)
+	default:
+		return ""
+	}
+}
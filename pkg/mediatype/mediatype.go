@@ -0,0 +1,145 @@
+// Package mediatype negotiates the media type of HTTP responses from the Accept header and converts values
+// between JSON and YAML, XML, MessagePack and CSV. Every type is a view of the JSON form of a value: the
+// same field names, omitted fields and formats of values.
+package mediatype
+
+import (
+	"errors"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	JSON    = "application/json"
+	YAML    = "application/yaml"
+	XML     = "application/xml"
+	MsgPack = "application/msgpack"
+	CSV     = "text/csv"
+)
+
+var ErrUnsupported = errors.New("unsupported media type")
+
+// All lists the media types in the order preferred when the Accept header has no preference among them.
+var All = []string{JSON, YAML, XML, MsgPack, CSV}
+
+// _names maps the format names, and media types in use besides the registered ones, to the media types.
+var _names = map[string]string{
+	"json":                     JSON,
+	"yaml":                     YAML,
+	"yml":                      YAML,
+	"xml":                      XML,
+	"msgpack":                  MsgPack,
+	"csv":                      CSV,
+	"application/x-yaml":       YAML,
+	"text/yaml":                YAML,
+	"text/x-yaml":              YAML,
+	"text/xml":                 XML,
+	"application/x-msgpack":    MsgPack,
+	"application/vnd.msgpack":  MsgPack,
+	"application/vnd.ms-excel": CSV,
+}
+
+// _contentTypes are the Content-Type headers of the media types.
+var _contentTypes = map[string]string{
+	JSON:    "application/json; charset=utf-8",
+	YAML:    "application/yaml; charset=utf-8",
+	XML:     "application/xml; charset=utf-8",
+	MsgPack: MsgPack,
+	CSV:     "text/csv; charset=utf-8",
+}
+
+// Lookup returns the media type of a format name such as yaml, or of a media type with or without parameters.
+func Lookup(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if parsed, _, err := mime.ParseMediaType(name); err == nil {
+		name = parsed
+	}
+	if mediaType, ok := _names[name]; ok {
+		return mediaType, true
+	}
+	if _, ok := _contentTypes[name]; ok {
+		return name, true
+	}
+	return "", false
+}
+
+// ContentType is the Content-Type header of a response of the media type.
+func ContentType(mediaType string) string {
+	return _contentTypes[mediaType]
+}
+
+// accepted is a media range of an Accept header.
+type accepted struct {
+	mediaType string
+	quality   float64
+}
+
+// Negotiate picks the media type of offered that the Accept header rates highest, the more specific of its
+// ranges deciding; ties go to the first offered. An empty header accepts the first offered, and ok is false
+// when the header accepts none of them.
+func Negotiate(accept string, offered []string) (mediaType string, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		if len(offered) == 0 {
+			return "", false
+		}
+		return offered[0], true
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQuality := "", 0.0
+	for _, candidate := range offered {
+		if quality := rate(ranges, candidate); quality > bestQuality {
+			best, bestQuality = candidate, quality
+		}
+	}
+	return best, best != ""
+}
+
+func parseAccept(accept string) []accepted {
+	ranges := make([]accepted, 0, strings.Count(accept, ",")+1)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if value, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(value, 64); err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+		if known, ok := Lookup(mediaType); ok {
+			mediaType = known
+		}
+		ranges = append(ranges, accepted{mediaType: mediaType, quality: quality})
+	}
+	// The most specific ranges first: type/subtype, then type/*, then */*.
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return specificity(ranges[i].mediaType) > specificity(ranges[j].mediaType)
+	})
+	return ranges
+}
+
+func specificity(mediaRange string) int {
+	switch {
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// rate is the quality the most specific range matching the media type gives it, 0 when none does.
+func rate(ranges []accepted, mediaType string) float64 {
+	for _, r := range ranges {
+		if r.mediaType == mediaType || r.mediaType == "*/*" ||
+			(strings.HasSuffix(r.mediaType, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(r.mediaType, "*"))) {
+			return r.quality
+		}
+	}
+	return 0
+}
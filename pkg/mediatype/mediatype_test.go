@@ -0,0 +1,238 @@
+package mediatype
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type child struct {
+	Name string `json:"name"`
+}
+
+type sample struct {
+	ID      uint              `json:"id"`
+	Title   string            `json:"title"`
+	Done    bool              `json:"done"`
+	Score   float64           `json:"score,omitempty"`
+	Due     *time.Time        `json:"due,omitempty"`
+	Tags    []string          `json:"tags"`
+	Parent  *child            `json:"parent,omitempty"`
+	Counts  map[string]int    `json:"counts,omitempty"`
+	Ignored string            `json:"-"`
+	Labels  map[uint]string   `json:"labels,omitempty"`
+	Extra   map[string]string `json:"extra,omitempty"`
+}
+
+func TestLookup(t *testing.T) {
+	testTable := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "FORMAT NAME", input: "yaml", want: YAML},
+		{name: "MEDIA TYPE", input: "application/msgpack", want: MsgPack},
+		{name: "ALIAS WITH PARAMS", input: "text/xml; charset=utf-8", want: XML},
+		{name: "UPPER CASE", input: "CSV", want: CSV},
+		{name: "UNKNOWN", input: "ndjson"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, ok := Lookup(testCase.input)
+			assert.Equal(t, testCase.want != "", ok)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	testTable := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "NO HEADER", accept: "", want: JSON},
+		{name: "ANY", accept: "*/*", want: JSON},
+		{name: "EXACT", accept: "application/yaml", want: YAML},
+		{name: "ALIAS", accept: "application/x-msgpack", want: MsgPack},
+		{name: "QUALITY", accept: "application/json;q=0.5, application/xml", want: XML},
+		{name: "MORE SPECIFIC WINS", accept: "text/*;q=0.9, text/csv;q=0.1, application/json;q=0.5", want: JSON},
+		{name: "TYPE WILDCARD", accept: "text/*", want: CSV},
+		{name: "EXCLUDED", accept: "application/json;q=0, */*", want: YAML},
+		{name: "BROWSER", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: XML},
+		{name: "NONE", accept: "text/html, image/png"},
+		{name: "MALFORMED QUALITY", accept: "application/yaml;q=2"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, ok := Negotiate(testCase.accept, All)
+			assert.Equal(t, testCase.want != "", ok)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	due := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	value := []sample{
+		{ID: 1, Title: "Write <docs> & \"tests\"", Done: true, Score: 1.5, Due: &due, Tags: []string{"a", "true"},
+			Parent: &child{Name: "root"}, Labels: map[uint]string{7: "seven"}},
+		{ID: 2, Title: "123", Tags: []string{}},
+	}
+
+	testTable := []struct {
+		name      string
+		mediaType string
+		want      string
+	}{
+		{
+			name:      "JSON",
+			mediaType: JSON,
+			want: `[{"id":1,"title":"Write \u003cdocs\u003e \u0026 \"tests\"","done":true,"score":1.5,"due":"2024-03-01T09:30:00Z",` +
+				`"tags":["a","true"],"parent":{"name":"root"},"labels":{"7":"seven"}},{"id":2,"title":"123","done":false,"tags":[]}]`,
+		},
+		{
+			name:      "YAML",
+			mediaType: YAML,
+			want: "- id: 1\n  title: Write <docs> & \"tests\"\n  done: true\n  score: 1.5\n  due: \"2024-03-01T09:30:00Z\"\n" +
+				"  tags:\n    - a\n    - \"true\"\n  parent:\n    name: root\n  labels:\n    \"7\": seven\n" +
+				"- id: 2\n  title: \"123\"\n  done: false\n  tags: []\n",
+		},
+		{
+			name:      "XML",
+			mediaType: XML,
+			want: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><item><id>1</id><title>Write &lt;docs&gt; &amp; &#34;tests&#34;</title>` +
+				`<done>true</done><score>1.5</score><due>2024-03-01T09:30:00Z</due><tags><item>a</item><item>true</item></tags>` +
+				`<parent><name>root</name></parent><labels><entry key="7">seven</entry></labels></item>` +
+				`<item><id>2</id><title>123</title><done>false</done><tags></tags></item></response>`,
+		},
+		{
+			name:      "CSV",
+			mediaType: CSV,
+			want: "id,title,done,score,due,tags,parent,labels\n" +
+				"1,\"Write <docs> & \"\"tests\"\"\",true,1.5,2024-03-01T09:30:00Z,\"[\"\"a\"\",\"\"true\"\"]\",\"{\"\"name\"\":\"\"root\"\"}\",\"{\"\"7\"\":\"\"seven\"\"}\"\n" +
+				"2,123,false,,,[],,\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := Marshal(testCase.mediaType, value)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, string(got))
+		})
+	}
+}
+
+func TestMarshalCSV(t *testing.T) {
+	got, err := Marshal(CSV, map[string]int{"open": 2, "done": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "done,open\n1,2\n", string(got))
+
+	got, err = Marshal(CSV, []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, "value\n1\n2\n", string(got))
+
+	got, err = Marshal(CSV, []interface{}{"=1+1", "-x", "'quoted", -1})
+	require.NoError(t, err)
+	assert.Equal(t, "value\n'=1+1\n'-x\n'quoted\n-1\n", string(got))
+}
+
+func TestUnescapeCSVCell(t *testing.T) {
+	for _, value := range []string{"=1+1", "+x", "-x", "@x", "\tx", "'quoted", "'", "plain", ""} {
+		assert.Equal(t, value, UnescapeCSVCell(EscapeCSVCell(value)), value)
+	}
+	assert.Equal(t, "'x", UnescapeCSVCell("'x"))
+}
+
+func TestMarshalUnsupported(t *testing.T) {
+	_, err := Marshal("text/html", []int{1})
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestRoundTrip(t *testing.T) {
+	due := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	value := sample{
+		ID: 3, Title: " spaced ", Done: true, Score: 2.25, Due: &due, Tags: []string{"x", "42"},
+		Parent: &child{Name: "root"}, Counts: map[string]int{"open": 4}, Labels: map[uint]string{7: "seven"},
+		Extra: map[string]string{"not a name": "kept"},
+	}
+
+	for _, mediaType := range []string{JSON, YAML, XML, MsgPack} {
+		t.Run(mediaType, func(t *testing.T) {
+			data, err := Marshal(mediaType, value)
+			require.NoError(t, err)
+
+			var got sample
+			require.NoError(t, Unmarshal(mediaType, data, &got))
+			assert.Equal(t, value, got)
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	testTable := []struct {
+		name      string
+		mediaType string
+		input     string
+		want      sample
+		wantErr   bool
+	}{
+		{
+			name:      "YAML",
+			mediaType: YAML,
+			input:     "id: 4\ntitle: from yaml\ndue: 2024-03-01T09:30:00Z\ntags: [a, b]\nparent: {name: p}\n",
+			want: sample{ID: 4, Title: "from yaml", Due: timePtr(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)),
+				Tags: []string{"a", "b"}, Parent: &child{Name: "p"}},
+		},
+		{
+			name:      "XML ANY ROOT AND ELEMENT NAMES",
+			mediaType: XML,
+			input:     `<task><id>5</id><title>from xml</title><done>1</done><tags><tag>a</tag><tag>b</tag></tags><due/><unknown><x>1</x></unknown></task>`,
+			want:      sample{ID: 5, Title: "from xml", Done: true, Tags: []string{"a", "b"}},
+		},
+		{
+			name:      "XML BAD NUMBER",
+			mediaType: XML,
+			input:     `<task><id>five</id></task>`,
+			wantErr:   true,
+		},
+		{
+			name:      "MALFORMED XML",
+			mediaType: XML,
+			input:     `<task><id>5</id>`,
+			wantErr:   true,
+		},
+		{
+			name:      "MALFORMED YAML",
+			mediaType: YAML,
+			input:     "id: [",
+			wantErr:   true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			var got sample
+			err := Unmarshal(testCase.mediaType, []byte(testCase.input), &got)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestUnmarshalUnsupported(t *testing.T) {
+	var got sample
+	assert.ErrorIs(t, Unmarshal(CSV, []byte("id\n1\n"), &got), ErrUnsupported)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,262 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules used for recurring tasks:
+// FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY (weekly), BYMONTHDAY (monthly), UNTIL and COUNT.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency -.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// _maxPeriods bounds the search for rules that can never match again, e.g. BYMONTHDAY=30 every 12 months from February.
+const _maxPeriods = 100000
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// Rule -.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	// ByDay lists the weekdays of a weekly rule; empty means the weekday of the start.
+	ByDay []time.Weekday
+	// ByMonthDay lists the days of a monthly rule, negative ones counting from the end of the month;
+	// empty means the day of the start. Months without the day are skipped.
+	ByMonthDay []int
+	// Until is the last instant an occurrence may fall on; zero means no limit.
+	Until time.Time
+	// untilDate marks an UNTIL given as a date, which covers that whole day in the location of the start.
+	untilDate bool
+	// Count is the number of occurrences including the start; 0 means no limit.
+	Count int
+}
+
+// Parse reads a rule such as FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR. An optional RRULE: prefix is accepted.
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "RRULE:"), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return rule, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(value)
+		case "BYDAY":
+			rule.ByDay, err = parseWeekdays(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseMonthDays(value)
+		case "UNTIL":
+			rule.Until, rule.untilDate, err = parseUntil(value)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(value)
+		default:
+			return rule, fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, key)
+		}
+		if err != nil {
+			return rule, fmt.Errorf("%w: %s: %v", ErrInvalidRule, key, err)
+		}
+	}
+
+	return rule, rule.validate()
+}
+
+func (r Rule) validate() error {
+	switch r.Freq {
+	case Daily, Weekly, Monthly:
+	case "":
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	default:
+		return fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRule, r.Freq)
+	}
+	if r.Interval < 1 {
+		return fmt.Errorf("%w: INTERVAL must be positive", ErrInvalidRule)
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("%w: COUNT must be positive", ErrInvalidRule)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("%w: COUNT and UNTIL are exclusive", ErrInvalidRule)
+	}
+	if len(r.ByDay) > 0 && r.Freq != Weekly {
+		return fmt.Errorf("%w: BYDAY needs FREQ=WEEKLY", ErrInvalidRule)
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq != Monthly {
+		return fmt.Errorf("%w: BYMONTHDAY needs FREQ=MONTHLY", ErrInvalidRule)
+	}
+	return nil
+}
+
+// Next returns the first occurrence strictly after after, along with its position in the series. start is
+// the occurrence at position seq, 1 for the first one of the series; occurrences are generated in the
+// location of start, so a daily 09:00 stays at 09:00 across daylight saving changes. ok is false once
+// the series has ended.
+func (r Rule) Next(start time.Time, seq int, after time.Time) (next time.Time, nextSeq int, ok bool) {
+	until := r.Until
+	if r.untilDate {
+		y, m, d := until.Date()
+		until = time.Date(y, m, d+1, 0, 0, 0, 0, start.Location()).Add(-time.Nanosecond)
+	}
+
+	for period := 0; period < _maxPeriods; period++ {
+		for _, occurrence := range r.period(start, period) {
+			if !occurrence.After(start) {
+				continue
+			}
+			seq++
+			if r.Count > 0 && seq > r.Count {
+				return time.Time{}, 0, false
+			}
+			if !until.IsZero() && occurrence.After(until) {
+				return time.Time{}, 0, false
+			}
+			if occurrence.After(after) {
+				return occurrence, seq, true
+			}
+		}
+	}
+	return time.Time{}, 0, false
+}
+
+// period returns the occurrences of the n-th period after the one holding start, in ascending order.
+func (r Rule) period(start time.Time, n int) []time.Time {
+	y, m, d := start.Date()
+	hh, mm, ss := start.Clock()
+	loc := start.Location()
+
+	switch r.Freq {
+	case Daily:
+		return []time.Time{time.Date(y, m, d+n*r.Interval, hh, mm, ss, start.Nanosecond(), loc)}
+
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// Weeks start on Monday, as the RFC 5545 WKST default.
+		monday := d - (int(start.Weekday())+6)%7 + n*r.Interval*7
+		occurrences := make([]time.Time, 0, len(days))
+		for _, day := range days {
+			occurrences = append(occurrences, time.Date(y, m, monday+(int(day)+6)%7, hh, mm, ss, start.Nanosecond(), loc))
+		}
+		sortTimes(occurrences)
+		return occurrences
+
+	case Monthly:
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{d}
+		}
+		first := time.Date(y, m+time.Month(n*r.Interval), 1, hh, mm, ss, start.Nanosecond(), loc)
+		last := first.AddDate(0, 1, -1).Day()
+		occurrences := make([]time.Time, 0, len(days))
+		for _, day := range days {
+			if day < 0 {
+				day = last + day + 1
+			}
+			if day < 1 || day > last {
+				continue
+			}
+			occurrences = append(occurrences, first.AddDate(0, 0, day-1))
+		}
+		sortTimes(occurrences)
+		return occurrences
+	}
+	return nil
+}
+
+// String formats the rule back into RRULE syntax.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, strings.ToUpper(day.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if !r.Until.IsZero() {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, name := range strings.Split(value, ",") {
+		day, ok := weekdays[strings.ToUpper(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseMonthDays(value string) ([]int, error) {
+	var days []int
+	for _, s := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, err
+		}
+		if day == 0 || day < -31 || day > 31 {
+			return nil, fmt.Errorf("day %d out of range", day)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseUntil(value string) (time.Time, bool, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, false, nil
+	}
+	until, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("expected YYYYMMDD or YYYYMMDDTHHMMSSZ, got %q", value)
+	}
+	return until, true, nil
+}
+
+func sortTimes(times []time.Time) {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+}
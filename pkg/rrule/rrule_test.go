@@ -0,0 +1,154 @@
+package rrule
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testTable := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "DAILY", input: "FREQ=DAILY"},
+		{name: "WEEKLY", input: "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10"},
+		{name: "MONTHLY", input: "FREQ=MONTHLY;BYMONTHDAY=1,-1;UNTIL=20250101T000000Z"},
+		{name: "NO FREQ", input: "INTERVAL=2", wantErr: true},
+		{name: "YEARLY", input: "FREQ=YEARLY", wantErr: true},
+		{name: "BYDAY NOT WEEKLY", input: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+		{name: "COUNT AND UNTIL", input: "FREQ=DAILY;COUNT=2;UNTIL=20250101", wantErr: true},
+		{name: "BAD WEEKDAY", input: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "ZERO INTERVAL", input: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := Parse(testCase.input)
+			if testCase.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRule)
+				return
+			}
+			require.NoError(t, err)
+
+			again, err := Parse(rule.String())
+			require.NoError(t, err)
+			assert.Equal(t, rule, again)
+		})
+	}
+}
+
+func TestRule_Next(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name     string
+		rule     string
+		start    time.Time
+		seq      int
+		after    time.Time
+		expected []time.Time
+		ends     bool
+	}{
+		{
+			name:  "DAILY KEEPS WALL CLOCK ACROSS DST",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2024, time.March, 30, 9, 0, 0, 0, berlin),
+			seq:   1,
+			expected: []time.Time{
+				time.Date(2024, time.March, 31, 9, 0, 0, 0, berlin),
+				time.Date(2024, time.April, 1, 9, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:  "WEEKLY BYDAY EVERY OTHER WEEK",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR",
+			start: time.Date(2024, time.January, 3, 10, 0, 0, 0, time.UTC), // Wednesday
+			seq:   1,
+			expected: []time.Time{
+				time.Date(2024, time.January, 5, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, time.January, 19, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, time.January, 29, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "MONTHLY SKIPS SHORT MONTHS",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=31",
+			start: time.Date(2024, time.January, 31, 8, 0, 0, 0, time.UTC),
+			seq:   1,
+			expected: []time.Time{
+				time.Date(2024, time.March, 31, 8, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 31, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "MONTHLY LAST DAY",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: time.Date(2024, time.January, 31, 8, 0, 0, 0, time.UTC),
+			seq:   1,
+			expected: []time.Time{
+				time.Date(2024, time.February, 29, 8, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 31, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "COUNT",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: time.Date(2024, time.January, 2, 8, 0, 0, 0, time.UTC),
+			seq:   2,
+			expected: []time.Time{
+				time.Date(2024, time.January, 3, 8, 0, 0, 0, time.UTC),
+			},
+			ends: true,
+		},
+		{
+			name:  "UNTIL DATE COVERS THE DAY",
+			rule:  "FREQ=DAILY;UNTIL=20240102",
+			start: time.Date(2024, time.January, 1, 23, 0, 0, 0, berlin),
+			seq:   1,
+			expected: []time.Time{
+				time.Date(2024, time.January, 2, 23, 0, 0, 0, berlin),
+			},
+			ends: true,
+		},
+		{
+			name:  "SKIPS TO AFTER",
+			rule:  "FREQ=WEEKLY",
+			start: time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC),
+			seq:   1,
+			after: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, time.February, 5, 8, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := Parse(testCase.rule)
+			require.NoError(t, err)
+
+			after := testCase.after
+			if after.IsZero() {
+				after = testCase.start
+			}
+
+			start, seq := testCase.start, testCase.seq
+			for i, expected := range testCase.expected {
+				next, nextSeq, ok := rule.Next(start, seq, after)
+				require.True(t, ok, "occurrence %d", i)
+				assert.True(t, expected.Equal(next), "occurrence %d: expected %s, got %s", i, expected, next)
+				start, seq, after = next, nextSeq, next
+			}
+
+			if testCase.ends {
+				_, _, ok := rule.Next(start, seq, after)
+				assert.False(t, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type config struct {
+	exporter     string
+	endpoint     string
+	insecure     bool
+	serviceName  string
+	sampleRatio  float64
+	providerOpts []sdktrace.TracerProviderOption
+}
+
+// Option -.
+type Option func(*config)
+
+// Exporter is one of ExporterNone, ExporterStdout or ExporterOTLP.
+func Exporter(exporter string) Option {
+	return func(c *config) {
+		c.exporter = exporter
+	}
+}
+
+// Endpoint is the OTLP/HTTP collector address, host:port.
+func Endpoint(endpoint string, insecure bool) Option {
+	return func(c *config) {
+		c.endpoint = endpoint
+		c.insecure = insecure
+	}
+}
+
+// ServiceName -.
+func ServiceName(name string) Option {
+	return func(c *config) {
+		if name != "" {
+			c.serviceName = name
+		}
+	}
+}
+
+// SampleRatio -.
+func SampleRatio(ratio float64) Option {
+	return func(c *config) {
+		if ratio > 0 {
+			c.sampleRatio = ratio
+		}
+	}
+}
+
+// SpanProcessor adds a processor, e.g. one wrapping an in-memory exporter in tests.
+func SpanProcessor(sp sdktrace.SpanProcessor) Option {
+	return func(c *config) {
+		c.providerOpts = append(c.providerOpts, sdktrace.WithSpanProcessor(sp))
+	}
+}
@@ -0,0 +1,82 @@
+// Package tracing implements OpenTelemetry tracer provider setup.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"os"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	_defaultServiceName = "crud-golang"
+)
+
+// Tracing -.
+type Tracing struct {
+	provider *sdktrace.TracerProvider
+}
+
+// New installs a global tracer provider and the W3C trace context propagator.
+func New(opts ...Option) (*Tracing, error) {
+	cfg := &config{
+		exporter:    ExporterNone,
+		serviceName: _defaultServiceName,
+		sampleRatio: 1,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.sampleRatio))),
+	}
+
+	switch cfg.exporter {
+	case ExporterNone, "":
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("tracing - New - stdouttrace.New: %w", err)
+		}
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		exporterOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.endpoint)}
+		if cfg.insecure {
+			exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(context.Background(), exporterOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("tracing - New - otlptracehttp.New: %w", err)
+		}
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("tracing - New - unknown exporter %q", cfg.exporter)
+	}
+
+	providerOpts = append(providerOpts, cfg.providerOpts...)
+	t := &Tracing{provider: sdktrace.NewTracerProvider(providerOpts...)}
+
+	otel.SetTracerProvider(t.provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return t, nil
+}
+
+// Shutdown flushes pending spans.
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	return t.provider.Shutdown(ctx)
+}